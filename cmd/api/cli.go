@@ -0,0 +1,417 @@
+package main
+
+import (
+	"MiniJira/internal/backup"
+	"MiniJira/internal/jira"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commands are client subcommands that talk to a running server over HTTP.
+var commands = map[string]func(args []string) error{
+	"export":      runExport,
+	"import":      runImport,
+	"import-jira": runImportJira,
+	"backup":      runBackup,
+	"backups":     runBackups,
+	"restore":     runRestore,
+}
+
+type client struct {
+	server string
+	token  string
+	http   *http.Client
+}
+
+func clientFlags(fs *flag.FlagSet) *client {
+	c := &client{http: &http.Client{Timeout: 5 * time.Minute}}
+	fs.StringVar(&c.server, "server", envOr("MINIJIRA_SERVER", "http://localhost:8080"), "server base URL")
+	fs.StringVar(&c.token, "token", os.Getenv("MINIJIRA_TOKEN"), "bearer token")
+
+	return c
+}
+
+func envOr(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+
+	return def
+}
+
+func (c *client) do(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequest(method, strings.TrimRight(c.server, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+
+	return resp, nil
+}
+
+// responseError turns a problem+json reply into a readable error.
+func responseError(resp *http.Response) error {
+	var p struct {
+		Detail string `json:"detail"`
+		Code   string `json:"code"`
+		Errors []struct {
+			Field   string `json:"field"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil || p.Code == "" {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	msg := fmt.Sprintf("server returned %s: %s", resp.Status, p.Code)
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	for _, f := range p.Errors {
+		msg += "\n  " + f.Field + ": " + f.Message
+	}
+
+	return errors.New(msg)
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	c := clientFlags(fs)
+	project := fs.String("project", "", "project key (required)")
+	out := fs.String("o", "-", "output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return errors.New("export: -project is required")
+	}
+
+	resp, err := c.do(http.MethodGet, "/api/v2/projects/"+url.PathEscape(*project)+"/export", nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	w := io.Writer(os.Stdout)
+	if *out != "-" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	c := clientFlags(fs)
+	in := fs.String("f", "-", "archive file, - for stdin")
+	key := fs.String("key", "", "import under this project key instead of the archived one")
+	name := fs.String("name", "", "import under this project name instead of the archived one")
+	dryRun := fs.Bool("dry-run", false, "validate and report without storing anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	r := io.Reader(os.Stdin)
+	if *in != "-" {
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	q := url.Values{}
+	if *key != "" {
+		q.Set("key", *key)
+	}
+	if *name != "" {
+		q.Set("name", *name)
+	}
+	if *dryRun {
+		q.Set("dry_run", strconv.FormatBool(true))
+	}
+
+	path := "/api/v2/projects/import"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	return c.post(path, r)
+}
+
+func printJSON(data []byte) error {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, data, "", "  "); err != nil {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if !bytes.HasSuffix(pretty.Bytes(), []byte("\n")) {
+		pretty.WriteByte('\n')
+	}
+	_, err := pretty.WriteTo(os.Stdout)
+	return err
+}
+
+// runImportJira converts a Jira export into one archive per project,
+// prints the mapping report and imports the archives one by one.
+func runImportJira(args []string) error {
+	fs := flag.NewFlagSet("import-jira", flag.ContinueOnError)
+	c := clientFlags(fs)
+	in := fs.String("f", "", "Jira export file (required)")
+	format := fs.String("format", "", "json or csv (default: from the file extension)")
+	mappingFile := fs.String("mapping", "", "JSON file overriding project keys, statuses, types, priorities and link types")
+	dryRun := fs.Bool("dry-run", false, "validate and report without storing anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errors.New("import-jira: -f is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*in)), ".")
+	}
+
+	var mapping jira.Mapping
+	if *mappingFile != "" {
+		data, err := os.ReadFile(*mappingFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &mapping); err != nil {
+			return fmt.Errorf("import-jira: mapping file: %w", err)
+		}
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var issues []jira.Issue
+	switch *format {
+	case "json":
+		issues, err = jira.ParseJSON(f)
+	case "csv":
+		issues, err = jira.ParseCSV(f)
+	default:
+		return fmt.Errorf("import-jira: unknown format %q, use -format json or csv", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	archives, report := jira.Convert(issues, mapping, time.Now().UTC())
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(data); err != nil {
+		return err
+	}
+
+	path := "/api/v2/projects/import"
+	if *dryRun {
+		path += "?dry_run=true"
+	}
+	for _, a := range archives {
+		body, err := json.Marshal(a)
+		if err != nil {
+			return err
+		}
+		if err := c.post(path, bytes.NewReader(body)); err != nil {
+			return fmt.Errorf("import-jira: project %s: %w", a.Project.Key, err)
+		}
+	}
+
+	return nil
+}
+
+// runBackup takes a backup on the server and, with -o, downloads it.
+func runBackup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	c := clientFlags(fs)
+	out := fs.String("o", "", "also download the backup to this file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	resp, err := c.do(http.MethodPost, "/api/v2/admin/backups", nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := printJSON(body); err != nil {
+		return err
+	}
+	if *out == "" {
+		return nil
+	}
+
+	var meta backup.Meta
+	if err := json.Unmarshal(body, &meta); err != nil {
+		return err
+	}
+
+	return c.download("/api/v2/admin/backups/"+url.PathEscape(meta.ID), *out)
+}
+
+func (c *client) download(path, out string) error {
+	resp, err := c.do(http.MethodGet, path, nil, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func runBackups(args []string) error {
+	fs := flag.NewFlagSet("backups", flag.ContinueOnError)
+	c := clientFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	list, err := c.listBackups()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(data)
+}
+
+func (c *client) listBackups() ([]backup.Meta, error) {
+	resp, err := c.do(http.MethodGet, "/api/v2/admin/backups", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var list []backup.Meta
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// runRestore restores a backup kept on the server (-id, or the newest one
+// taken at or before -at) or uploads a backup file (-f).
+func runRestore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	c := clientFlags(fs)
+	id := fs.String("id", "", "backup ID on the server")
+	at := fs.String("at", "", "restore the newest backup taken at or before this RFC 3339 time (the nearest earlier snapshot, not the exact state at that time)")
+	in := fs.String("f", "", "backup file to upload")
+	dryRun := fs.Bool("dry-run", false, "verify the backup without restoring it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	set := 0
+	for _, v := range []string{*id, *at, *in} {
+		if v != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("restore: exactly one of -id, -at and -f is required")
+	}
+
+	q := url.Values{}
+	if *dryRun {
+		q.Set("dry_run", strconv.FormatBool(true))
+	}
+
+	path := "/api/v2/admin/backups/" + url.PathEscape(*id) + "/restore"
+	var body io.Reader
+	switch {
+	case *in != "":
+		f, err := os.Open(*in)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		path, body = "/api/v2/admin/restore", f
+	case *at != "":
+		if _, err := time.Parse(time.RFC3339, *at); err != nil {
+			return fmt.Errorf("restore: -at: %w", err)
+		}
+		q.Set("at", *at)
+		path = "/api/v2/admin/restore"
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	return c.post(path, body)
+}
+
+// post sends body as JSON and prints the server's reply.
+func (c *client) post(path string, body io.Reader) error {
+	resp, err := c.do(http.MethodPost, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(data)
+}
@@ -2,14 +2,29 @@
 // @Version 0.1
 // @Description ...
 // @BasePath /
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description "Bearer " followed by ADMIN_TOKEN.
 package main
 
 import (
+	"MiniJira/internal/automation"
+	"MiniJira/internal/backup"
 	"MiniJira/internal/config"
+	"MiniJira/internal/events"
+	"MiniJira/internal/health"
 	"MiniJira/internal/httpapi"
+	"MiniJira/internal/httpapi/middleware"
+	"MiniJira/internal/scheduler"
+	"MiniJira/internal/sla"
 	"MiniJira/internal/store/memory"
+	"MiniJira/internal/tracing"
+	"MiniJira/internal/usecase"
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
@@ -20,6 +35,23 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		cmd, ok := commands[os.Args[1]]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown command %q (available: export, import, import-jira, backup, backups, restore)\n", os.Args[1])
+			os.Exit(2)
+		}
+		err := cmd(os.Args[2:])
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	s := memory.NewStore()
 	cfg, err := config.LoadConfig()
 	if err != nil {
@@ -40,7 +72,43 @@ func main() {
 
 	logger.Info("starting server")
 
-	mux := httpapi.NewMux(s, s, s, logger)
+	tracer := newTracer(cfg)
+	bus := events.NewBus()
+	service := usecase.NewService(s, bus, tracer)
+
+	runCtx, stopRunning := context.WithCancel(context.Background())
+	defer stopRunning()
+	go automation.NewEngine(service, logger).Run(runCtx)
+
+	backups := backup.NewManager(s, cfg.BackupDir)
+	jobs, err := newJobs(cfg, service, backups, logger)
+	if err != nil {
+		logger.Fatal(err)
+	}
+	jobs.Start()
+
+	probe := health.NewProbe(2*time.Second,
+		health.PingCheck("store", s),
+		health.BacklogCheck("event_bus", bus, cfg.EventBacklogLimit),
+	)
+	if cfg.DataDir != "" {
+		probe.Add(health.DiskSpaceCheck("disk", cfg.DataDir, uint64(cfg.DiskMinFreeMB)<<20))
+	}
+
+	mux := httpapi.NewMux(httpapi.Deps{
+		Service: service,
+		Health:  probe,
+		Logger:  logger,
+		Tracer:  tracer,
+
+		Backups:    backups,
+		Jobs:       jobs,
+		AdminToken: cfg.AdminToken,
+
+		IdempotencyTTL: cfg.IdempotencyTTL,
+		ReadLimit:      middleware.RateLimit{Rate: float64(cfg.ReadRPS), Burst: cfg.ReadBurst},
+		WriteLimit:     middleware.RateLimit{Rate: float64(cfg.WriteRPS), Burst: cfg.WriteBurst},
+	})
 
 	srv := &http.Server{Addr: ":" + cfg.HTTPPort, Handler: mux}
 
@@ -58,6 +126,10 @@ func main() {
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 	<-sigCh
 
+	probe.SetDraining()
+	logger.WithField("delay", cfg.DrainDelay).Info("draining connections")
+	time.Sleep(cfg.DrainDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
@@ -65,6 +137,49 @@ func main() {
 	if err != nil {
 		logger.WithError(err).Fatal("error shutting down server")
 	}
+	err = jobs.Shutdown(ctx)
+	if err != nil {
+		logger.WithError(err).Error("background jobs did not finish in time")
+	}
+	stopRunning()
+
+	err = tracer.Shutdown(ctx)
+	if err != nil {
+		logger.WithError(err).Error("error flushing traces")
+	}
 
 	return
 }
+
+// newJobs registers the periodic background work.
+func newJobs(cfg config.Config, service *usecase.Service, backups *backup.Manager, logger logrus.FieldLogger) (*scheduler.Scheduler, error) {
+	jobs := scheduler.New(logger)
+	if cfg.SLACheckInterval > 0 {
+		err := jobs.Add("sla-check", scheduler.Every(cfg.SLACheckInterval), sla.NewChecker(service, logger).Check)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.BackupSchedule != nil {
+		err := jobs.Add("backup", cfg.BackupSchedule, func(context.Context) error {
+			_, err := backups.Create()
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return jobs, nil
+}
+
+func newTracer(cfg config.Config) *tracing.Tracer {
+	switch cfg.TraceExporter {
+	case "stdout":
+		return tracing.NewTracer(tracing.NewStdoutExporter(os.Stdout))
+	case "otlp":
+		return tracing.NewTracer(tracing.NewOTLPExporter(cfg.OTLPEndpoint, cfg.ServiceName))
+	}
+
+	return nil
+}
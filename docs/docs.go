@@ -15,42 +15,90 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/api/v2/admin/backups": {
             "get": {
-                "description": "Check service availability",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Backups in the backup directory, oldest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "system"
+                    "admin"
                 ],
-                "summary": "Health check",
+                "summary": "List backups",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpapi.HealthResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.BackupResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Takes a consistent snapshot of the whole store while the server keeps serving and stores it in the backup directory.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create backup",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.BackupResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/issue": {
+        "/api/v2/admin/backups/{id}": {
             "get": {
-                "description": "Returns issue by ID",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "The backup file (format minijira.backup), for keeping a copy elsewhere.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "issues"
+                    "admin"
                 ],
-                "summary": "Get issue by id",
+                "summary": "Download backup",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Issue ID",
+                        "type": "string",
+                        "description": "Backup ID",
                         "name": "id",
-                        "in": "query",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -58,7 +106,59 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpapi.IssueResponse"
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/admin/backups/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Verifies the checksum of a stored backup and replaces the whole store with it. With dry_run=true only the checks run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Restore backup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backup ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Verify only",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.RestoreResponse"
                         }
                     },
                     "400": {
@@ -67,14 +167,48 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/v2/admin/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Scheduled background jobs with their next run and the outcome of their latest run.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List background jobs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.JobResponse"
+                            }
+                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
@@ -82,22 +216,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/issues": {
+        "/api/v2/admin/jobs/{name}": {
             "get": {
-                "description": "Returns issues for a project (filter is required)",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "issues"
+                    "admin"
                 ],
-                "summary": "List issues by project key",
+                "summary": "Get background job",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project key",
-                        "name": "project_key",
-                        "in": "query",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -105,58 +243,57 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/httpapi.IssueResponse"
-                            }
+                            "$ref": "#/definitions/httpapi.JobResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/v2/admin/jobs/{name}/run": {
             "post": {
-                "description": "Create an issue in existing project",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Starts a job now, outside its schedule, and returns without waiting for it. Fails if the job is already running.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "issues"
+                    "admin"
                 ],
-                "summary": "Create issue",
+                "summary": "Run background job",
                 "parameters": [
                     {
-                        "description": "Issue payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/httpapi.CreateIssueRequest"
-                        }
+                        "type": "string",
+                        "description": "Job name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/httpapi.IssueResponse"
+                            "$ref": "#/definitions/httpapi.JobResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
@@ -167,8 +304,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
@@ -176,9 +319,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/issues/transition": {
+        "/api/v2/admin/restore": {
             "post": {
-                "description": "Change issue status following allowed transitions",
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Verifies the checksum of an uploaded backup file and replaces the whole store with it. With at, the body is ignored and the stored backup nearest before that time is restored instead: backups are snapshots, so changes made between it and at are lost. With dry_run=true only the checks run.",
                 "consumes": [
                     "application/json"
                 ],
@@ -186,17 +334,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "issues"
+                    "admin"
                 ],
-                "summary": "Transition issue status",
+                "summary": "Restore uploaded backup",
                 "parameters": [
                     {
-                        "description": "Transition payload",
+                        "type": "string",
+                        "description": "Restore the newest stored backup taken at or before this RFC 3339 time",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Verify only",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Backup file",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpapi.TransitionIssueRequest"
+                            "type": "object"
                         }
                     }
                 ],
@@ -204,7 +363,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpapi.IssueResponse"
+                            "$ref": "#/definitions/httpapi.RestoreResponse"
                         }
                     },
                     "400": {
@@ -213,20 +372,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/httpapi.ErrorResponse"
-                        }
-                    },
-                    "409": {
-                        "description": "Conflict",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
@@ -234,35 +387,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/projects": {
+        "/api/v2/automation/rules/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "v2"
+                ],
+                "summary": "Get an automation rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "List projects",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/httpapi.ProjectResponse"
-                            }
+                            "$ref": "#/definitions/httpapi.RuleResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Create a new project with unique key",
+            "delete": {
+                "description": "The audit log keeps the executions of the rule.",
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Delete an automation rule",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
                 "consumes": [
                     "application/json"
                 ],
@@ -270,25 +467,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "projects"
+                    "v2"
                 ],
-                "summary": "Create project",
+                "summary": "Update an automation rule",
                 "parameters": [
                     {
-                        "description": "Project payload",
+                        "type": "integer",
+                        "description": "Rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/httpapi.CreateProjectRequest"
+                            "$ref": "#/definitions/httpapi.UpdateRuleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/httpapi.ProjectResponse"
+                            "$ref": "#/definitions/httpapi.RuleResponse"
                         }
                     },
                     "400": {
@@ -297,128 +501,5787 @@ const docTemplate = `{
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/components/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get a component",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Component ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ComponentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "A new lead applies to issues created afterwards; existing issues keep their assignee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Update a component",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Component ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateComponentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ComponentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
                     "409": {
                         "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/httpapi.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/v2/custom-fields/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get a custom field",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Custom field ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CustomFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the field and its values from every issue of the project.",
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Delete a custom field",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Custom field ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "The key and type are fixed. Existing values are checked against a new required flag or options only when an issue next changes the field.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Update a custom field",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Custom field ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateCustomFieldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CustomFieldResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/bulk": {
+            "post": {
+                "description": "Applies a transition, assignee change, label change or sprint move to issue_ids or to a query result (at most 500 issues).\nEach item reports its own outcome. With atomic=true the first failure rolls back the whole batch; committed is then false and the other items fail with code rolled_back.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Apply one change to many issues",
+                "parameters": [
+                    {
+                        "description": "Bulk operation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.BulkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.BulkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get issue by id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Only fields present in the body are changed. Status changes go through transitions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Update issue fields",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/comments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List comments of an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.CommentResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Comment on an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.AddCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CommentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/history": {
+            "get": {
+                "description": "Oldest first. The first change has no from and marks the creation of the issue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List status changes of an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.StatusChangeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/links": {
+            "get": {
+                "description": "Links with the issue on either end.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List links of an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.LinkResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Link issue to another issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Link",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.LinkIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.LinkResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/time-tracking": {
+            "get": {
+                "description": "Sums the issue and every issue below it, so an epic includes its stories and their subtasks.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Time tracking of an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.TimeTrackingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/transitions": {
+            "post": {
+                "description": "The hooks of the workflow transition run first: a failed condition answers 403, a failed validator 400.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Transition issue status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.TransitionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/issues/{id}/worklogs": {
+            "get": {
+                "description": "In the order they were logged.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List worklogs of an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.WorklogResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds to the time spent on the issue and lowers its remaining estimate by the same amount, never below zero. Issues without a time estimate keep none.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Log work on an issue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Worklog",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.LogWorkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.WorklogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List projects",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.ProjectResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create project",
+                "parameters": [
+                    {
+                        "description": "Project payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ProjectResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created project"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/import": {
+            "post": {
+                "description": "Recreates an exported project. New IDs are assigned and returned as old-to-new maps.\nkey and name override the archived project key and name. With dry_run=true nothing is stored and the report shows what would be created.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Import project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Target project key",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target project name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Project archive",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/archive.Archive"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ImportReportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get project by key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ProjectResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/automation/audit": {
+            "get": {
+                "description": "Rule executions, newest first. SKIPPED entries are rules stopped by loop protection: a rule runs at most once per chain, and a chain holds at most 5 rules.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Automation audit log of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of entries (default 100, 0 for all kept)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.RuleExecutionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/automation/rules": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List automation rules of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.RuleResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Triggers: issue.created, issue.updated, issue.transitioned, subtasks.done (the subject is the parent once all its subtasks are done).\nConditions: type, priority, status, assignee, label, from_status, to_status.\nActions: assign, add_label, remove_label, set_priority, transition, comment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create an automation rule in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.RuleResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created rule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/components": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List components of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.ComponentResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Names are unique within a project, ignoring case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create a component in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Component",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateComponentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ComponentResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created component"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/custom-fields": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List custom fields of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.CustomFieldResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Keys are lowercase letters, digits and underscores starting with a letter, unique within the project, and cannot change.\nIssues carry the values under custom_fields by key. New issues must set every required field.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create a custom field in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Custom field",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateCustomFieldRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CustomFieldResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created custom field"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/export": {
+            "get": {
+                "description": "Streams the project with its sprints, components, versions, custom fields and issues as a versioned JSON archive (format minijira.project).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Export project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/archive.Archive"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/issues": {
+            "get": {
+                "description": "Issues in board order, optionally filtered. sprint=0 selects the backlog.\nCustom fields filter as cf.\u003ckey\u003e=value, for example cf.severity=S1; a multi_select field matches issues that have the option.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List issues of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "OPEN",
+                            "IN_PROGRESS",
+                            "DONE"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assignee",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "sprint",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fix version ID, 0 for issues without one",
+                        "name": "fix_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Component ID, 0 for issues without one",
+                        "name": "component",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.IssueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create issue in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Issue payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateIssueV2Request"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created issue"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/issues/csv": {
+            "get": {
+                "description": "Issues in board order with the same filters as the issue list.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Export issues as CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated columns: id,project_key,title,status,rank,assignee,labels,sprint_id",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "OPEN",
+                            "IN_PROGRESS",
+                            "DONE"
+                        ],
+                        "type": "string",
+                        "description": "Status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assignee",
+                        "name": "assignee",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "sprint",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Fix version ID, 0 for issues without one",
+                        "name": "fix_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Component ID, 0 for issues without one",
+                        "name": "component",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV with a header line",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates one issue per row. The header names the columns; title, assignee, labels and cf.\u003ckey\u003e custom fields are read, other columns are ignored.\nmap renames headers, e.g. Summary:title,Owner:assignee. Rows are created in chunks of 500 as the file is read, each chunk in one transaction; each failing row is reported without stopping the import.",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Import issues from CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Header to field mapping",
+                        "name": "map",
+                        "in": "query"
+                    },
+                    {
+                        "description": "CSV file",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CSVImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/reports/burndown": {
+            "get": {
+                "description": "Remaining work at the end of each sprint day, counted in issues or story points, with the ideal line from the commitment to zero.\nWithout sprint the active sprint is used.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Sprint burndown",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "sprint",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "issues",
+                            "points"
+                        ],
+                        "type": "string",
+                        "description": "Unit of work",
+                        "name": "unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.BurndownResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/reports/cumulative-flow": {
+            "get": {
+                "description": "Issues per status at the end of each day, for spotting bottlenecks. Days are calendar days in tz; the current day counts up to now.\nfrom and to are dates, to included; the default range is the last 30 days. Issues created before from are counted from their history.\nThe CSV has one row per day and a column per status. The issue list filters, such as component, narrow the issues counted.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Cumulative flow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-09-01",
+                        "description": "First day",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-09-30",
+                        "description": "Last day",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Europe/Berlin",
+                        "description": "IANA time zone (default UTC)",
+                        "name": "tz",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Component ID, 0 for issues without one",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CumulativeFlowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/reports/cycle-time": {
+            "get": {
+                "description": "Cycle time runs from the first move out of a TODO status to the last move into DONE, lead time from creation to DONE.\nfrom and to are RFC 3339 times or dates; a date as to includes that day. The default range is the last 30 days.\nThe CSV has one row per issue and a seconds column per status. The issue list filters, such as component, narrow the issues covered.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Time in status, cycle time and lead time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-09-01",
+                        "description": "Start of the range",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-09-30",
+                        "description": "End of the range",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Component ID, 0 for issues without one",
+                        "name": "component",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.FlowReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/reports/velocity": {
+            "get": {
+                "description": "Committed and completed issues and story points of the last closed sprints, oldest first, with the average completed work.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Sprint velocity",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of closed sprints (default 5, max 50)",
+                        "name": "sprints",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VelocityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/slas": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List SLA policies of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.SLAPolicyResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Statuses must belong to the project's workflow, and a stop status may not also start or pause the timer. Timers show up in the sla field of issues; a background check publishes an sla.breached event once per issue and policy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create an SLA policy in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "SLA policy",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateSLAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SLAPolicyResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created policy"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/sprints": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List sprints of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.SprintResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create sprint in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sprint payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateSprintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SprintResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/versions": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "List versions of a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.VersionResponse"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Versions start UNRELEASED. Names are unique within a project, ignoring case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Create a version in a project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Version",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VersionResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the created version"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/projects/{key}/workflow": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get project workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.WorkflowResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Transitions may name hooks. Built in are the validator assignee_required and the post function clear_assignee; conditions come only from hooks registered in code. The resolution and resolved_at are always handled by the transition itself.\nEvery issue of the project must stay in a listed status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Replace project workflow",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Workflow",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.WorkflowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.WorkflowResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/slas/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "SLA policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SLAPolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Delete an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "SLA policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Timers are recomputed from the status history of issues, so a change applies to past time too.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Update an SLA policy",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "SLA policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateSLAPolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SLAPolicyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sprints/{id}/close": {
+            "post": {
+                "description": "Records the completed issues and story points and moves unfinished issues to move_to_sprint_id or the backlog.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Close the active sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Where unfinished issues go",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CloseSprintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SprintResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sprints/{id}/start": {
+            "post": {
+                "description": "Makes a future sprint the active one and records the issues and story points in it as the commitment. A project has at most one active sprint.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Start a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Planned end",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.StartSprintRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.SprintResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/sprints/{id}/time-tracking": {
+            "get": {
+                "description": "Sums the issues currently in the sprint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Time tracking of a sprint",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sprint ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.TimeTrackingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/versions/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Get a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Update a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/versions/{id}/archive": {
+            "post": {
+                "description": "Archived versions keep their issues but cannot be set as a fix version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Archive a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/versions/{id}/release": {
+            "post": {
+                "description": "Issues of the version that are not done must be moved to move_to_version_id, another unreleased version of the project; without it the release fails with unfinished_issues.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Release a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Release",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ReleaseVersionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.VersionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/versions/{id}/release-notes": {
+            "get": {
+                "description": "The done issues of the version grouped by issue type, as JSON or Markdown.",
+                "produces": [
+                    "application/json",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Release notes of a version",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Version ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "markdown"
+                        ],
+                        "type": "string",
+                        "description": "Response format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ReleaseNotesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/worklogs/{id}": {
+            "delete": {
+                "description": "Gives the logged time back to the remaining estimate of the issue.",
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Delete a worklog",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Worklog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "A change in time spent moves the remaining estimate of the issue the other way.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "v2"
+                ],
+                "summary": "Edit a worklog",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Worklog ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.UpdateWorklogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.WorklogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Check service availability",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/issue": {
+            "get": {
+                "description": "Returns issue by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issues"
+                ],
+                "summary": "Get issue by id",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Issue ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/issues": {
+            "get": {
+                "description": "Returns issues for a project (filter is required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issues"
+                ],
+                "summary": "List issues by project key",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project key",
+                        "name": "project_key",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.IssueResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create an issue in existing project",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issues"
+                ],
+                "summary": "Create issue",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Issue payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/issues/transition": {
+            "post": {
+                "description": "Change issue status following allowed transitions. API v1 has no resolution, so an issue it moves into the DONE category from another is resolved as DONE, and one already there keeps its resolution; use API v2 to choose the resolution.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "issues"
+                ],
+                "summary": "Transition issue status",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Transition payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.TransitionIssueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.IssueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Reports that the process is running; does not check dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/projects": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "List projects",
+                "deprecated": true,
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/httpapi.ProjectResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new project with unique key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "projects"
+                ],
+                "summary": "Create project",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "Project payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Runs dependency checks; returns 503 when any check fails or the server is shutting down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "system"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ReadinessResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ReadinessResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws/board": {
+            "get": {
+                "description": "WebSocket endpoint. Clients send BoardRequest messages (subscribe, unsubscribe, transition, rank)\nand receive BoardMessage responses correlated by request_id, plus events for subscribed projects.",
+                "tags": [
+                    "board"
+                ],
+                "summary": "Board collaboration channel",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/httpapi.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "archive.Archive": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Component"
+                    }
+                },
+                "custom_fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.CustomField"
+                    }
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Issue"
+                    }
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Link"
+                    }
+                },
+                "project": {
+                    "$ref": "#/definitions/archive.Project"
+                },
+                "sprints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Sprint"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "versions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.ProjectVersion"
+                    }
+                },
+                "workflow": {
+                    "description": "Workflow is nil for projects on the default workflow.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/archive.Workflow"
+                        }
+                    ]
+                }
+            }
+        },
+        "archive.Comment": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.Component": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lead": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.CustomField": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.Issue": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string"
+                },
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Comment"
+                    }
+                },
+                "component_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "description": "CreatedAt and History are absent in archives before version 3.",
+                    "type": "string"
+                },
+                "custom_fields": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "fix_version_id": {
+                    "type": "integer"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.StatusChange"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_estimate_seconds": {
+                    "description": "The fields below are absent before version 6. The time spent is not\narchived: it is the sum of the worklogs.",
+                    "type": "integer"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "priority": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "remaining_estimate_seconds": {
+                    "type": "integer"
+                },
+                "resolution": {
+                    "description": "Resolution and ResolvedAt are absent before version 5; issues of\nolder archives in a DONE status are imported as resolved DONE.",
+                    "type": "string"
+                },
+                "resolved_at": {
+                    "type": "string"
+                },
+                "sprint_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "story_points": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "worklogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.Worklog"
+                    }
+                }
+            }
+        },
+        "archive.Link": {
+            "type": "object",
+            "properties": {
+                "from_id": {
+                    "type": "integer"
+                },
+                "to_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.Project": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.ProjectVersion": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "released_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.Sprint": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "committed_issues": {
+                    "type": "integer"
+                },
+                "committed_points": {
+                    "type": "integer"
+                },
+                "completed_issues": {
+                    "type": "integer"
+                },
+                "completed_points": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "incomplete": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.StatusChange": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.Workflow": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.WorkflowStatus"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/archive.WorkflowTransition"
+                    }
+                }
+            }
+        },
+        "archive.WorkflowStatus": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "archive.WorkflowTransition": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "post_functions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "archive.Worklog": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "reduced_seconds": {
+                    "type": "integer"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "time_spent_seconds": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "httpapi.AddCommentRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Reproduced on staging."
+                }
+            }
+        },
+        "httpapi.BackupResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-18T12:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "20261018T120000.000Z"
+                },
+                "sha256": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "httpapi.BulkItemError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_transition"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "invalid transition"
+                }
+            }
+        },
+        "httpapi.BulkItemResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/httpapi.BulkItemError"
+                },
+                "issue": {
+                    "$ref": "#/definitions/httpapi.IssueResponse"
+                },
+                "issue_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "ok": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "httpapi.BulkQuery": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "label": {
+                    "type": "string",
+                    "example": "release-1.4"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "sprint_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "httpapi.BulkRequest": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "transition",
+                        "assign",
+                        "label",
+                        "move_to_sprint"
+                    ],
+                    "example": "transition"
+                },
+                "add_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "released"
+                    ]
+                },
+                "assignee": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "atomic": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "issue_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        10,
+                        11,
+                        12
+                    ]
+                },
+                "query": {
+                    "$ref": "#/definitions/httpapi.BulkQuery"
+                },
+                "remove_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "triage"
+                    ]
+                },
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "FIXED",
+                        "WONT_DO",
+                        "DUPLICATE",
+                        "CANNOT_REPRODUCE",
+                        "DONE"
+                    ],
+                    "example": "FIXED"
+                },
+                "sprint_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "to_status": {
+                    "type": "string",
+                    "example": "DONE"
+                }
+            }
+        },
+        "httpapi.BulkResponse": {
+            "type": "object",
+            "properties": {
+                "committed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.BulkItemResponse"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "httpapi.BurndownDayResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-07"
+                },
+                "ideal": {
+                    "type": "number",
+                    "example": 10.5
+                },
+                "remaining": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "httpapi.BurndownResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.BurndownDayResponse"
+                    }
+                },
+                "scope": {
+                    "type": "integer",
+                    "example": 34
+                },
+                "sprint": {
+                    "$ref": "#/definitions/httpapi.SprintResponse"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "issues",
+                        "points"
+                    ],
+                    "example": "points"
+                }
+            }
+        },
+        "httpapi.CSVImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.CSVRowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "truncated": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "httpapi.CSVRowError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "required"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must not be empty"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "httpapi.CalendarBody": {
+            "type": "object",
+            "properties": {
+                "closes": {
+                    "type": "string",
+                    "example": "17:00"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2026-12-25"
+                    ]
+                },
+                "opens": {
+                    "type": "string",
+                    "example": "09:00"
+                },
+                "time_zone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "workdays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "MON",
+                            "TUE",
+                            "WED",
+                            "THU",
+                            "FRI",
+                            "SAT",
+                            "SUN"
+                        ]
+                    },
+                    "example": [
+                        "MON",
+                        "TUE",
+                        "WED",
+                        "THU",
+                        "FRI"
+                    ]
+                }
+            }
+        },
+        "httpapi.CheckResponse": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "number",
+                    "example": 0.02
+                },
+                "error": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "store"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "fail"
+                    ],
+                    "example": "ok"
+                }
+            }
+        },
+        "httpapi.CloseSprintRequest": {
+            "type": "object",
+            "properties": {
+                "move_to_sprint_id": {
+                    "type": "integer",
+                    "example": 4
+                }
+            }
+        },
+        "httpapi.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "body": {
+                    "type": "string",
+                    "example": "Reproduced on staging."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "issue_id": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "httpapi.ComponentResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Cart, checkout and receipts"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "lead": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Checkout"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                }
+            }
+        },
+        "httpapi.CreateComponentRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Cart, checkout and receipts"
+                },
+                "lead": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Checkout"
+                }
+            }
+        },
+        "httpapi.CreateCustomFieldRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "severity"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Severity"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "S1",
+                        "S2",
+                        "S3"
+                    ]
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "select",
+                        "multi_select",
+                        "date",
+                        "user"
+                    ],
+                    "example": "select"
+                }
+            }
+        },
+        "httpapi.CreateIssueRequest": {
+            "type": "object",
+            "properties": {
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix checkout validation"
+                }
+            }
+        },
+        "httpapi.CreateIssueV2Request": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "component_ids": {
+                    "description": "Without an assignee the issue goes to the lead of the first\ncomponent that has one.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "custom_fields": {
+                    "description": "CustomFields must include the required fields of the project.",
+                    "type": "object"
+                },
+                "fix_version_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "original_estimate_seconds": {
+                    "description": "The remaining estimate defaults to the original one.",
+                    "type": "integer",
+                    "example": 28800
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "HIGHEST",
+                        "HIGH",
+                        "MEDIUM",
+                        "LOW",
+                        "LOWEST"
+                    ],
+                    "example": "HIGH"
+                },
+                "remaining_estimate_seconds": {
+                    "type": "integer",
+                    "example": 28800
+                },
+                "story_points": {
+                    "description": "StoryPoints is the estimate used by sprint reports.",
+                    "type": "integer",
+                    "example": 3
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix checkout validation"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "TASK",
+                        "BUG",
+                        "STORY",
+                        "EPIC",
+                        "SUBTASK"
+                    ],
+                    "example": "BUG"
+                }
+            }
+        },
+        "httpapi.CreateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Payments"
+                }
+            }
+        },
+        "httpapi.CreateRuleRequest": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleAction"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleCondition"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Escalate blocker bugs"
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "issue.created"
+                }
+            }
+        },
+        "httpapi.CreateSLAPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "calendar": {
+                    "$ref": "#/definitions/httpapi.CalendarBody"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "First response"
+                },
+                "pause_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "OPEN"
+                    ]
+                },
+                "stop_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "IN_PROGRESS"
+                    ]
+                },
+                "target_seconds": {
+                    "type": "integer",
+                    "example": 14400
+                }
+            }
+        },
+        "httpapi.CreateSprintRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Sprint 12"
+                }
+            }
+        },
+        "httpapi.CreateVersionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Checkout rework"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "1.4.0"
+                },
+                "release_date": {
+                    "description": "ReleaseDate is the planned release day.",
+                    "type": "string",
+                    "example": "2026-11-02"
+                }
+            }
+        },
+        "httpapi.CumulativeFlowResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.FlowDayResponse"
+                    }
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-09-19"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "OPEN",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-10-18"
+                }
+            }
+        },
+        "httpapi.CustomFieldResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "severity"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Severity"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "S1",
+                        "S2",
+                        "S3"
+                    ]
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "number",
+                        "select",
+                        "multi_select",
+                        "date",
+                        "user"
+                    ],
+                    "example": "select"
+                }
+            }
+        },
+        "httpapi.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_issue"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "invalid issue: title: must not be empty"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "invalid request"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.FieldErrorResponse"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/issues"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2b7d9e0a3c5b6d7e8f90"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Invalid issue"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:minijira:problem:invalid_issue"
+                }
+            }
+        },
+        "httpapi.FieldErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "required"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "title"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must not be empty"
+                }
+            }
+        },
+        "httpapi.FlowDayResponse": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-07"
+                }
+            }
+        },
+        "httpapi.FlowReportResponse": {
+            "type": "object",
+            "properties": {
+                "cycle_time": {
+                    "$ref": "#/definitions/httpapi.PercentilesResponse"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "2026-09-18T00:00:00Z"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.IssueTimesResponse"
+                    }
+                },
+                "lead_time": {
+                    "$ref": "#/definitions/httpapi.PercentilesResponse"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "OPEN",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ]
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2026-10-18T00:00:00Z"
+                }
+            }
+        },
+        "httpapi.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "httpapi.ImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "integer",
+                    "example": 17
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "issue_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "issues": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "links": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "project": {
+                    "$ref": "#/definitions/httpapi.ProjectResponse"
+                },
+                "sprint_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "sprints": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "worklogs": {
+                    "type": "integer",
+                    "example": 9
+                }
+            }
+        },
+        "httpapi.IssueResponse": {
+            "type": "object",
+            "properties": {
+                "assignee": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "component_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2
+                    ]
+                },
+                "created_at": {
+                    "description": "CreatedAt is absent for issues stored before creation times were kept.",
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
+                "custom_fields": {
+                    "description": "CustomFields holds the custom field values by field key. Every value\nis a list; only multi_select fields have more than one entry.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "fix_version_id": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend",
+                        "checkout"
+                    ]
+                },
+                "original_estimate_seconds": {
+                    "description": "The time estimates are absent for issues without one; time spent is\nthe sum of the worklogs.",
+                    "type": "integer",
+                    "example": 28800
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "priority": {
+                    "type": "string",
+                    "enum": [
+                        "HIGHEST",
+                        "HIGH",
+                        "MEDIUM",
+                        "LOW",
+                        "LOWEST"
+                    ],
+                    "example": "MEDIUM"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remaining_estimate_seconds": {
+                    "type": "integer",
+                    "example": 14400
+                },
+                "resolution": {
+                    "description": "Resolution and ResolvedAt are set while the issue is in a DONE\nstatus; reopening clears them.",
+                    "type": "string",
+                    "enum": [
+                        "FIXED",
+                        "WONT_DO",
+                        "DUPLICATE",
+                        "CANNOT_REPRODUCE",
+                        "DONE"
+                    ],
+                    "example": "FIXED"
+                },
+                "resolved_at": {
+                    "type": "string",
+                    "example": "2026-10-18T16:00:00Z"
+                },
+                "sla": {
+                    "description": "SLA holds the timers of the project's SLA policies; it is filled in\nwhen issues are fetched one by one or listed.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.SLATimerResponse"
+                    }
+                },
+                "sprint_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "status": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "story_points": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 18000
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix checkout validation"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "TASK",
+                        "BUG",
+                        "STORY",
+                        "EPIC",
+                        "SUBTASK"
+                    ],
+                    "example": "TASK"
+                }
+            }
+        },
+        "httpapi.IssueTimesResponse": {
+            "type": "object",
+            "properties": {
+                "cycle_time_seconds": {
+                    "type": "integer",
+                    "example": 172800
+                },
+                "done_at": {
+                    "type": "string",
+                    "example": "2026-10-04T09:00:00Z"
+                },
+                "in_status_seconds": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "issue_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "lead_time_seconds": {
+                    "type": "integer",
+                    "example": 259200
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-02T09:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "DONE"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Fix checkout validation"
+                }
+            }
+        },
+        "httpapi.JobResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "write backup: disk full"
+                },
+                "last_finished_at": {
+                    "type": "string",
+                    "example": "2026-10-18T12:00:01Z"
+                },
+                "last_started_at": {
+                    "type": "string",
+                    "example": "2026-10-18T12:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "sla-check"
+                },
+                "next_run_at": {
+                    "type": "string",
+                    "example": "2026-10-18T12:01:00Z"
+                },
+                "running": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "runs": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "schedule": {
+                    "type": "string",
+                    "example": "@every 1m0s"
+                },
+                "skipped": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "httpapi.LinkIssueRequest": {
+            "type": "object",
+            "properties": {
+                "to_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "blocks",
+                        "relates",
+                        "duplicates",
+                        "clones"
+                    ],
+                    "example": "blocks"
+                }
+            }
+        },
+        "httpapi.LinkResponse": {
+            "type": "object",
+            "properties": {
+                "from_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "blocks",
+                        "relates",
+                        "duplicates",
+                        "clones"
+                    ],
+                    "example": "blocks"
+                }
+            }
+        },
+        "httpapi.LogWorkRequest": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Reproduced and fixed the rounding."
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-18T09:00:00Z"
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 5400
+                }
+            }
+        },
+        "httpapi.PercentilesResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "p50_seconds": {
+                    "type": "integer",
+                    "example": 172800
+                },
+                "p85_seconds": {
+                    "type": "integer",
+                    "example": 432000
+                },
+                "p95_seconds": {
+                    "type": "integer",
+                    "example": 604800
+                }
+            }
+        },
+        "httpapi.ProjectResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Payments"
+                }
+            }
+        },
+        "httpapi.ReadinessResponse": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.CheckResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "fail"
+                    ],
+                    "example": "ok"
+                }
+            }
+        },
+        "httpapi.ReleaseNotesGroupResponse": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.IssueResponse"
+                    }
+                },
+                "type": {
+                    "type": "string",
+                    "example": "BUG"
+                }
+            }
+        },
+        "httpapi.ReleaseNotesResponse": {
+            "type": "object",
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.ReleaseNotesGroupResponse"
+                    }
+                },
+                "version": {
+                    "$ref": "#/definitions/httpapi.VersionResponse"
+                }
+            }
+        },
+        "httpapi.ReleaseVersionRequest": {
+            "type": "object",
+            "properties": {
+                "move_to_version_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "release_date": {
+                    "description": "ReleaseDate defaults to the planned date, or today without one.",
+                    "type": "string",
+                    "example": "2026-11-02"
+                }
+            }
+        },
+        "httpapi.RestoreResponse": {
+            "type": "object",
+            "properties": {
+                "backup": {
+                    "$ref": "#/definitions/httpapi.BackupResponse"
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "httpapi.RuleAction": {
+            "type": "object",
+            "properties": {
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "FIXED",
+                        "WONT_DO",
+                        "DUPLICATE",
+                        "CANNOT_REPRODUCE",
+                        "DONE"
+                    ],
+                    "example": "FIXED"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "add_label"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "urgent"
+                }
+            }
+        },
+        "httpapi.RuleCondition": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "priority"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "HIGHEST"
+                }
+            }
+        },
+        "httpapi.RuleExecutionResponse": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "at": {
+                    "type": "string",
+                    "example": "2024-05-01T12:00:00Z"
+                },
+                "chain": {
+                    "description": "Chain lists the rules that led to the execution, this one last.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1
+                    ]
+                },
+                "error": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "issue_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "rule_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "rule_name": {
+                    "type": "string",
+                    "example": "Escalate blocker bugs"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "SUCCEEDED"
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "issue.created"
+                }
+            }
+        },
+        "httpapi.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleAction"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleCondition"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Escalate blocker bugs"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "issue.created"
+                }
+            }
+        },
+        "httpapi.SLAPolicyResponse": {
+            "type": "object",
+            "properties": {
+                "calendar": {
+                    "$ref": "#/definitions/httpapi.CalendarBody"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "name": {
+                    "type": "string",
+                    "example": "First response"
+                },
+                "pause_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "SUP"
+                },
+                "start_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "OPEN"
+                    ]
+                },
+                "stop_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "IN_PROGRESS"
+                    ]
+                },
+                "target_seconds": {
+                    "type": "integer",
+                    "example": 14400
+                }
+            }
+        },
+        "httpapi.SLATimerResponse": {
+            "type": "object",
+            "properties": {
+                "breached": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2026-10-19T13:00:00Z"
+                },
+                "elapsed_seconds": {
+                    "type": "integer",
+                    "example": 3600
+                },
+                "name": {
+                    "type": "string",
+                    "example": "First response"
+                },
+                "policy_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remaining_seconds": {
+                    "type": "integer",
+                    "example": 10800
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-19T09:00:00Z"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "NOT_STARTED",
+                        "RUNNING",
+                        "PAUSED",
+                        "STOPPED"
+                    ],
+                    "example": "RUNNING"
+                },
+                "stopped_at": {
+                    "type": "string"
+                },
+                "target_seconds": {
+                    "type": "integer",
+                    "example": 14400
+                }
+            }
+        },
+        "httpapi.SprintResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string",
+                    "example": "2026-10-20T16:00:00Z"
+                },
+                "committed_issues": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "committed_points": {
+                    "type": "integer",
+                    "example": 34
+                },
+                "completed_issues": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "completed_points": {
+                    "type": "integer",
+                    "example": 29
+                },
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-10-20T09:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "incomplete_issue_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        14,
+                        15
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Sprint 12"
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-06T09:00:00Z"
+                },
+                "state": {
+                    "type": "string",
+                    "enum": [
+                        "FUTURE",
+                        "ACTIVE",
+                        "CLOSED"
+                    ],
+                    "example": "ACTIVE"
+                }
+            }
+        },
+        "httpapi.StartSprintRequest": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2026-11-01T09:00:00Z"
+                }
+            }
+        },
+        "httpapi.StatusChangeResponse": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string",
+                    "example": "2026-10-18T09:30:00Z"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "OPEN"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "httpapi.TimeTrackingResponse": {
+            "type": "object",
+            "properties": {
+                "issues": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "original_estimate_seconds": {
+                    "type": "integer",
+                    "example": 57600
+                },
+                "remaining_estimate_seconds": {
+                    "type": "integer",
+                    "example": 21600
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 43200
+                }
+            }
+        },
+        "httpapi.TransitionIssueRequest": {
+            "type": "object",
+            "properties": {
+                "issue_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "to_status": {
+                    "type": "string",
+                    "enum": [
+                        "OPEN",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "httpapi.TransitionRequest": {
+            "type": "object",
+            "properties": {
+                "resolution": {
+                    "type": "string",
+                    "enum": [
+                        "FIXED",
+                        "WONT_DO",
+                        "DUPLICATE",
+                        "CANNOT_REPRODUCE",
+                        "DONE"
+                    ],
+                    "example": "FIXED"
+                },
+                "to_status": {
+                    "type": "string",
+                    "enum": [
+                        "OPEN",
+                        "IN_PROGRESS",
+                        "DONE"
+                    ],
+                    "example": "IN_PROGRESS"
+                }
+            }
+        },
+        "httpapi.UpdateComponentRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Cart, checkout and receipts"
+                },
+                "lead": {
+                    "type": "string",
+                    "example": "bob"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Checkout"
+                }
+            }
+        },
+        "httpapi.UpdateCustomFieldRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Severity"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/httpapi.ErrorResponse"
-                        }
-                    }
+                    "example": [
+                        "S1",
+                        "S2",
+                        "S3",
+                        "S4"
+                    ]
+                },
+                "required": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
-        }
-    },
-    "definitions": {
-        "httpapi.CreateIssueRequest": {
+        },
+        "httpapi.UpdateIssueRequest": {
             "type": "object",
             "properties": {
-                "project_key": {
+                "add_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "backend"
+                    ]
+                },
+                "assignee": {
                     "type": "string",
-                    "example": "PAY"
+                    "example": "alice"
+                },
+                "component_ids": {
+                    "description": "ComponentIDs replaces the whole set; an empty list removes them all.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "custom_fields": {
+                    "description": "CustomFields sets the fields present; null or an empty value clears\none.",
+                    "type": "object"
+                },
+                "fix_version_id": {
+                    "description": "FixVersionID 0 removes the fix version.",
+                    "type": "integer",
+                    "example": 2
+                },
+                "labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "original_estimate_seconds": {
+                    "description": "original_estimate_seconds alone also resets the remaining estimate\nwhile no work is logged.",
+                    "type": "integer",
+                    "example": 28800
+                },
+                "parent_id": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "priority": {
+                    "type": "string",
+                    "example": "HIGH"
+                },
+                "remaining_estimate_seconds": {
+                    "type": "integer",
+                    "example": 14400
+                },
+                "remove_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "triage"
+                    ]
+                },
+                "sprint_id": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "story_points": {
+                    "type": "integer",
+                    "example": 5
                 },
                 "title": {
                     "type": "string",
                     "example": "Fix checkout validation"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "BUG"
                 }
             }
         },
-        "httpapi.CreateProjectRequest": {
+        "httpapi.UpdateRuleRequest": {
             "type": "object",
             "properties": {
-                "key": {
+                "actions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleAction"
+                    }
+                },
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.RuleCondition"
+                    }
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
                     "type": "string",
-                    "example": "PAY"
+                    "example": "Escalate blocker bugs"
+                },
+                "trigger": {
+                    "type": "string",
+                    "example": "issue.created"
+                }
+            }
+        },
+        "httpapi.UpdateSLAPolicyRequest": {
+            "type": "object",
+            "properties": {
+                "calendar": {
+                    "$ref": "#/definitions/httpapi.CalendarBody"
                 },
                 "name": {
                     "type": "string",
-                    "example": "Payments"
+                    "example": "First response"
+                },
+                "pause_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "start_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stop_statuses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "target_seconds": {
+                    "type": "integer",
+                    "example": 14400
                 }
             }
         },
-        "httpapi.ErrorResponse": {
+        "httpapi.UpdateVersionRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "description": {
                     "type": "string",
-                    "example": "invalid request"
+                    "example": "Checkout rework"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "1.4.0"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2026-11-02"
                 }
             }
         },
-        "httpapi.HealthResponse": {
+        "httpapi.UpdateWorklogRequest": {
             "type": "object",
             "properties": {
-                "status": {
+                "comment": {
                     "type": "string",
-                    "example": "ok"
+                    "example": "Also covered the refund path."
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2026-10-18T09:00:00Z"
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 7200
                 }
             }
         },
-        "httpapi.IssueResponse": {
+        "httpapi.VelocityResponse": {
+            "type": "object",
+            "properties": {
+                "average_issues": {
+                    "type": "number",
+                    "example": 10.5
+                },
+                "average_points": {
+                    "type": "number",
+                    "example": 31
+                },
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "sprints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.SprintResponse"
+                    }
+                }
+            }
+        },
+        "httpapi.VersionResponse": {
             "type": "object",
             "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Checkout rework"
+                },
                 "id": {
                     "type": "integer",
-                    "example": 10
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "1.4.0"
                 },
                 "project_key": {
                     "type": "string",
                     "example": "PAY"
                 },
-                "status": {
+                "release_date": {
+                    "type": "string",
+                    "example": "2026-11-02"
+                },
+                "released_at": {
+                    "type": "string",
+                    "example": "2026-11-02T15:04:05Z"
+                },
+                "state": {
                     "type": "string",
                     "enum": [
-                        "OPEN",
+                        "UNRELEASED",
+                        "RELEASED",
+                        "ARCHIVED"
+                    ],
+                    "example": "UNRELEASED"
+                }
+            }
+        },
+        "httpapi.WorkflowRequest": {
+            "type": "object",
+            "properties": {
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.WorkflowStatusResponse"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.WorkflowTransitionResponse"
+                    }
+                }
+            }
+        },
+        "httpapi.WorkflowResponse": {
+            "type": "object",
+            "properties": {
+                "project_key": {
+                    "type": "string",
+                    "example": "PAY"
+                },
+                "statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.WorkflowStatusResponse"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/httpapi.WorkflowTransitionResponse"
+                    }
+                }
+            }
+        },
+        "httpapi.WorkflowStatusResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "TODO",
                         "IN_PROGRESS",
                         "DONE"
                     ],
-                    "example": "OPEN"
+                    "example": "IN_PROGRESS"
                 },
-                "title": {
+                "name": {
                     "type": "string",
-                    "example": "Fix checkout validation"
+                    "example": "IN_REVIEW"
                 }
             }
         },
-        "httpapi.ProjectResponse": {
+        "httpapi.WorkflowTransitionResponse": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "integer",
-                    "example": 1
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "key": {
+                "from": {
                     "type": "string",
-                    "example": "PAY"
+                    "example": "OPEN"
                 },
-                "name": {
+                "post_functions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "clear_assignee"
+                    ]
+                },
+                "to": {
                     "type": "string",
-                    "example": "Payments"
+                    "example": "IN_PROGRESS"
+                },
+                "validators": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "assignee_required"
+                    ]
                 }
             }
         },
-        "httpapi.TransitionIssueRequest": {
+        "httpapi.WorklogResponse": {
             "type": "object",
             "properties": {
-                "issue_id": {
+                "author": {
+                    "type": "string",
+                    "example": "alice"
+                },
+                "comment": {
+                    "type": "string",
+                    "example": "Reproduced and fixed the rounding."
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2026-10-18T10:30:00Z"
+                },
+                "id": {
                     "type": "integer",
                     "example": 1
                 },
-                "to_status": {
+                "issue_id": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "started_at": {
                     "type": "string",
-                    "enum": [
-                        "OPEN",
-                        "IN_PROGRESS",
-                        "DONE"
-                    ],
-                    "example": "IN_PROGRESS"
+                    "example": "2026-10-18T09:00:00Z"
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 5400
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2026-10-18T10:30:00Z"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "\"Bearer \" followed by ADMIN_TOKEN.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -3,6 +3,7 @@ module MiniJira
 go 1.25
 
 require (
+	github.com/coder/websocket v1.8.15
 	github.com/sirupsen/logrus v1.9.4
 	github.com/swaggo/http-swagger/v2 v2.0.2
 	github.com/swaggo/swag v1.16.6
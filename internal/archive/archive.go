@@ -0,0 +1,397 @@
+// Package archive defines the versioned JSON format used to move a project
+// with its sprints and issues between MiniJira instances.
+package archive
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	Format = "minijira.project"
+	// Version 2 added issue type, priority, parent, comments, links and the
+	// project workflow; version 3 added issue creation times and status
+	// history; version 4 added story points and sprint states; version 5
+	// added issue resolutions; version 6 added components, versions,
+	// custom fields, time estimates and worklogs. Older archives are still
+	// read.
+	Version = 6
+)
+
+var ErrInvalidArchive = errors.New("invalid archive")
+
+// Archive is the decoded form of an export. IDs are those of the source
+// instance; Import assigns new ones and reports the mapping.
+type Archive struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Project    Project   `json:"project"`
+	// Workflow is nil for projects on the default workflow.
+	Workflow     *Workflow        `json:"workflow,omitempty"`
+	Components   []Component      `json:"components,omitempty"`
+	Versions     []ProjectVersion `json:"versions,omitempty"`
+	CustomFields []CustomField    `json:"custom_fields,omitempty"`
+	Sprints      []Sprint         `json:"sprints"`
+	Issues       []Issue          `json:"issues"`
+	Links        []Link           `json:"links,omitempty"`
+}
+
+type Project struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type Workflow struct {
+	Statuses    []WorkflowStatus     `json:"statuses"`
+	Transitions []WorkflowTransition `json:"transitions,omitempty"`
+}
+
+type WorkflowStatus struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+type WorkflowTransition struct {
+	From          string   `json:"from"`
+	To            string   `json:"to"`
+	Conditions    []string `json:"conditions,omitempty"`
+	Validators    []string `json:"validators,omitempty"`
+	PostFunctions []string `json:"post_functions,omitempty"`
+}
+
+// Component and ProjectVersion are referred to by issues with their
+// archived IDs.
+type Component struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Lead        string `json:"lead,omitempty"`
+}
+
+// ProjectVersion has no state while it is unreleased.
+type ProjectVersion struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	State       string    `json:"state,omitempty"`
+	ReleaseDate time.Time `json:"release_date,omitzero"`
+	ReleasedAt  time.Time `json:"released_at,omitzero"`
+}
+
+// CustomField is a field definition; issues hold values by its key.
+type CustomField struct {
+	Key      string   `json:"key"`
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required,omitempty"`
+	Options  []string `json:"options,omitempty"`
+}
+
+// Sprint carries its state from version 4 on; older sprints are future
+// ones. Incomplete lists archived issue IDs.
+type Sprint struct {
+	ID              int       `json:"id"`
+	Name            string    `json:"name"`
+	State           string    `json:"state,omitempty"`
+	StartedAt       time.Time `json:"started_at,omitzero"`
+	EndsAt          time.Time `json:"ends_at,omitzero"`
+	ClosedAt        time.Time `json:"closed_at,omitzero"`
+	CommittedIssues int       `json:"committed_issues,omitempty"`
+	CommittedPoints int       `json:"committed_points,omitempty"`
+	CompletedIssues int       `json:"completed_issues,omitempty"`
+	CompletedPoints int       `json:"completed_points,omitempty"`
+	Incomplete      []int     `json:"incomplete,omitempty"`
+}
+
+type Issue struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title"`
+	Type     string    `json:"type,omitempty"`
+	Priority string    `json:"priority,omitempty"`
+	Status   string    `json:"status"`
+	Rank     int       `json:"rank"`
+	Assignee string    `json:"assignee,omitempty"`
+	Labels   []string  `json:"labels,omitempty"`
+	SprintID int       `json:"sprint_id,omitempty"`
+	ParentID int       `json:"parent_id,omitempty"`
+	Comments []Comment `json:"comments,omitempty"`
+	// CreatedAt and History are absent in archives before version 3.
+	CreatedAt   time.Time      `json:"created_at,omitzero"`
+	History     []StatusChange `json:"history,omitempty"`
+	StoryPoints int            `json:"story_points,omitempty"`
+	// Resolution and ResolvedAt are absent before version 5; issues of
+	// older archives in a DONE status are imported as resolved DONE.
+	Resolution string    `json:"resolution,omitempty"`
+	ResolvedAt time.Time `json:"resolved_at,omitzero"`
+	// The fields below are absent before version 6. The time spent is not
+	// archived: it is the sum of the worklogs.
+	OriginalEstimateSeconds  int64               `json:"original_estimate_seconds,omitempty"`
+	RemainingEstimateSeconds int64               `json:"remaining_estimate_seconds,omitempty"`
+	FixVersionID             int                 `json:"fix_version_id,omitempty"`
+	ComponentIDs             []int               `json:"component_ids,omitempty"`
+	CustomFields             map[string][]string `json:"custom_fields,omitempty"`
+	Worklogs                 []Worklog           `json:"worklogs,omitempty"`
+}
+
+// Worklog is time spent on the issue. Reduced is how much it lowered the
+// remaining estimate.
+type Worklog struct {
+	Author           string    `json:"author"`
+	TimeSpentSeconds int64     `json:"time_spent_seconds"`
+	ReducedSeconds   int64     `json:"reduced_seconds,omitempty"`
+	StartedAt        time.Time `json:"started_at"`
+	Comment          string    `json:"comment,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at,omitzero"`
+}
+
+// StatusChange is an issue entering status To at At. The first change of
+// an issue has no From.
+type StatusChange struct {
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+type Comment struct {
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Link connects two issues of the archive by their archived IDs.
+type Link struct {
+	Type   string `json:"type"`
+	FromID int    `json:"from_id"`
+	ToID   int    `json:"to_id"`
+}
+
+// Snapshot is the state of one project to export. Links holds only links
+// with both ends in the project.
+type Snapshot struct {
+	Project      logic.Project
+	Workflow     *logic.Workflow
+	Components   []logic.Component
+	Versions     []logic.Version
+	CustomFields []logic.CustomField
+	Sprints      []logic.Sprint
+	Issues       []logic.Issue
+	Comments     map[int][]logic.Comment
+	History      map[int][]logic.StatusChange
+	Worklogs     map[int][]logic.Worklog
+	Links        []logic.IssueLink
+}
+
+// Write encodes s as an archive. Issues are written one at a time, so the
+// output is streamed rather than built in memory first.
+func Write(w io.Writer, s Snapshot, now time.Time) error {
+	header := struct {
+		Format       string           `json:"format"`
+		Version      int              `json:"version"`
+		ExportedAt   time.Time        `json:"exported_at"`
+		Project      Project          `json:"project"`
+		Workflow     *Workflow        `json:"workflow,omitempty"`
+		Components   []Component      `json:"components,omitempty"`
+		Versions     []ProjectVersion `json:"versions,omitempty"`
+		CustomFields []CustomField    `json:"custom_fields,omitempty"`
+		Sprints      []Sprint         `json:"sprints"`
+	}{
+		Format:     Format,
+		Version:    Version,
+		ExportedAt: now.UTC(),
+		Project:    Project{Key: s.Project.Key, Name: s.Project.Name},
+		Sprints:    make([]Sprint, len(s.Sprints)),
+	}
+	if s.Workflow != nil {
+		header.Workflow = fromWorkflow(*s.Workflow)
+	}
+	for _, c := range s.Components {
+		header.Components = append(header.Components, Component{ID: c.ID, Name: c.Name, Description: c.Description, Lead: c.Lead})
+	}
+	for _, v := range s.Versions {
+		header.Versions = append(header.Versions, ProjectVersion{
+			ID:          v.ID,
+			Name:        v.Name,
+			Description: v.Description,
+			State:       v.State,
+			ReleaseDate: v.ReleaseDate,
+			ReleasedAt:  v.ReleasedAt,
+		})
+	}
+	for _, f := range s.CustomFields {
+		header.CustomFields = append(header.CustomFields, CustomField{Key: f.Key, Name: f.Name, Type: f.Type, Required: f.Required, Options: f.Options})
+	}
+	for i, sp := range s.Sprints {
+		header.Sprints[i] = Sprint{
+			ID:              sp.ID,
+			Name:            sp.Name,
+			State:           sp.State,
+			StartedAt:       sp.StartedAt,
+			EndsAt:          sp.EndsAt,
+			ClosedAt:        sp.ClosedAt,
+			CommittedIssues: sp.CommittedIssues,
+			CommittedPoints: sp.CommittedPoints,
+			CompletedIssues: sp.CompletedIssues,
+			CompletedPoints: sp.CompletedPoints,
+			Incomplete:      sp.Incomplete,
+		}
+	}
+
+	head, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	// Reopen the header object to append the issues and links arrays.
+	if _, err := w.Write(head[:len(head)-1]); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `,"issues":[`); err != nil {
+		return err
+	}
+
+	for i, issue := range s.Issues {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+
+		b, err := json.Marshal(fromIssue(issue, s.Comments[issue.ID], s.History[issue.ID], s.Worklogs[issue.ID]))
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	links := make([]Link, len(s.Links))
+	for i, l := range s.Links {
+		links[i] = Link{Type: l.Type, FromID: l.FromID, ToID: l.ToID}
+	}
+	tail, err := json.Marshal(links)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, `],"links":`); err != nil {
+		return err
+	}
+	if _, err := w.Write(tail); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "}\n")
+	return err
+}
+
+func fromWorkflow(wf logic.Workflow) *Workflow {
+	res := &Workflow{
+		Statuses:    make([]WorkflowStatus, len(wf.Statuses)),
+		Transitions: make([]WorkflowTransition, len(wf.Transitions)),
+	}
+	for i, s := range wf.Statuses {
+		res.Statuses[i] = WorkflowStatus{Name: s.Name, Category: s.Category}
+	}
+	for i, t := range wf.Transitions {
+		res.Transitions[i] = WorkflowTransition{From: t.From, To: t.To, Conditions: t.Conditions, Validators: t.Validators, PostFunctions: t.PostFunctions}
+	}
+
+	return res
+}
+
+func toWorkflow(projectKey string, wf Workflow) logic.Workflow {
+	res := logic.Workflow{
+		ProjectKey:  projectKey,
+		Statuses:    make([]logic.WorkflowStatus, len(wf.Statuses)),
+		Transitions: make([]logic.WorkflowTransition, len(wf.Transitions)),
+	}
+	for i, s := range wf.Statuses {
+		res.Statuses[i] = logic.WorkflowStatus{Name: s.Name, Category: s.Category}
+	}
+	for i, t := range wf.Transitions {
+		res.Transitions[i] = logic.WorkflowTransition{From: t.From, To: t.To, Conditions: t.Conditions, Validators: t.Validators, PostFunctions: t.PostFunctions}
+	}
+
+	return res
+}
+
+func fromIssue(i logic.Issue, comments []logic.Comment, history []logic.StatusChange, worklogs []logic.Worklog) Issue {
+	res := Issue{
+		ID:          i.ID,
+		Title:       i.Title,
+		Type:        i.Type,
+		Priority:    i.Priority,
+		Status:      i.Status,
+		Rank:        i.Rank,
+		Assignee:    i.Assignee,
+		Labels:      i.Labels,
+		SprintID:    i.SprintID,
+		ParentID:    i.ParentID,
+		CreatedAt:   i.CreatedAt,
+		StoryPoints: i.StoryPoints,
+		Resolution:  i.Resolution,
+		ResolvedAt:  i.ResolvedAt,
+
+		OriginalEstimateSeconds:  int64(i.OriginalEstimate / time.Second),
+		RemainingEstimateSeconds: int64(i.RemainingEstimate / time.Second),
+		FixVersionID:             i.FixVersionID,
+		ComponentIDs:             i.ComponentIDs,
+		CustomFields:             i.CustomFields,
+	}
+	for _, w := range worklogs {
+		res.Worklogs = append(res.Worklogs, Worklog{
+			Author:           w.Author,
+			TimeSpentSeconds: int64(w.Spent / time.Second),
+			ReducedSeconds:   int64(w.Reduced / time.Second),
+			StartedAt:        w.StartedAt,
+			Comment:          w.Comment,
+			CreatedAt:        w.CreatedAt,
+			UpdatedAt:        w.UpdatedAt,
+		})
+	}
+	for _, c := range comments {
+		res.Comments = append(res.Comments, Comment{Author: c.Author, Body: c.Body, CreatedAt: c.CreatedAt})
+	}
+	for _, h := range history {
+		res.History = append(res.History, StatusChange{From: h.From, To: h.To, At: h.At})
+	}
+
+	return res
+}
+
+// Read decodes an archive and checks that its format and version are ones
+// this build understands.
+func Read(r io.Reader) (Archive, error) {
+	var a Archive
+	if err := json.NewDecoder(r).Decode(&a); err != nil {
+		return Archive{}, logic.NewValidationError(ErrInvalidArchive, logic.FieldError{
+			Field:   "body",
+			Code:    logic.FieldInvalid,
+			Message: err.Error(),
+		})
+	}
+
+	if a.Format != Format {
+		return Archive{}, logic.NewValidationError(ErrInvalidArchive, logic.FieldError{
+			Field:   "format",
+			Code:    logic.FieldInvalid,
+			Message: fmt.Sprintf("must be %q", Format),
+		})
+	}
+	if a.Version < 1 || a.Version > Version {
+		return Archive{}, logic.NewValidationError(ErrInvalidArchive, logic.FieldError{
+			Field:   "version",
+			Code:    logic.FieldInvalid,
+			Message: fmt.Sprintf("unsupported version %d, this server reads up to %d", a.Version, Version),
+		})
+	}
+
+	return a, nil
+}
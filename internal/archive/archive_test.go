@@ -0,0 +1,257 @@
+package archive
+
+import (
+	"MiniJira/internal/logic"
+	"MiniJira/internal/store/memory"
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	snap := Snapshot{
+		Project: logic.Project{ID: 1, Key: "PAY", Name: "Payments"},
+		Sprints: []logic.Sprint{{ID: 4, ProjectKey: "PAY", Name: "Sprint 1"}},
+		Issues: []logic.Issue{
+			{ID: 7, ProjectKey: "PAY", Title: "Fix checkout", Status: logic.StatusDone, Rank: 1, Labels: []string{"release"}, SprintID: 4, Resolution: logic.ResolutionWontDo, ResolvedAt: time.Unix(3600, 0).UTC()},
+			{ID: 9, ProjectKey: "PAY", Title: "Refund flow", Status: logic.StatusOpen, Rank: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, snap, time.Unix(0, 0)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	a, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if a.Version != Version || a.Project.Key != "PAY" || len(a.Sprints) != 1 || len(a.Issues) != 2 {
+		t.Fatalf("unexpected archive: %+v", a)
+	}
+	if a.Issues[0].SprintID != 4 || a.Issues[0].Labels[0] != "release" || a.Issues[0].Resolution != logic.ResolutionWontDo || !a.Issues[0].ResolvedAt.Equal(time.Unix(3600, 0)) {
+		t.Fatalf("unexpected issue: %+v", a.Issues[0])
+	}
+}
+
+func TestRead_RejectsNewerVersion(t *testing.T) {
+	_, err := Read(strings.NewReader(`{"format":"minijira.project","version":99}`))
+	if !errors.Is(err, ErrInvalidArchive) {
+		t.Fatalf("expected ErrInvalidArchive, got %v", err)
+	}
+}
+
+func TestImport_RemapsIDs(t *testing.T) {
+	store := memory.NewStore()
+	store.CreateProject(logic.Project{Key: "PAY", Name: "Payments"})
+	store.CreateIssue(logic.Issue{ProjectKey: "PAY", Title: "Existing", Status: logic.StatusOpen, Rank: 1})
+
+	a := Archive{
+		Format:  Format,
+		Version: Version,
+		Project: Project{Key: "PAY", Name: "Payments"},
+		Sprints: []Sprint{{ID: 4, Name: "Sprint 1"}},
+		Issues: []Issue{
+			{ID: 9, Title: "Second", Status: logic.StatusOpen, Rank: 5},
+			{ID: 7, Title: "First", Status: logic.StatusDone, Rank: 2, SprintID: 4},
+		},
+	}
+
+	_, err := Import(store, a, Options{})
+	if !errors.Is(err, logic.ErrProjectKeyExists) {
+		t.Fatalf("expected ErrProjectKeyExists, got %v", err)
+	}
+
+	rep, err := Import(store, a, Options{ProjectKey: "PAY2"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	first, _ := store.GetIssueByID(rep.IssueIDs[7])
+	if first.ProjectKey != "PAY2" || first.Rank != 1 || first.SprintID != rep.SprintIDs[4] {
+		t.Fatalf("unexpected imported issue: %+v", first)
+	}
+	if rep.IssueIDs[9] == 9 {
+		t.Fatalf("expected issue 9 to get a new id, got %v", rep.IssueIDs)
+	}
+}
+
+func TestImport_ReportsEveryProblem(t *testing.T) {
+	a := Archive{
+		Format:  Format,
+		Version: Version,
+		Project: Project{Key: "PAY"},
+		Issues: []Issue{
+			{ID: 1, Title: "", Status: logic.StatusOpen, History: []StatusChange{{To: "CLOSED"}}},
+			{ID: 1, Title: "Dup", Status: "CLOSED", SprintID: 3},
+		},
+	}
+
+	_, err := Import(memory.NewStore(), a, Options{})
+
+	var verr *logic.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	var got []string
+	for _, f := range verr.Fields {
+		got = append(got, f.Field)
+	}
+	want := "project.name issues[0].title issues[0].history[0].to issues[0].history[0].at issues[1].id issues[1].status issues[1].sprint_id"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("expected fields %q, got %q", want, strings.Join(got, " "))
+	}
+}
+
+func TestImport_History(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	started := created.Add(24 * time.Hour)
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	a := Archive{
+		Format:  Format,
+		Version: Version,
+		Project: Project{Key: "PAY", Name: "Payments"},
+		Issues: []Issue{
+			{ID: 1, Title: "Old", Status: logic.StatusOpen, Rank: 1},
+			{ID: 2, Title: "Tracked", Status: logic.StatusDone, Rank: 2, CreatedAt: created, History: []StatusChange{
+				{To: logic.StatusOpen, At: created},
+				{From: logic.StatusOpen, To: logic.StatusInProgress, At: started},
+			}},
+		},
+	}
+
+	store := memory.NewStore()
+	rep, err := Import(store, a, Options{Now: now})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	old := store.ListStatusChanges(rep.IssueIDs[1])
+	if len(old) != 1 || old[0].To != logic.StatusOpen || !old[0].At.Equal(now) {
+		t.Fatalf("expected one change at import time, got %+v", old)
+	}
+
+	tracked := store.ListStatusChanges(rep.IssueIDs[2])
+	if len(tracked) != 3 || !tracked[1].At.Equal(started) {
+		t.Fatalf("expected the archived history replayed, got %+v", tracked)
+	}
+	if last := tracked[2]; last.From != logic.StatusInProgress || last.To != logic.StatusDone || !last.At.Equal(now) {
+		t.Fatalf("expected a closing change to the current status, got %+v", last)
+	}
+}
+
+func TestImport_Resolution(t *testing.T) {
+	created := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	done := created.Add(48 * time.Hour)
+	issues := []Issue{
+		{ID: 1, Title: "Open", Status: logic.StatusOpen, Rank: 1, CreatedAt: created},
+		{ID: 2, Title: "Shipped", Status: logic.StatusDone, Rank: 2, CreatedAt: created, History: []StatusChange{
+			{To: logic.StatusOpen, At: created},
+			{From: logic.StatusOpen, To: logic.StatusDone, At: done},
+		}},
+	}
+
+	store := memory.NewStore()
+	rep, err := Import(store, Archive{Format: Format, Version: 4, Project: Project{Key: "OLD", Name: "Old"}, Issues: issues}, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if open, _ := store.GetIssueByID(rep.IssueIDs[1]); open.Resolution != "" {
+		t.Fatalf("expected no resolution outside DONE, got %+v", open)
+	}
+	shipped, _ := store.GetIssueByID(rep.IssueIDs[2])
+	if shipped.Resolution != logic.ResolutionDone || !shipped.ResolvedAt.Equal(done) {
+		t.Fatalf("expected a version 4 issue resolved as DONE when it was done, got %+v", shipped)
+	}
+
+	issues[1].Resolution = logic.ResolutionDuplicate
+	rep, err = Import(store, Archive{Format: Format, Version: Version, Project: Project{Key: "NEW", Name: "New"}, Issues: issues}, Options{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if shipped, _ := store.GetIssueByID(rep.IssueIDs[2]); shipped.Resolution != logic.ResolutionDuplicate || !shipped.ResolvedAt.Equal(done) {
+		t.Fatalf("expected the archived resolution kept, got %+v", shipped)
+	}
+
+	issues[0].Resolution = logic.ResolutionFixed
+	issues[1].Resolution = "SOLVED"
+	_, err = Import(store, Archive{Format: Format, Version: Version, Project: Project{Key: "BAD", Name: "Bad"}, Issues: issues}, Options{})
+	var verr *logic.ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 || verr.Fields[0].Field != "issues[0].resolution" || verr.Fields[1].Field != "issues[1].resolution" {
+		t.Fatalf("expected both resolutions rejected, got %v", err)
+	}
+}
+
+func TestImport_ProjectData(t *testing.T) {
+	at := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	snap := Snapshot{
+		Project:      logic.Project{ID: 1, Key: "PAY", Name: "Payments"},
+		Components:   []logic.Component{{ID: 3, ProjectKey: "PAY", Name: "Backend", Lead: "alice"}},
+		Versions:     []logic.Version{{ID: 5, ProjectKey: "PAY", Name: "1.4", State: logic.VersionUnreleased}},
+		CustomFields: []logic.CustomField{{ID: 2, ProjectKey: "PAY", Key: "team", Name: "Team", Type: logic.CustomSelect, Required: true, Options: []string{"core", "growth"}}},
+		Issues: []logic.Issue{{
+			ID: 7, ProjectKey: "PAY", Title: "Fix checkout", Status: logic.StatusOpen, Rank: 1, CreatedAt: at,
+			OriginalEstimate: 8 * time.Hour, RemainingEstimate: 6 * time.Hour, TimeSpent: 2 * time.Hour,
+			FixVersionID: 5, ComponentIDs: []int{3}, CustomFields: map[string][]string{"team": {"core"}},
+		}},
+		Worklogs: map[int][]logic.Worklog{7: {{ID: 1, IssueID: 7, Author: "alice", Spent: 2 * time.Hour, Reduced: 2 * time.Hour, StartedAt: at, CreatedAt: at, UpdatedAt: at}}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, snap, at); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	a, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	store := memory.NewStore()
+	rep, err := Import(store, a, Options{ProjectKey: "PAY2"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rep.Worklogs != 1 {
+		t.Fatalf("expected one worklog imported, got %+v", rep)
+	}
+
+	issue, _ := store.GetIssueByID(rep.IssueIDs[7])
+	components := store.ListComponentsByProjectKey("PAY2")
+	versions := store.ListVersionsByProjectKey("PAY2")
+	if len(components) != 1 || len(versions) != 1 || len(store.ListCustomFieldsByProjectKey("PAY2")) != 1 {
+		t.Fatalf("expected the component, version and custom field imported, got %+v %+v", components, versions)
+	}
+	if issue.FixVersionID != versions[0].ID || len(issue.ComponentIDs) != 1 || issue.ComponentIDs[0] != components[0].ID {
+		t.Fatalf("expected the references remapped, got %+v", issue)
+	}
+	if issue.OriginalEstimate != 8*time.Hour || issue.RemainingEstimate != 6*time.Hour || issue.TimeSpent != 2*time.Hour || issue.CustomFields["team"][0] != "core" {
+		t.Fatalf("unexpected imported issue: %+v", issue)
+	}
+	if worklogs := store.ListWorklogsByIssueID(issue.ID); len(worklogs) != 1 || worklogs[0].Reduced != 2*time.Hour {
+		t.Fatalf("unexpected worklogs: %+v", worklogs)
+	}
+
+	a.Issues[0].CustomFields = nil
+	a.Issues[0].FixVersionID = 9
+	a.Issues[0].Worklogs[0].TimeSpentSeconds = 0
+	_, err = Import(store, a, Options{ProjectKey: "PAY3"})
+
+	var verr *logic.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+	var got []string
+	for _, f := range verr.Fields {
+		got = append(got, f.Field)
+	}
+	want := "issues[0].fix_version_id issues[0].custom_fields.team issues[0].worklogs[0].time_spent_seconds issues[0].worklogs[0].reduced_seconds"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("expected fields %q, got %q", want, strings.Join(got, " "))
+	}
+}
@@ -0,0 +1,573 @@
+package archive
+
+import (
+	"MiniJira/internal/logic"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options control how an archive is mapped onto the target instance.
+type Options struct {
+	// ProjectKey and ProjectName replace the archived values when set,
+	// e.g. to import a second copy next to the original.
+	ProjectKey  string
+	ProjectName string
+	// Now dates issues and status changes the archive has no time for,
+	// as in archives before version 3. Zero means time.Now.
+	Now time.Time
+}
+
+// Report describes an import. SprintIDs and IssueIDs map archived IDs to
+// the IDs assigned by the target store.
+type Report struct {
+	Project   logic.Project
+	Workflow  logic.Workflow
+	SprintIDs map[int]int
+	IssueIDs  map[int]int
+	Issues    []logic.Issue
+	Comments  int
+	Worklogs  int
+	Links     int
+}
+
+// Import validates the whole archive first and writes nothing if any part
+// of it is invalid; the returned ValidationError lists every problem with
+// its path in the archive. Callers wanting all-or-nothing semantics across
+// store failures as well should run it inside a transaction. Custom field
+// values get the checks of logic.CreateIssue, so an issue without a value
+// for a required field is rejected like a new one would be.
+func Import(store logic.Store, a Archive, opts Options) (Report, error) {
+	key := strings.TrimSpace(a.Project.Key)
+	if opts.ProjectKey != "" {
+		key = strings.TrimSpace(opts.ProjectKey)
+	}
+	name := strings.TrimSpace(a.Project.Name)
+	if opts.ProjectName != "" {
+		name = strings.TrimSpace(opts.ProjectName)
+	}
+
+	wf := logic.DefaultWorkflow(key)
+	if a.Workflow != nil {
+		wf = toWorkflow(key, *a.Workflow)
+	}
+
+	fields := customFields(a)
+	if err := validate(a, key, name, wf, fields); err != nil {
+		return Report{}, err
+	}
+	if _, ok := store.GetByKey(key); ok {
+		return Report{}, logic.ErrProjectKeyExists
+	}
+
+	rep := Report{
+		Project:   store.CreateProject(logic.Project{Key: key, Name: name}),
+		Workflow:  wf,
+		SprintIDs: make(map[int]int, len(a.Sprints)),
+		IssueIDs:  make(map[int]int, len(a.Issues)),
+	}
+	if a.Workflow != nil {
+		rep.Workflow = store.SaveWorkflow(wf)
+	}
+
+	componentIDs := make(map[int]int, len(a.Components))
+	for _, c := range a.Components {
+		created := store.CreateComponent(logic.Component{
+			ProjectKey:  key,
+			Name:        strings.TrimSpace(c.Name),
+			Description: strings.TrimSpace(c.Description),
+			Lead:        strings.TrimSpace(c.Lead),
+		})
+		componentIDs[c.ID] = created.ID
+	}
+	versionIDs := make(map[int]int, len(a.Versions))
+	for _, v := range a.Versions {
+		created := store.CreateVersion(logic.Version{
+			ProjectKey:  key,
+			Name:        strings.TrimSpace(v.Name),
+			Description: strings.TrimSpace(v.Description),
+			State:       orDefault(v.State, logic.VersionUnreleased),
+			ReleaseDate: v.ReleaseDate,
+			ReleasedAt:  v.ReleasedAt,
+		})
+		versionIDs[v.ID] = created.ID
+	}
+	for _, f := range fields {
+		f.ProjectKey = key
+		store.CreateCustomField(f)
+	}
+
+	for _, sp := range a.Sprints {
+		created := store.CreateSprint(logic.Sprint{
+			ProjectKey:      key,
+			Name:            strings.TrimSpace(sp.Name),
+			State:           orDefault(sp.State, logic.SprintFuture),
+			StartedAt:       sp.StartedAt,
+			EndsAt:          sp.EndsAt,
+			ClosedAt:        sp.ClosedAt,
+			CommittedIssues: sp.CommittedIssues,
+			CommittedPoints: sp.CommittedPoints,
+			CompletedIssues: sp.CompletedIssues,
+			CompletedPoints: sp.CompletedPoints,
+		})
+		rep.SprintIDs[sp.ID] = created.ID
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	issues := slices.Clone(a.Issues)
+	sort.SliceStable(issues, func(x, y int) bool {
+		return issues[x].Rank < issues[y].Rank
+	})
+
+	for n, i := range issues {
+		createdAt := i.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = now
+			if len(i.History) > 0 {
+				createdAt = i.History[0].At
+			}
+		}
+
+		resolution := i.Resolution
+		if a.Version < 5 && category(wf, i.Status) == logic.CategoryDone {
+			resolution = logic.ResolutionDone
+		}
+
+		values, _ := logic.ValidateCustomValues(fields, i.CustomFields)
+		var components []int
+		for _, id := range i.ComponentIDs {
+			components = append(components, componentIDs[id])
+		}
+		var spent time.Duration
+		for _, w := range i.Worklogs {
+			spent += seconds(w.TimeSpentSeconds)
+		}
+
+		created := store.CreateIssue(logic.Issue{
+			ProjectKey:  key,
+			Title:       strings.TrimSpace(i.Title),
+			Type:        orDefault(i.Type, logic.TypeTask),
+			Priority:    orDefault(i.Priority, logic.PriorityMedium),
+			Status:      i.Status,
+			Rank:        n + 1,
+			Assignee:    strings.TrimSpace(i.Assignee),
+			Labels:      i.Labels,
+			SprintID:    rep.SprintIDs[i.SprintID],
+			CreatedAt:   createdAt,
+			StoryPoints: i.StoryPoints,
+			Resolution:  resolution,
+			ResolvedAt:  i.ResolvedAt,
+
+			OriginalEstimate:  seconds(i.OriginalEstimateSeconds),
+			RemainingEstimate: seconds(i.RemainingEstimateSeconds),
+			TimeSpent:         spent,
+			FixVersionID:      versionIDs[i.FixVersionID],
+			ComponentIDs:      components,
+			CustomFields:      values,
+		})
+		rep.IssueIDs[i.ID] = created.ID
+		history := importHistory(store, created, i.History, now)
+		if created.Resolution != "" && created.ResolvedAt.IsZero() {
+			created.ResolvedAt = enteredDone(wf, created, history)
+			store.UpdateIssue(created)
+		}
+
+		for _, c := range i.Comments {
+			store.CreateComment(logic.Comment{
+				IssueID:   created.ID,
+				Author:    strings.TrimSpace(c.Author),
+				Body:      strings.TrimSpace(c.Body),
+				CreatedAt: c.CreatedAt,
+			})
+			rep.Comments++
+		}
+		for _, w := range i.Worklogs {
+			store.CreateWorklog(logic.Worklog{
+				IssueID:   created.ID,
+				Author:    strings.TrimSpace(w.Author),
+				Spent:     seconds(w.TimeSpentSeconds),
+				Reduced:   seconds(w.ReducedSeconds),
+				StartedAt: w.StartedAt,
+				Comment:   strings.TrimSpace(w.Comment),
+				CreatedAt: w.CreatedAt,
+				UpdatedAt: cmp.Or(w.UpdatedAt, w.CreatedAt),
+			})
+			rep.Worklogs++
+		}
+	}
+
+	// Parents are set once every issue has its new ID.
+	for _, i := range issues {
+		created, _ := store.GetIssueByID(rep.IssueIDs[i.ID])
+		if i.ParentID != 0 {
+			created.ParentID = rep.IssueIDs[i.ParentID]
+			created, _ = store.UpdateIssue(created)
+		}
+		rep.Issues = append(rep.Issues, created)
+	}
+
+	for _, sp := range a.Sprints {
+		if len(sp.Incomplete) == 0 {
+			continue
+		}
+		created, _ := store.GetSprintByID(rep.SprintIDs[sp.ID])
+		for _, id := range sp.Incomplete {
+			created.Incomplete = append(created.Incomplete, rep.IssueIDs[id])
+		}
+		store.UpdateSprint(created)
+	}
+
+	for _, l := range a.Links {
+		store.CreateLink(logic.IssueLink{Type: l.Type, FromID: rep.IssueIDs[l.FromID], ToID: rep.IssueIDs[l.ToID]})
+		rep.Links++
+	}
+
+	return rep, nil
+}
+
+// importHistory replays the archived status changes of an issue and
+// returns them. Without history the issue is taken to have been in its
+// status since creation; a history ending elsewhere gets a final change to
+// the current status.
+func importHistory(store logic.Store, issue logic.Issue, history []StatusChange, now time.Time) []logic.StatusChange {
+	var res []logic.StatusChange
+	last := StatusChange{At: issue.CreatedAt}
+	for _, h := range history {
+		res = append(res, logic.StatusChange{IssueID: issue.ID, From: h.From, To: h.To, At: h.At})
+		last = h
+	}
+
+	if last.To != issue.Status {
+		at := last.At
+		if len(history) > 0 && now.After(at) {
+			at = now
+		}
+		res = append(res, logic.StatusChange{IssueID: issue.ID, From: last.To, To: issue.Status, At: at})
+	}
+	for _, c := range res {
+		store.AddStatusChange(c)
+	}
+
+	return res
+}
+
+// enteredDone is when the issue last came into the DONE category from
+// another one, or its creation time if the history does not show it.
+func enteredDone(wf logic.Workflow, issue logic.Issue, history []logic.StatusChange) time.Time {
+	at := issue.CreatedAt
+	for _, h := range history {
+		if category(wf, h.To) == logic.CategoryDone && (h.From == "" || category(wf, h.From) != logic.CategoryDone) {
+			at = h.At
+		}
+	}
+
+	return at
+}
+
+func category(wf logic.Workflow, status string) string {
+	c, _ := wf.Category(status)
+	return c
+}
+
+// customFields returns the custom field definitions of the archive the
+// way logic.CreateCustomField would store them.
+func customFields(a Archive) []logic.CustomField {
+	res := make([]logic.CustomField, len(a.CustomFields))
+	for n, f := range a.CustomFields {
+		res[n] = logic.CustomField{Key: f.Key, Name: f.Name, Type: f.Type, Required: f.Required, Options: f.Options}
+		logic.ValidateCustomField(&res[n])
+	}
+
+	return res
+}
+
+func seconds(s int64) time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+func orDefault(v, def string) string {
+	if v == "" {
+		return def
+	}
+
+	return v
+}
+
+func validate(a Archive, key, name string, wf logic.Workflow, customFields []logic.CustomField) error {
+	var fields []logic.FieldError
+	add := func(field, code, msg string) {
+		fields = append(fields, logic.FieldError{Field: field, Code: code, Message: msg})
+	}
+
+	if key == "" {
+		add("project.key", logic.FieldRequired, "must not be empty")
+	}
+	if name == "" {
+		add("project.name", logic.FieldRequired, "must not be empty")
+	}
+
+	var verr *logic.ValidationError
+	if err := logic.ValidateWorkflow(wf); err != nil && errors.As(err, &verr) {
+		for _, f := range verr.Fields {
+			add("workflow."+f.Field, f.Code, f.Message)
+		}
+	}
+
+	components := make(map[int]bool, len(a.Components))
+	for n, c := range a.Components {
+		path := fmt.Sprintf("components[%d]", n)
+		switch {
+		case c.ID <= 0:
+			add(path+".id", logic.FieldInvalid, "must be a positive integer")
+		case components[c.ID]:
+			add(path+".id", logic.FieldInvalid, "duplicate component id")
+		}
+		components[c.ID] = true
+
+		switch cname := strings.TrimSpace(c.Name); {
+		case cname == "":
+			add(path+".name", logic.FieldRequired, "must not be empty")
+		case slices.ContainsFunc(a.Components[:n], func(o Component) bool { return strings.EqualFold(strings.TrimSpace(o.Name), cname) }):
+			add(path+".name", logic.FieldInvalid, "duplicate component name")
+		}
+	}
+
+	versions := make(map[int]bool, len(a.Versions))
+	for n, v := range a.Versions {
+		path := fmt.Sprintf("versions[%d]", n)
+		switch {
+		case v.ID <= 0:
+			add(path+".id", logic.FieldInvalid, "must be a positive integer")
+		case versions[v.ID]:
+			add(path+".id", logic.FieldInvalid, "duplicate version id")
+		}
+		versions[v.ID] = true
+
+		switch vname := strings.TrimSpace(v.Name); {
+		case vname == "":
+			add(path+".name", logic.FieldRequired, "must not be empty")
+		case slices.ContainsFunc(a.Versions[:n], func(o ProjectVersion) bool { return strings.EqualFold(strings.TrimSpace(o.Name), vname) }):
+			add(path+".name", logic.FieldInvalid, "duplicate version name")
+		}
+		switch v.State {
+		case "", logic.VersionUnreleased, logic.VersionArchived:
+		case logic.VersionReleased:
+			if v.ReleasedAt.IsZero() {
+				add(path+".released_at", logic.FieldRequired, "must not be empty")
+			}
+		default:
+			add(path+".state", logic.FieldInvalid, "must be UNRELEASED, RELEASED or ARCHIVED")
+		}
+	}
+
+	for n := range a.CustomFields {
+		path := fmt.Sprintf("custom_fields[%d]", n)
+		if err := logic.ValidateCustomField(&customFields[n]); err != nil && errors.As(err, &verr) {
+			for _, fe := range verr.Fields {
+				add(path+"."+fe.Field, fe.Code, fe.Message)
+			}
+		}
+		if slices.ContainsFunc(customFields[:n], func(o logic.CustomField) bool { return o.Key == customFields[n].Key }) {
+			add(path+".key", logic.FieldInvalid, "duplicate custom field key")
+		}
+	}
+
+	sprints := make(map[int]bool, len(a.Sprints))
+	active := 0
+	for n, sp := range a.Sprints {
+		path := fmt.Sprintf("sprints[%d]", n)
+		switch {
+		case sp.ID <= 0:
+			add(path+".id", logic.FieldInvalid, "must be a positive integer")
+		case sprints[sp.ID]:
+			add(path+".id", logic.FieldInvalid, "duplicate sprint id")
+		}
+		sprints[sp.ID] = true
+
+		if strings.TrimSpace(sp.Name) == "" {
+			add(path+".name", logic.FieldRequired, "must not be empty")
+		}
+		switch sp.State {
+		case "", logic.SprintFuture, logic.SprintClosed:
+		case logic.SprintActive:
+			active++
+			if active > 1 {
+				add(path+".state", logic.FieldInvalid, "only one sprint may be active")
+			}
+		default:
+			add(path+".state", logic.FieldInvalid, "must be FUTURE, ACTIVE or CLOSED")
+		}
+		if sp.State != "" && sp.State != logic.SprintFuture && (sp.StartedAt.IsZero() || !sp.EndsAt.After(sp.StartedAt)) {
+			add(path+".ends_at", logic.FieldInvalid, "a started sprint needs started_at before ends_at")
+		}
+		if sp.State == logic.SprintClosed && sp.ClosedAt.IsZero() {
+			add(path+".closed_at", logic.FieldRequired, "must not be empty")
+		}
+	}
+
+	parents := make(map[int]int, len(a.Issues))
+	for _, i := range a.Issues {
+		if i.ID > 0 {
+			if _, dup := parents[i.ID]; !dup {
+				parents[i.ID] = i.ParentID
+			}
+		}
+	}
+
+	seen := make(map[int]bool, len(a.Issues))
+	for n, i := range a.Issues {
+		path := fmt.Sprintf("issues[%d]", n)
+		switch {
+		case i.ID <= 0:
+			add(path+".id", logic.FieldInvalid, "must be a positive integer")
+		case seen[i.ID]:
+			add(path+".id", logic.FieldInvalid, "duplicate issue id")
+		}
+		seen[i.ID] = true
+
+		if strings.TrimSpace(i.Title) == "" {
+			add(path+".title", logic.FieldRequired, "must not be empty")
+		}
+		if !wf.HasStatus(i.Status) {
+			add(path+".status", logic.FieldInvalid, "must be a status of the project workflow")
+		}
+		if i.Type != "" && !slices.Contains(logic.IssueTypes, i.Type) {
+			add(path+".type", logic.FieldInvalid, "must be one of "+strings.Join(logic.IssueTypes, ", "))
+		}
+		if i.Priority != "" && !slices.Contains(logic.Priorities, i.Priority) {
+			add(path+".priority", logic.FieldInvalid, "must be one of "+strings.Join(logic.Priorities, ", "))
+		}
+		if i.SprintID != 0 && !sprints[i.SprintID] {
+			add(path+".sprint_id", logic.FieldInvalid, "must refer to a sprint in the archive")
+		}
+		if i.Resolution != "" {
+			switch {
+			case !slices.Contains(logic.Resolutions, i.Resolution):
+				add(path+".resolution", logic.FieldInvalid, "must be one of "+strings.Join(logic.Resolutions, ", "))
+			case wf.HasStatus(i.Status) && category(wf, i.Status) != logic.CategoryDone:
+				add(path+".resolution", logic.FieldInvalid, "only issues in a DONE status have a resolution")
+			}
+		}
+		if i.StoryPoints < 0 {
+			add(path+".story_points", logic.FieldInvalid, "must not be negative")
+		}
+		if i.OriginalEstimateSeconds < 0 {
+			add(path+".original_estimate_seconds", logic.FieldInvalid, "must not be negative")
+		}
+		if i.RemainingEstimateSeconds < 0 {
+			add(path+".remaining_estimate_seconds", logic.FieldInvalid, "must not be negative")
+		}
+		if i.FixVersionID != 0 && !versions[i.FixVersionID] {
+			add(path+".fix_version_id", logic.FieldInvalid, "must refer to a version in the archive")
+		}
+		for _, id := range i.ComponentIDs {
+			if !components[id] {
+				add(path+".component_ids", logic.FieldInvalid, "must refer to components in the archive")
+				break
+			}
+		}
+		if _, err := logic.ValidateCustomValues(customFields, i.CustomFields); err != nil && errors.As(err, &verr) {
+			for _, fe := range verr.Fields {
+				add(path+"."+fe.Field, fe.Code, fe.Message)
+			}
+		}
+		if i.ParentID != 0 {
+			if _, ok := parents[i.ParentID]; !ok || i.ParentID == i.ID {
+				add(path+".parent_id", logic.FieldInvalid, "must refer to another issue in the archive")
+			} else if hasCycle(parents, i.ID) {
+				add(path+".parent_id", logic.FieldInvalid, "parent chain loops back to the issue")
+			}
+		}
+		for _, l := range i.Labels {
+			if !logic.ValidLabel(l) {
+				add(path+".labels", logic.FieldInvalid, "labels must be non-empty and contain no spaces")
+				break
+			}
+		}
+		for c, comment := range i.Comments {
+			cpath := fmt.Sprintf("%s.comments[%d]", path, c)
+			if strings.TrimSpace(comment.Author) == "" {
+				add(cpath+".author", logic.FieldRequired, "must not be empty")
+			}
+			if strings.TrimSpace(comment.Body) == "" {
+				add(cpath+".body", logic.FieldRequired, "must not be empty")
+			}
+		}
+		for w, wl := range i.Worklogs {
+			wpath := fmt.Sprintf("%s.worklogs[%d]", path, w)
+			if strings.TrimSpace(wl.Author) == "" {
+				add(wpath+".author", logic.FieldRequired, "must not be empty")
+			}
+			if wl.TimeSpentSeconds <= 0 {
+				add(wpath+".time_spent_seconds", logic.FieldInvalid, "must be a positive integer")
+			}
+			if wl.ReducedSeconds < 0 || wl.ReducedSeconds > wl.TimeSpentSeconds {
+				add(wpath+".reduced_seconds", logic.FieldInvalid, "must be between 0 and time_spent_seconds")
+			}
+			if wl.StartedAt.IsZero() {
+				add(wpath+".started_at", logic.FieldRequired, "must not be empty")
+			}
+			if wl.CreatedAt.IsZero() {
+				add(wpath+".created_at", logic.FieldRequired, "must not be empty")
+			}
+		}
+		for h, change := range i.History {
+			hpath := fmt.Sprintf("%s.history[%d]", path, h)
+			if !wf.HasStatus(change.To) {
+				add(hpath+".to", logic.FieldInvalid, "must be a status of the project workflow")
+			}
+			switch {
+			case change.At.IsZero():
+				add(hpath+".at", logic.FieldRequired, "must not be empty")
+			case h > 0 && change.At.Before(i.History[h-1].At):
+				add(hpath+".at", logic.FieldInvalid, "must not be before the previous change")
+			}
+		}
+	}
+
+	for n, sp := range a.Sprints {
+		for _, id := range sp.Incomplete {
+			if !seen[id] {
+				add(fmt.Sprintf("sprints[%d].incomplete", n), logic.FieldInvalid, "must refer to issues in the archive")
+				break
+			}
+		}
+	}
+
+	for n, l := range a.Links {
+		path := fmt.Sprintf("links[%d]", n)
+		if !slices.Contains(logic.LinkTypes, l.Type) {
+			add(path+".type", logic.FieldInvalid, "must be one of "+strings.Join(logic.LinkTypes, ", "))
+		}
+		if !seen[l.FromID] || !seen[l.ToID] || l.FromID == l.ToID {
+			add(path, logic.FieldInvalid, "must connect two different issues of the archive")
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return logic.NewValidationError(ErrInvalidArchive, fields...)
+}
+
+// hasCycle reports whether following parents from id leads back to id.
+func hasCycle(parents map[int]int, id int) bool {
+	p := parents[id]
+	for steps := 0; p != 0 && steps <= len(parents); steps++ {
+		if p == id {
+			return true
+		}
+		p = parents[p]
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+// Package automation runs the automation rules of projects: it listens to
+// issue events, checks the conditions of the rules they trigger and carries
+// out the actions through the use-case layer, like a user would.
+package automation
+
+import (
+	"MiniJira/internal/events"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/usecase"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Author signs the comments left by rules and is the actor of their
+// transitions.
+const Author = "automation"
+
+type Engine struct {
+	service *usecase.Service
+	logger  logrus.FieldLogger
+	now     func() time.Time
+}
+
+func NewEngine(service *usecase.Service, logger logrus.FieldLogger) *Engine {
+	return &Engine{service: service, logger: logger, now: time.Now}
+}
+
+// Run handles events until ctx is done. The engine subscribes with a
+// queue, so a burst of events, such as an import, and the events its own
+// actions publish wait for their turn instead of being missed.
+func (e *Engine) Run(ctx context.Context) {
+	sub := e.service.SubscribeQueued(nil)
+	defer sub.Close()
+
+	e.consume(ctx, sub)
+}
+
+func (e *Engine) consume(ctx context.Context, sub *events.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			e.handle(ctx, ev)
+		}
+	}
+}
+
+// handle runs the rules an event triggers, in the order they were created.
+func (e *Engine) handle(ctx context.Context, ev events.Event) {
+	switch ev.Type {
+	case events.IssueCreated:
+		e.fire(ctx, ev, logic.TriggerIssueCreated, ev.Issue)
+	case events.IssueUpdated:
+		e.fire(ctx, ev, logic.TriggerIssueUpdated, ev.Issue)
+	case events.IssueTransitioned:
+		e.fire(ctx, ev, logic.TriggerIssueTransitioned, ev.Issue)
+
+		parent, ok, err := e.service.DoneParent(ctx, ev.Issue.ID)
+		if err != nil {
+			e.logger.WithError(err).WithField("issue_id", ev.Issue.ID).Error("automation: look up parent")
+			return
+		}
+		if ok {
+			e.fire(ctx, ev, logic.TriggerSubtasksDone, parent)
+		}
+	}
+}
+
+func (e *Engine) fire(ctx context.Context, ev events.Event, trigger string, issue logic.Issue) {
+	rules, err := e.service.ListRules(ctx, issue.ProjectKey)
+	if err != nil {
+		e.logger.WithError(err).WithField("project", issue.ProjectKey).Error("automation: list rules")
+		return
+	}
+
+	for _, r := range rules {
+		if !r.Enabled || r.Trigger != trigger {
+			continue
+		}
+		// Transition conditions only apply to the event's own issue.
+		from, to := ev.FromStatus, ev.ToStatus
+		if issue.ID != ev.Issue.ID {
+			from, to = "", ""
+		}
+		if !r.Matches(issue, from, to) {
+			continue
+		}
+
+		x := logic.RuleExecution{
+			RuleID:     r.ID,
+			RuleName:   r.Name,
+			ProjectKey: issue.ProjectKey,
+			IssueID:    issue.ID,
+			Trigger:    trigger,
+			Chain:      append(slices.Clone(ev.Rules), r.ID),
+			At:         e.now().UTC(),
+		}
+		switch {
+		case slices.Contains(ev.Rules, r.ID):
+			x.Status = logic.ExecutionSkipped
+			x.Error = "the rule already ran in this chain"
+		case len(ev.Rules) >= logic.MaxRuleChain:
+			x.Status = logic.ExecutionSkipped
+			x.Error = fmt.Sprintf("chain reached the limit of %d rules", logic.MaxRuleChain)
+		default:
+			x.Actions, err = e.run(events.WithRules(ctx, x.Chain), r, issue.ID)
+			x.Status = logic.ExecutionSucceeded
+			if err != nil {
+				x.Status = logic.ExecutionFailed
+				x.Error = err.Error()
+			}
+		}
+
+		e.service.RecordRuleExecution(ctx, x)
+	}
+}
+
+// run carries out the actions of a rule in order and stops at the first
+// that fails. It returns how many succeeded.
+func (e *Engine) run(ctx context.Context, r logic.Rule, issueID int) (int, error) {
+	for n, a := range r.Actions {
+		var err error
+		switch a.Type {
+		case logic.ActionAssign:
+			_, err = e.service.UpdateIssue(ctx, issueID, logic.IssuePatch{Assignee: &a.Value})
+		case logic.ActionAddLabel:
+			_, err = e.service.UpdateIssue(ctx, issueID, logic.IssuePatch{AddLabels: []string{a.Value}})
+		case logic.ActionRemoveLabel:
+			_, err = e.service.UpdateIssue(ctx, issueID, logic.IssuePatch{RemoveLabels: []string{a.Value}})
+		case logic.ActionSetPriority:
+			_, err = e.service.UpdateIssue(ctx, issueID, logic.IssuePatch{Priority: &a.Value})
+		case logic.ActionTransition:
+			in := logic.IssueTransition{ToStatus: a.Value, Actor: Author, Resolution: a.Resolution}
+			_, err = e.service.TransitionIssueWith(ctx, issueID, in)
+		case logic.ActionComment:
+			_, err = e.service.AddComment(ctx, issueID, Author, a.Value)
+		default:
+			err = fmt.Errorf("%w: unknown action %q", logic.ErrInvalidRule, a.Type)
+		}
+		if err != nil {
+			return n, fmt.Errorf("%s: %w", a.Type, err)
+		}
+	}
+
+	return len(r.Actions), nil
+}
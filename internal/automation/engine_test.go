@@ -0,0 +1,326 @@
+package automation
+
+import (
+	"MiniJira/internal/events"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/store/memory"
+	"MiniJira/internal/usecase"
+	"context"
+	"io"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// newTestEngine returns a service with a PAY project and a settle function
+// that makes the engine handle the events published so far, and those its
+// actions publish in turn.
+func newTestEngine(t *testing.T) (*usecase.Service, func()) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	service := usecase.NewService(memory.NewStore(), events.NewBus(), nil)
+	engine := NewEngine(service, logger)
+	sub := service.Subscribe(1024, nil)
+	t.Cleanup(sub.Close)
+
+	settle := func() {
+		for {
+			select {
+			case ev := <-sub.Events():
+				engine.handle(context.Background(), ev)
+			default:
+				return
+			}
+		}
+	}
+
+	if _, err := service.CreateProject(context.Background(), "PAY", "Payments"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return service, settle
+}
+
+func mustCreateRule(t *testing.T, service *usecase.Service, r logic.Rule) logic.Rule {
+	t.Helper()
+
+	r.Enabled = true
+	created, err := service.CreateRule(context.Background(), "PAY", r)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return created
+}
+
+func TestEngine_CreatedBugIsEscalated(t *testing.T) {
+	service, settle := newTestEngine(t)
+	ctx := context.Background()
+
+	rule := mustCreateRule(t, service, logic.Rule{
+		Name:    "Escalate",
+		Trigger: logic.TriggerIssueCreated,
+		Conditions: []logic.RuleCondition{
+			{Field: logic.ConditionType, Value: logic.TypeBug},
+			{Field: logic.ConditionPriority, Value: logic.PriorityHighest},
+		},
+		Actions: []logic.RuleAction{
+			{Type: logic.ActionAssign, Value: "oncall"},
+			{Type: logic.ActionAddLabel, Value: "urgent"},
+			{Type: logic.ActionComment, Value: "Paged on-call."},
+		},
+	})
+
+	bug, _ := service.CreateIssueFrom(ctx, "PAY", logic.NewIssue{Title: "Checkout down", Type: logic.TypeBug, Priority: logic.PriorityHighest})
+	task, _ := service.CreateIssueFrom(ctx, "PAY", logic.NewIssue{Title: "Refactor", Priority: logic.PriorityHighest})
+	settle()
+
+	got, _ := service.GetIssue(ctx, bug.ID)
+	if got.Assignee != "oncall" || !slices.Contains(got.Labels, "urgent") {
+		t.Fatalf("expected the bug escalated, got %+v", got)
+	}
+	comments, _ := service.ListComments(ctx, bug.ID)
+	if len(comments) != 1 || comments[0].Author != Author {
+		t.Fatalf("expected a comment by automation, got %+v", comments)
+	}
+	if other, _ := service.GetIssue(ctx, task.ID); other.Assignee != "" {
+		t.Fatalf("expected the task left alone, got %+v", other)
+	}
+
+	log, _ := service.ListRuleExecutions(ctx, "PAY", 0)
+	if len(log) != 1 || log[0].Status != logic.ExecutionSucceeded || log[0].Actions != 3 || !slices.Equal(log[0].Chain, []int{rule.ID}) {
+		t.Fatalf("expected one successful execution, got %+v", log)
+	}
+}
+
+func TestEngine_SubtasksDoneTransitionsParent(t *testing.T) {
+	service, settle := newTestEngine(t)
+	ctx := context.Background()
+
+	mustCreateRule(t, service, logic.Rule{
+		Name:    "Close parent",
+		Trigger: logic.TriggerSubtasksDone,
+		Actions: []logic.RuleAction{{Type: logic.ActionTransition, Value: logic.StatusDone, Resolution: logic.ResolutionDone}},
+	})
+
+	parent, _ := service.CreateIssue(ctx, "PAY", "Checkout")
+	sub, _ := service.CreateIssueFrom(ctx, "PAY", logic.NewIssue{Title: "API", Type: logic.TypeSubtask, ParentID: parent.ID})
+	for _, step := range []struct {
+		id     int
+		status string
+	}{{parent.ID, logic.StatusInProgress}, {sub.ID, logic.StatusInProgress}, {sub.ID, logic.StatusDone}} {
+		in := logic.IssueTransition{ToStatus: step.status, Resolution: logic.ResolutionFixed}
+		if _, err := service.TransitionIssueWith(ctx, step.id, in); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	settle()
+
+	if got, _ := service.GetIssue(ctx, parent.ID); got.Status != logic.StatusDone || got.Resolution != logic.ResolutionDone {
+		t.Fatalf("expected the parent done, got %q, %q", got.Status, got.Resolution)
+	}
+}
+
+func TestEngine_LoopProtection(t *testing.T) {
+	service, settle := newTestEngine(t)
+	ctx := context.Background()
+
+	// Each rule undoes the other, so they would flip the label forever.
+	add := mustCreateRule(t, service, logic.Rule{
+		Name:       "Add",
+		Trigger:    logic.TriggerIssueUpdated,
+		Conditions: []logic.RuleCondition{{Field: logic.ConditionAssignee, Value: "bob"}},
+		Actions:    []logic.RuleAction{{Type: logic.ActionAddLabel, Value: "flip"}},
+	})
+	remove := mustCreateRule(t, service, logic.Rule{
+		Name:       "Remove",
+		Trigger:    logic.TriggerIssueUpdated,
+		Conditions: []logic.RuleCondition{{Field: logic.ConditionLabel, Value: "flip"}},
+		Actions:    []logic.RuleAction{{Type: logic.ActionRemoveLabel, Value: "flip"}},
+	})
+
+	issue, _ := service.CreateIssue(ctx, "PAY", "Checkout")
+	bob := "bob"
+	if _, err := service.UpdateIssue(ctx, issue.ID, logic.IssuePatch{Assignee: &bob}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	settle()
+
+	log, _ := service.ListRuleExecutions(ctx, "PAY", 0)
+	slices.Reverse(log)
+	var chains [][]int
+	for _, x := range log {
+		chains = append(chains, x.Chain)
+	}
+	want := [][]int{{add.ID}, {add.ID, add.ID}, {add.ID, remove.ID}, {add.ID, remove.ID, add.ID}}
+	if !slices.EqualFunc(chains, want, slices.Equal) {
+		t.Fatalf("expected the chain cut whenever add came round again, got %v", chains)
+	}
+	for i, status := range []string{logic.ExecutionSucceeded, logic.ExecutionSkipped, logic.ExecutionSucceeded, logic.ExecutionSkipped} {
+		if log[i].Status != status {
+			t.Fatalf("entry %d: expected %s, got %+v", i, status, log[i])
+		}
+	}
+
+	// A change made at the end of a full chain triggers nothing more.
+	tagged := events.WithRules(ctx, []int{10, 11, 12, 13, 14})
+	if _, err := service.UpdateIssue(tagged, issue.ID, logic.IssuePatch{}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	settle()
+
+	log, _ = service.ListRuleExecutions(ctx, "PAY", 1)
+	if len(log) != 1 || log[0].Status != logic.ExecutionSkipped || len(log[0].Chain) != logic.MaxRuleChain+1 {
+		t.Fatalf("expected add skipped at the chain limit, got %+v", log)
+	}
+}
+
+func TestEngine_FailedActionIsRecorded(t *testing.T) {
+	service, settle := newTestEngine(t)
+	ctx := context.Background()
+
+	mustCreateRule(t, service, logic.Rule{
+		Name:    "Skip ahead",
+		Trigger: logic.TriggerIssueCreated,
+		Actions: []logic.RuleAction{
+			{Type: logic.ActionAddLabel, Value: "fast"},
+			{Type: logic.ActionTransition, Value: logic.StatusDone},
+		},
+	})
+
+	service.CreateIssue(ctx, "PAY", "Checkout")
+	settle()
+
+	log, _ := service.ListRuleExecutions(ctx, "PAY", 0)
+	if len(log) != 1 || log[0].Status != logic.ExecutionFailed || log[0].Actions != 1 || log[0].Error == "" {
+		t.Fatalf("expected a failed execution after one action, got %+v", log)
+	}
+}
+
+func TestEngine_ActionsDoNotUndoConcurrentTransitions(t *testing.T) {
+	service, _ := newTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	sub := service.SubscribeQueued(nil)
+	defer sub.Close()
+	go NewEngine(service, logger).consume(ctx, sub)
+
+	mustCreateRule(t, service, logic.Rule{
+		Name:    "Tag moves",
+		Trigger: logic.TriggerIssueTransitioned,
+		Actions: []logic.RuleAction{{Type: logic.ActionAddLabel, Value: "moved"}},
+	})
+
+	// Each issue goes round the workflow while the rule updates it; an
+	// update that wrote back a stale status would make the next
+	// transition fail.
+	steps := []logic.IssueTransition{
+		{ToStatus: logic.StatusInProgress},
+		{ToStatus: logic.StatusDone, Resolution: logic.ResolutionFixed},
+		{ToStatus: logic.StatusOpen},
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for range 10 {
+		issue, err := service.CreateIssue(ctx, "PAY", "Checkout")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		wg.Go(func() {
+			for range 10 {
+				for _, step := range steps {
+					if _, err := service.TransitionIssueWith(ctx, issue.ID, step); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Every transition leaves one entry in the audit log once its rule
+	// has run.
+	waitForExecutions(t, service, 10*10*len(steps))
+
+	issues, _ := service.ListIssues(ctx, "PAY")
+	for _, i := range issues {
+		if i.Status != logic.StatusOpen || !slices.Contains(i.Labels, "moved") {
+			t.Fatalf("expected issue %d reopened and labeled, got %+v", i.ID, i)
+		}
+	}
+}
+
+// waitForExecutions waits until the audit log of PAY has n entries.
+func waitForExecutions(t *testing.T, service *usecase.Service, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		log, _ := service.ListRuleExecutions(context.Background(), "PAY", 0)
+		if len(log) >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d rule executions, got %d", n, len(log))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestEngine_Run_KeepsUpWithBursts(t *testing.T) {
+	service, _ := newTestEngine(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	sub := service.SubscribeQueued(nil)
+	defer sub.Close()
+	go NewEngine(service, logger).consume(ctx, sub)
+
+	mustCreateRule(t, service, logic.Rule{
+		Name:    "Tag new issues",
+		Trigger: logic.TriggerIssueCreated,
+		Actions: []logic.RuleAction{{Type: logic.ActionAddLabel, Value: "triaged"}},
+	})
+
+	// More events at once than any subscriber buffer holds, as an import
+	// publishes them.
+	ins := make([]logic.NewIssue, 3000)
+	for n := range ins {
+		ins[n] = logic.NewIssue{Title: "Imported"}
+	}
+	_, errs := service.CreateIssues(ctx, "PAY", ins)
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		issues, _ := service.ListIssues(ctx, "PAY")
+		untagged := slices.IndexFunc(issues, func(i logic.Issue) bool { return !slices.Contains(i.Labels, "triaged") })
+		if len(issues) == len(ins) && untagged < 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected every issue tagged, issue %d is not", untagged)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,151 @@
+// Package backup takes checksummed snapshots of the whole store while the
+// server keeps serving, keeps them in a directory and restores any of them.
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	Format  = "minijira.backup"
+	Version = 1
+)
+
+var (
+	ErrInvalidBackup    = errors.New("invalid backup")
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	ErrBackupNotFound   = errors.New("backup not found")
+)
+
+// Store is a store that can copy out and replace its whole state. Dump
+// must return a consistent copy without blocking writers for longer than
+// the copy takes; Load must leave the store unchanged if it fails.
+type Store interface {
+	Dump() ([]byte, error)
+	Load(data []byte) error
+}
+
+// Meta describes a backup. SHA256 is the hex digest of the store dump.
+type Meta struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+}
+
+// file is the on-disk form. The header fields come before data so List can
+// read them without loading the dump.
+type file struct {
+	Format    string          `json:"format"`
+	Version   int             `json:"version"`
+	ID        string          `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	SHA256    string          `json:"sha256"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Write encodes a store dump as a backup file.
+func Write(w io.Writer, id string, createdAt time.Time, data []byte) (Meta, error) {
+	sum := sha256.Sum256(data)
+	f := file{
+		Format:    Format,
+		Version:   Version,
+		ID:        id,
+		CreatedAt: createdAt,
+		SHA256:    hex.EncodeToString(sum[:]),
+		Data:      data,
+	}
+
+	body, err := json.Marshal(f)
+	if err != nil {
+		return Meta{}, err
+	}
+	if _, err := w.Write(body); err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{ID: id, CreatedAt: createdAt, Size: int64(len(body)), SHA256: f.SHA256}, nil
+}
+
+// Read decodes a backup file and verifies the checksum of the dump.
+func Read(r io.Reader) (Meta, []byte, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return Meta{}, nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(body, &f); err != nil {
+		return Meta{}, nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+	if err := checkHeader(f); err != nil {
+		return Meta{}, nil, err
+	}
+	if len(f.Data) == 0 || bytes.Equal(f.Data, []byte("null")) {
+		return Meta{}, nil, fmt.Errorf("%w: no data", ErrInvalidBackup)
+	}
+
+	sum := sha256.Sum256(f.Data)
+	if hex.EncodeToString(sum[:]) != f.SHA256 {
+		return Meta{}, nil, fmt.Errorf("%w: expected sha256 %s", ErrChecksumMismatch, f.SHA256)
+	}
+
+	return Meta{ID: f.ID, CreatedAt: f.CreatedAt, Size: int64(len(body)), SHA256: f.SHA256}, f.Data, nil
+}
+
+func checkHeader(f file) error {
+	if f.Format != Format {
+		return fmt.Errorf("%w: format %q, expected %q", ErrInvalidBackup, f.Format, Format)
+	}
+	if f.Version < 1 || f.Version > Version {
+		return fmt.Errorf("%w: version %d is not supported (up to %d)", ErrInvalidBackup, f.Version, Version)
+	}
+
+	return nil
+}
+
+// readHeader decodes the fields before data and stops there.
+func readHeader(r io.Reader) (file, error) {
+	var f file
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return f, fmt.Errorf("%w: not a JSON object", ErrInvalidBackup)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return f, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+
+		var dst any
+		switch tok {
+		case "format":
+			dst = &f.Format
+		case "version":
+			dst = &f.Version
+		case "id":
+			dst = &f.ID
+		case "created_at":
+			dst = &f.CreatedAt
+		case "sha256":
+			dst = &f.SHA256
+		case "data":
+			return f, checkHeader(f)
+		default:
+			dst = new(json.RawMessage)
+		}
+		if err := dec.Decode(dst); err != nil {
+			return f, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+	}
+
+	return f, fmt.Errorf("%w: no data", ErrInvalidBackup)
+}
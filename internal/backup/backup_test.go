@@ -0,0 +1,112 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeStore struct {
+	data []byte
+}
+
+func (s *fakeStore) Dump() ([]byte, error) {
+	return bytes.Clone(s.data), nil
+}
+
+func (s *fakeStore) Load(data []byte) error {
+	s.data = bytes.Clone(data)
+	return nil
+}
+
+func newTestManager(t *testing.T, store Store) *Manager {
+	m := NewManager(store, t.TempDir())
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	return m
+}
+
+func TestManager_CreateAndRestore(t *testing.T) {
+	store := &fakeStore{data: []byte(`{"issues":[1]}`)}
+	m := newTestManager(t, store)
+
+	first, err := m.Create()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	store.data = []byte(`{"issues":[1,2]}`)
+	second, err := m.Create()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first.ID >= second.ID {
+		t.Fatalf("expected increasing ids, got %s and %s", first.ID, second.ID)
+	}
+
+	list, err := m.List()
+	if err != nil || len(list) != 2 || list[0].SHA256 != first.SHA256 {
+		t.Fatalf("unexpected list: %+v, %v", list, err)
+	}
+
+	if _, err := m.Restore(first.ID, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if string(store.data) != `{"issues":[1]}` {
+		t.Fatalf("expected the first snapshot restored, got %s", store.data)
+	}
+
+	before, err := m.Before(second.CreatedAt.Add(-time.Nanosecond))
+	if err != nil || before.ID != first.ID {
+		t.Fatalf("expected the first backup, got %+v, %v", before, err)
+	}
+	if _, err := m.Before(first.CreatedAt.Add(-time.Second)); !errors.Is(err, ErrBackupNotFound) {
+		t.Fatalf("expected ErrBackupNotFound, got %v", err)
+	}
+}
+
+func TestManager_RejectsTamperedBackup(t *testing.T) {
+	store := &fakeStore{data: []byte(`{"issues":[1]}`)}
+	m := newTestManager(t, store)
+
+	meta, err := m.Create()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	path := filepath.Join(m.dir, meta.ID+fileSuffix)
+	body, _ := os.ReadFile(path)
+	body = bytes.Replace(body, []byte(`[1]`), []byte(`[7]`), 1)
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store.data = []byte(`{"issues":[]}`)
+	_, err = m.Restore(meta.ID, false)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+	if string(store.data) != `{"issues":[]}` {
+		t.Fatalf("expected the store untouched, got %s", store.data)
+	}
+}
+
+func TestManager_UnknownID(t *testing.T) {
+	m := newTestManager(t, &fakeStore{})
+
+	for _, id := range []string{"20261018T120000.000Z", "../etc/passwd"} {
+		if _, err := m.Restore(id, true); !errors.Is(err, ErrBackupNotFound) {
+			t.Fatalf("%s: expected ErrBackupNotFound, got %v", id, err)
+		}
+	}
+}
+
+func TestRead_RejectsOtherFormats(t *testing.T) {
+	_, _, err := Read(bytes.NewReader([]byte(`{"format":"minijira.project","version":1,"data":{}}`)))
+	if !errors.Is(err, ErrInvalidBackup) {
+		t.Fatalf("expected ErrInvalidBackup, got %v", err)
+	}
+}
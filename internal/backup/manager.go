@@ -0,0 +1,203 @@
+package backup
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fileSuffix = ".backup.json"
+	idLayout   = "20060102T150405.000Z"
+)
+
+// Manager takes backups of a store into a directory and restores them.
+type Manager struct {
+	store Store
+	dir   string
+	now   func() time.Time
+
+	// mu serializes Create so that IDs stay unique and ordered.
+	mu     sync.Mutex
+	lastAt time.Time
+}
+
+func NewManager(store Store, dir string) *Manager {
+	return &Manager{store: store, dir: dir, now: time.Now}
+}
+
+// Create dumps the store and writes the backup atomically: to a temporary
+// file first, synced, then renamed into place.
+func (m *Manager) Create() (Meta, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	at := m.now().UTC().Truncate(time.Millisecond)
+	if !at.After(m.lastAt) {
+		at = m.lastAt.Add(time.Millisecond)
+	}
+	id := at.Format(idLayout)
+
+	data, err := m.store.Dump()
+	if err != nil {
+		return Meta{}, fmt.Errorf("dump store: %w", err)
+	}
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return Meta{}, err
+	}
+	tmp, err := os.CreateTemp(m.dir, ".tmp-*")
+	if err != nil {
+		return Meta{}, err
+	}
+	defer os.Remove(tmp.Name())
+
+	meta, err := Write(tmp, id, at, data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return Meta{}, err
+	}
+	if err := os.Rename(tmp.Name(), m.path(id)); err != nil {
+		return Meta{}, err
+	}
+	m.lastAt = at
+
+	return meta, nil
+}
+
+// List returns the backups in the directory, oldest first. Files that are
+// not readable backups are skipped.
+func (m *Manager) List() ([]Meta, error) {
+	entries, err := os.ReadDir(m.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return []Meta{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Meta{}
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), fileSuffix)
+		if !ok || e.IsDir() {
+			continue
+		}
+		meta, err := m.stat(id)
+		if err != nil {
+			continue
+		}
+		res = append(res, meta)
+	}
+	slices.SortFunc(res, func(a, b Meta) int { return cmp.Compare(a.ID, b.ID) })
+
+	return res, nil
+}
+
+func (m *Manager) stat(id string) (Meta, error) {
+	f, err := os.Open(m.path(id))
+	if err != nil {
+		return Meta{}, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return Meta{}, err
+	}
+	h, err := readHeader(f)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return Meta{ID: id, CreatedAt: h.CreatedAt, Size: info.Size(), SHA256: h.SHA256}, nil
+}
+
+// Open returns the backup file for download.
+func (m *Manager) Open(id string) (io.ReadCloser, Meta, error) {
+	if !validID(id) {
+		return nil, Meta{}, ErrBackupNotFound
+	}
+	meta, err := m.stat(id)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, Meta{}, ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	f, err := os.Open(m.path(id))
+	if err != nil {
+		return nil, Meta{}, err
+	}
+
+	return f, meta, nil
+}
+
+// Restore verifies a stored backup and loads it into the store.
+func (m *Manager) Restore(id string, dryRun bool) (Meta, error) {
+	rc, _, err := m.Open(id)
+	if err != nil {
+		return Meta{}, err
+	}
+	defer rc.Close()
+
+	return m.RestoreFrom(rc, dryRun)
+}
+
+// RestoreFrom verifies a backup file and loads it into the store. A dry
+// run stops after the format and checksum checks.
+func (m *Manager) RestoreFrom(r io.Reader, dryRun bool) (Meta, error) {
+	meta, data, err := Read(r)
+	if err != nil {
+		return Meta{}, err
+	}
+	if dryRun {
+		return meta, nil
+	}
+
+	if err := m.store.Load(data); err != nil {
+		return Meta{}, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+
+	return meta, nil
+}
+
+// Before returns the newest backup taken at or before t. Backups are
+// snapshots, so restoring it loses the changes made between it and t.
+func (m *Manager) Before(t time.Time) (Meta, error) {
+	list, err := m.List()
+	if err != nil {
+		return Meta{}, err
+	}
+
+	for i := len(list) - 1; i >= 0; i-- {
+		if !list[i].CreatedAt.After(t) {
+			return list[i], nil
+		}
+	}
+
+	return Meta{}, ErrBackupNotFound
+}
+
+func (m *Manager) path(id string) string {
+	return filepath.Join(m.dir, id+fileSuffix)
+}
+
+// validID keeps IDs from the request path inside the backup directory.
+func validID(id string) bool {
+	_, err := time.Parse(idLayout, id)
+	return err == nil
+}
@@ -1,15 +1,45 @@
 package config
 
 import (
+	"MiniJira/internal/scheduler"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
-	HTTPPort  string
-	LogLevel  string
-	LogFormat string
+	HTTPPort      string
+	LogLevel      string
+	LogFormat     string
+	TraceExporter string
+	OTLPEndpoint  string
+	ServiceName   string
+
+	DataDir           string
+	DiskMinFreeMB     int
+	EventBacklogLimit int
+	DrainDelay        time.Duration
+	IdempotencyTTL    time.Duration
+
+	// Rate limits are requests per second per client, refilling a bucket
+	// of Burst requests; a zero RPS disables the limit.
+	ReadRPS    int
+	ReadBurst  int
+	WriteRPS   int
+	WriteBurst int
+
+	// SLACheckInterval is how often SLA timers are checked for breaches;
+	// zero disables the checks.
+	SLACheckInterval time.Duration
+
+	// BackupDir holds backups; AdminToken enables the admin routes.
+	BackupDir  string
+	AdminToken string
+	// BackupSchedule takes backups on a schedule; nil disables them.
+	BackupSchedule scheduler.Schedule
 }
 
 func LoadConfig() (Config, error) {
@@ -36,10 +66,144 @@ func LoadConfig() (Config, error) {
 		return Config{}, err
 	}
 
+	traceExporter := os.Getenv("TRACE_EXPORTER")
+	if traceExporter == "" {
+		traceExporter = "none"
+	}
+	err = Validate(traceExporter, allowedTraceExporters)
+	if err != nil {
+		return Config{}, err
+	}
+
+	otlpEndpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	if otlpEndpoint == "" {
+		otlpEndpoint = "http://localhost:4318"
+	}
+
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "minijira"
+	}
+
+	diskMinFreeMB, err := envInt("DISK_MIN_FREE_MB", 100)
+	if err != nil {
+		return Config{}, err
+	}
+
+	eventBacklogLimit, err := envInt("EVENT_BACKLOG_LIMIT", 200)
+	if err != nil {
+		return Config{}, err
+	}
+
+	drainDelay, err := envDuration("SHUTDOWN_DRAIN_DELAY", 5*time.Second)
+	if err != nil {
+		return Config{}, err
+	}
+
+	idempotencyTTL, err := envDuration("IDEMPOTENCY_TTL", 24*time.Hour)
+	if err != nil {
+		return Config{}, err
+	}
+
+	slaCheckInterval, err := envDuration("SLA_CHECK_INTERVAL", time.Minute)
+	if err != nil {
+		return Config{}, err
+	}
+
+	readRPS, err := envInt("RATE_LIMIT_READ_RPS", 50)
+	if err != nil {
+		return Config{}, err
+	}
+
+	readBurst, err := envInt("RATE_LIMIT_READ_BURST", 100)
+	if err != nil {
+		return Config{}, err
+	}
+
+	writeRPS, err := envInt("RATE_LIMIT_WRITE_RPS", 10)
+	if err != nil {
+		return Config{}, err
+	}
+
+	writeBurst, err := envInt("RATE_LIMIT_WRITE_BURST", 20)
+	if err != nil {
+		return Config{}, err
+	}
+
+	dataDir := os.Getenv("DATA_DIR")
+	backupDir := os.Getenv("BACKUP_DIR")
+	if backupDir == "" {
+		backupDir = filepath.Join(dataDir, "backups")
+	}
+
+	backupSchedule, err := envSchedule("BACKUP_SCHEDULE")
+	if err != nil {
+		return Config{}, err
+	}
+
 	return Config{
-		HTTPPort:  httpPort,
-		LogLevel:  LogLevel,
-		LogFormat: LogFormat}, nil
+		HTTPPort:          httpPort,
+		LogLevel:          LogLevel,
+		LogFormat:         LogFormat,
+		TraceExporter:     traceExporter,
+		OTLPEndpoint:      otlpEndpoint,
+		ServiceName:       serviceName,
+		DataDir:           dataDir,
+		DiskMinFreeMB:     diskMinFreeMB,
+		EventBacklogLimit: eventBacklogLimit,
+		DrainDelay:        drainDelay,
+		IdempotencyTTL:    idempotencyTTL,
+		SLACheckInterval:  slaCheckInterval,
+		ReadRPS:           readRPS,
+		ReadBurst:         readBurst,
+		WriteRPS:          writeRPS,
+		WriteBurst:        writeBurst,
+		BackupDir:         backupDir,
+		AdminToken:        os.Getenv("ADMIN_TOKEN"),
+		BackupSchedule:    backupSchedule}, nil
+}
+
+func envInt(name string, def int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative integer, got %q", name, raw)
+	}
+
+	return v, nil
+}
+
+func envDuration(name string, def time.Duration) (time.Duration, error) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := time.ParseDuration(raw)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%s must be a non-negative duration, got %q", name, raw)
+	}
+
+	return v, nil
+}
+
+// envSchedule reads a job schedule; an unset variable means no schedule.
+func envSchedule(name string) (scheduler.Schedule, error) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return nil, nil
+	}
+
+	v, err := scheduler.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return v, nil
 }
 
 var allowedLevels = map[string]struct{}{
@@ -54,6 +218,12 @@ var allowedFormats = map[string]struct{}{
 	"json": {},
 }
 
+var allowedTraceExporters = map[string]struct{}{
+	"none":   {},
+	"stdout": {},
+	"otlp":   {},
+}
+
 func Validate(check string, allow map[string]struct{}) error {
 	check = strings.TrimSpace(strings.ToLower(check))
 	if _, ok := allow[check]; !ok {
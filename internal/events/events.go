@@ -0,0 +1,252 @@
+package events
+
+import (
+	"MiniJira/internal/logic"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+type Type string
+
+const (
+	IssueCreated      Type = "issue.created"
+	IssueTransitioned Type = "issue.transitioned"
+	IssueRanked       Type = "issue.ranked"
+	IssueUpdated      Type = "issue.updated"
+	// SLABreached is published once per issue and SLA policy when the
+	// issue's timer goes over its target.
+	SLABreached Type = "sla.breached"
+)
+
+type Event struct {
+	Type       Type
+	ProjectKey string
+	Issue      logic.Issue
+	FromStatus string
+	ToStatus   string
+	// SLA is the breached timer of an sla.breached event.
+	SLA *logic.SLATimer
+	At  time.Time
+	// Rules lists the automation rules whose actions led to the event,
+	// the first one first; it is empty for changes made by people.
+	Rules []int
+}
+
+type rulesKey struct{}
+
+// WithRules marks the changes made under ctx as the work of a chain of
+// automation rules. Events published for them carry the chain.
+func WithRules(ctx context.Context, rules []int) context.Context {
+	return context.WithValue(ctx, rulesKey{}, slices.Clone(rules))
+}
+
+// RulesFrom returns the rule chain set by WithRules, nil if none.
+func RulesFrom(ctx context.Context) []int {
+	rules, _ := ctx.Value(rulesKey{}).([]int)
+
+	return slices.Clone(rules)
+}
+
+// Bus is an in-process fan-out of domain events. Publish never blocks:
+// a subscriber whose buffer is full is dropped and marked as lagged,
+// so one slow consumer cannot stall the use-case layer. Subscribers that
+// must see every event subscribe with a queue instead.
+type Bus struct {
+	mu        sync.RWMutex
+	subs      map[*Subscription]struct{}
+	listeners []func(Event)
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: make(map[*Subscription]struct{})}
+}
+
+type Subscription struct {
+	bus    *Bus
+	ch     chan Event
+	filter func(Event) bool
+	lagged bool
+	closed bool
+	// queue holds the events of a queued subscription that the channel
+	// has not taken yet; more wakes the goroutine feeding the channel and
+	// done stops it.
+	queued bool
+	queue  []Event
+	more   chan struct{}
+	done   chan struct{}
+}
+
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+func (s *Subscription) Lagged() bool {
+	s.bus.mu.RLock()
+	defer s.bus.mu.RUnlock()
+
+	return s.lagged
+}
+
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+
+	s.bus.remove(s)
+}
+
+// Subscribe registers a subscriber with a buffer of the given size.
+// A nil filter receives every event.
+func (b *Bus) Subscribe(buffer int, filter func(Event) bool) *Subscription {
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		ch:     make(chan Event, buffer),
+		filter: filter,
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	return sub
+}
+
+// SubscribeQueued registers a subscriber that is never dropped: events
+// wait in a queue of its own, however far behind it falls, and arrive in
+// order. It suits consumers that publish events themselves while handling
+// one. A nil filter receives every event.
+func (b *Bus) SubscribeQueued(filter func(Event) bool) *Subscription {
+	sub := &Subscription{
+		bus:    b,
+		ch:     make(chan Event),
+		filter: filter,
+		queued: true,
+		more:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go sub.feed()
+
+	return sub
+}
+
+// feed moves the queued events to the channel and closes it once the
+// subscription is closed.
+func (s *Subscription) feed() {
+	defer close(s.ch)
+
+	for {
+		s.bus.mu.Lock()
+		batch := s.queue
+		s.queue = nil
+		s.bus.mu.Unlock()
+
+		if len(batch) == 0 {
+			select {
+			case <-s.more:
+			case <-s.done:
+				return
+			}
+			continue
+		}
+		for _, e := range batch {
+			select {
+			case s.ch <- e:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// Listen registers fn to run synchronously inside Publish, before the
+// event is fanned out to subscribers. Listeners must be cheap.
+func (b *Bus) Listen(fn func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.listeners = append(b.listeners, fn)
+}
+
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.At.IsZero() {
+		e.At = time.Now()
+	}
+
+	b.mu.RLock()
+	listeners := b.listeners
+	b.mu.RUnlock()
+
+	for _, fn := range listeners {
+		fn(e)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subs {
+		if sub.filter != nil && !sub.filter(e) {
+			continue
+		}
+		if sub.queued {
+			sub.queue = append(sub.queue, e)
+			select {
+			case sub.more <- struct{}{}:
+			default:
+			}
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			sub.lagged = true
+			b.remove(sub)
+		}
+	}
+}
+
+// Backlog reports the number of undelivered events of the most lagging
+// subscriber that can be dropped. Queued subscribers are left out: a
+// burst only makes them wait.
+func (b *Bus) Backlog() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	max := 0
+	for sub := range b.subs {
+		if sub.queued {
+			continue
+		}
+		if n := len(sub.ch); n > max {
+			max = n
+		}
+	}
+
+	return max
+}
+
+func (b *Bus) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	delete(b.subs, sub)
+	if sub.queued {
+		sub.queue = nil
+		close(sub.done)
+		return
+	}
+	close(sub.ch)
+}
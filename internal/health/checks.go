@@ -0,0 +1,45 @@
+package health
+
+import (
+	"context"
+	"fmt"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+func PingCheck(name string, p Pinger) Check {
+	return NewCheck(name, p.Ping)
+}
+
+type Backlogger interface {
+	Backlog() int
+}
+
+// BacklogCheck fails when the fullest event subscriber has more than
+// limit undelivered events queued.
+func BacklogCheck(name string, b Backlogger, limit int) Check {
+	return NewCheck(name, func(ctx context.Context) error {
+		n := b.Backlog()
+		if n > limit {
+			return fmt.Errorf("%d events pending, limit %d", n, limit)
+		}
+		return nil
+	})
+}
+
+// DiskSpaceCheck fails when the filesystem holding dir has less than
+// minFree bytes available to the process.
+func DiskSpaceCheck(name, dir string, minFree uint64) Check {
+	return NewCheck(name, func(ctx context.Context) error {
+		free, err := freeBytes(dir)
+		if err != nil {
+			return err
+		}
+		if free < minFree {
+			return fmt.Errorf("%d bytes free in %s, need %d", free, dir, minFree)
+		}
+		return nil
+	})
+}
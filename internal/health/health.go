@@ -0,0 +1,144 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+var ErrDraining = errors.New("server is shutting down")
+
+type Check interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+type checkFunc struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+func (c checkFunc) Name() string {
+	return c.name
+}
+
+func (c checkFunc) Check(ctx context.Context) error {
+	return c.fn(ctx)
+}
+
+func NewCheck(name string, fn func(ctx context.Context) error) Check {
+	return checkFunc{name: name, fn: fn}
+}
+
+type CheckResult struct {
+	Name     string
+	Status   string
+	Error    string
+	Duration time.Duration
+}
+
+type Report struct {
+	Status string
+	Checks []CheckResult
+}
+
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Probe runs readiness checks. Checks run concurrently and each one is
+// bounded by the probe timeout, so a hung dependency reports as failed
+// instead of hanging the probe.
+type Probe struct {
+	timeout  time.Duration
+	draining atomic.Bool
+
+	mu     sync.RWMutex
+	checks []Check
+}
+
+func NewProbe(timeout time.Duration, checks ...Check) *Probe {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+
+	return &Probe{timeout: timeout, checks: checks}
+}
+
+func (p *Probe) Add(c Check) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.checks = append(p.checks, c)
+}
+
+// SetDraining makes the probe report not-ready from now on.
+func (p *Probe) SetDraining() {
+	p.draining.Store(true)
+}
+
+func (p *Probe) Ready(ctx context.Context) Report {
+	p.mu.RLock()
+	checks := make([]Check, len(p.checks))
+	copy(checks, p.checks)
+	p.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks)+1)
+	results[0] = CheckResult{Name: "shutdown", Status: StatusOK}
+	if p.draining.Load() {
+		results[0].Status = StatusFail
+		results[0].Error = ErrDraining.Error()
+	}
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i+1] = p.run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: results}
+	for _, r := range results {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+func (p *Probe) run(ctx context.Context, c Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := CheckResult{Name: c.Name(), Status: StatusOK, Duration: time.Since(start)}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+
+	return res
+}
@@ -0,0 +1,210 @@
+package httpapi
+
+import (
+	"MiniJira/internal/backup"
+	"MiniJira/internal/httpapi/middleware"
+	"MiniJira/internal/logic"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBackupSize bounds the backup file accepted by RestoreUploadV2.
+const maxBackupSize = 1 << 30
+
+type BackupResponse struct {
+	ID        string    `json:"id" example:"20261018T120000.000Z"`
+	CreatedAt time.Time `json:"created_at" example:"2026-10-18T12:00:00Z"`
+	Size      int64     `json:"size" example:"48213"`
+	SHA256    string    `json:"sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+type RestoreResponse struct {
+	DryRun bool           `json:"dry_run" example:"false"`
+	Backup BackupResponse `json:"backup"`
+}
+
+// registerAdmin adds the backup and job routes. Backups replace the whole
+// store, so the routes exist only when an admin token is configured.
+func registerAdmin(mux *http.ServeMux, h *Handler, token string) {
+	if token == "" {
+		return
+	}
+
+	admin := middleware.RequireToken(token)
+	if h.backups != nil {
+		mux.HandleFunc("POST /api/v2/admin/backups", admin(h.CreateBackupV2))
+		mux.HandleFunc("GET /api/v2/admin/backups", admin(h.ListBackupsV2))
+		mux.HandleFunc("GET /api/v2/admin/backups/{id}", admin(h.DownloadBackupV2))
+		mux.HandleFunc("POST /api/v2/admin/backups/{id}/restore", admin(h.RestoreBackupV2))
+		mux.HandleFunc("POST /api/v2/admin/restore", admin(h.RestoreUploadV2))
+	}
+	if h.jobs != nil {
+		mux.HandleFunc("GET /api/v2/admin/jobs", admin(h.ListJobsV2))
+		mux.HandleFunc("GET /api/v2/admin/jobs/{name}", admin(h.GetJobV2))
+		mux.HandleFunc("POST /api/v2/admin/jobs/{name}/run", admin(h.RunJobV2))
+	}
+}
+
+// CreateBackupV2 godoc
+// @Summary Create backup
+// @Description Takes a consistent snapshot of the whole store while the server keeps serving and stores it in the backup directory.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 201 {object} BackupResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v2/admin/backups [post]
+func (h *Handler) CreateBackupV2(w http.ResponseWriter, r *http.Request) {
+	meta, err := h.backups.Create()
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_backup")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/admin/backups/"+meta.ID)
+	WriteJSON(w, http.StatusCreated, toBackupResponse(meta))
+}
+
+// ListBackupsV2 godoc
+// @Summary List backups
+// @Description Backups in the backup directory, oldest first.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} BackupResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v2/admin/backups [get]
+func (h *Handler) ListBackupsV2(w http.ResponseWriter, r *http.Request) {
+	list, err := h.backups.List()
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_backups")
+		return
+	}
+
+	res := make([]BackupResponse, len(list))
+	for i, m := range list {
+		res[i] = toBackupResponse(m)
+	}
+
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// DownloadBackupV2 godoc
+// @Summary Download backup
+// @Description The backup file (format minijira.backup), for keeping a copy elsewhere.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Backup ID"
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/admin/backups/{id} [get]
+func (h *Handler) DownloadBackupV2(w http.ResponseWriter, r *http.Request) {
+	f, meta, err := h.backups.Open(r.PathValue("id"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "download_backup")
+		return
+	}
+	defer f.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.FormatInt(meta.Size, 10))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+meta.ID+`.backup.json"`)
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, f); err != nil {
+		h.logger.WithError(err).Warn("download_backup: write failed")
+	}
+}
+
+// RestoreBackupV2 godoc
+// @Summary Restore backup
+// @Description Verifies the checksum of a stored backup and replaces the whole store with it. With dry_run=true only the checks run.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Backup ID"
+// @Param dry_run query bool false "Verify only"
+// @Success 200 {object} RestoreResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/admin/backups/{id}/restore [post]
+func (h *Handler) RestoreBackupV2(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	meta, err := h.backups.Restore(r.PathValue("id"), dryRun)
+	if err != nil {
+		h.writeServiceError(w, r, err, "restore_backup")
+		return
+	}
+
+	h.logRestore(r, meta, dryRun)
+	WriteJSON(w, http.StatusOK, RestoreResponse{DryRun: dryRun, Backup: toBackupResponse(meta)})
+}
+
+// RestoreUploadV2 godoc
+// @Summary Restore uploaded backup
+// @Description Verifies the checksum of an uploaded backup file and replaces the whole store with it. With at, the body is ignored and the stored backup nearest before that time is restored instead: backups are snapshots, so changes made between it and at are lost. With dry_run=true only the checks run.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param at query string false "Restore the newest stored backup taken at or before this RFC 3339 time"
+// @Param dry_run query bool false "Verify only"
+// @Param request body object false "Backup file"
+// @Success 200 {object} RestoreResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/admin/restore [post]
+func (h *Handler) RestoreUploadV2(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	var meta backup.Meta
+	var err error
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		at, perr := time.Parse(time.RFC3339, raw)
+		if perr != nil {
+			WriteProblem(w, r, ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_time",
+				Title:  "Invalid time",
+				Detail: "at must be an RFC 3339 time",
+				Errors: []FieldErrorResponse{{Field: "at", Code: logic.FieldInvalid, Message: "must be an RFC 3339 time"}},
+			})
+			return
+		}
+		meta, err = h.backups.Before(at)
+		if err == nil {
+			meta, err = h.backups.Restore(meta.ID, dryRun)
+		}
+	} else {
+		meta, err = h.backups.RestoreFrom(http.MaxBytesReader(w, r.Body, maxBackupSize), dryRun)
+	}
+	if err != nil {
+		h.writeServiceError(w, r, err, "restore_backup")
+		return
+	}
+
+	h.logRestore(r, meta, dryRun)
+	WriteJSON(w, http.StatusOK, RestoreResponse{DryRun: dryRun, Backup: toBackupResponse(meta)})
+}
+
+func (h *Handler) logRestore(r *http.Request, meta backup.Meta, dryRun bool) {
+	if dryRun {
+		return
+	}
+
+	h.logger.WithField("rid", middleware.GetRequestID(r)).
+		WithField("backup", meta.ID).
+		WithField("sha256", meta.SHA256).
+		Warn("store restored from backup")
+}
+
+func toBackupResponse(m backup.Meta) BackupResponse {
+	return BackupResponse{ID: m.ID, CreatedAt: m.CreatedAt, Size: m.Size, SHA256: m.SHA256}
+}
@@ -0,0 +1,140 @@
+package httpapi
+
+import (
+	"MiniJira/internal/backup"
+	"MiniJira/internal/events"
+	"MiniJira/internal/store/memory"
+	"MiniJira/internal/usecase"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newBackupTestHandler(t *testing.T) http.Handler {
+	store := memory.NewStore()
+	deps := newTestDeps()
+	deps.Service = usecase.NewService(store, events.NewBus(), nil)
+	deps.Backups = backup.NewManager(store, t.TempDir())
+	deps.AdminToken = "secret"
+
+	return NewMux(deps)
+}
+
+func adminRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer secret")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestBackupRestore_HTTP(t *testing.T) {
+	handler := newBackupTestHandler(t)
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "Fix checkout")
+
+	w := adminRequest(t, handler, http.MethodPost, "/api/v2/admin/backups", "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	var meta BackupResponse
+	decodeJSON(t, w.Body, &meta)
+
+	createIssue(t, handler, "PAY", "Refund flow")
+
+	w = adminRequest(t, handler, http.MethodGet, "/api/v2/admin/backups/"+meta.ID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+	file := w.Body.String()
+
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/backups/"+meta.ID+"/restore", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 || issues[0].Title != "Fix checkout" {
+		t.Fatalf("expected the backed up issues only, got %+v", issues)
+	}
+
+	next := createIssue(t, handler, "PAY", "After restore")
+	if next.ID != 2 {
+		t.Fatalf("expected ids to continue from the backup, got %d", next.ID)
+	}
+
+	tampered := strings.Replace(file, "Fix checkout", "Fix checkouts", 1)
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/restore", tampered)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "checksum_mismatch") {
+		t.Fatalf("expected checksum_mismatch, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/restore?dry_run=true", file)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+}
+
+func TestRestoreAt_HTTP(t *testing.T) {
+	handler := newBackupTestHandler(t)
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "Fix checkout")
+
+	w := adminRequest(t, handler, http.MethodPost, "/api/v2/admin/backups", "")
+	var first BackupResponse
+	decodeJSON(t, w.Body, &first)
+	createIssue(t, handler, "PAY", "Refund flow")
+	time.Sleep(time.Millisecond)
+	adminRequest(t, handler, http.MethodPost, "/api/v2/admin/backups", "")
+
+	before := url.QueryEscape(first.CreatedAt.Add(-time.Second).Format(time.RFC3339Nano))
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/restore?at="+before, "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status code 404 before the first backup, got %d", w.Code)
+	}
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/restore?at=yesterday", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	at := url.QueryEscape(first.CreatedAt.Format(time.RFC3339Nano))
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/restore?at="+at, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+	var resp RestoreResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Backup.ID != first.ID {
+		t.Fatalf("expected backup %s, got %s", first.ID, resp.Backup.ID)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues", "")
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 {
+		t.Fatalf("expected the first backup restored, got %d issues", len(issues))
+	}
+}
+
+func TestAdmin_HTTP_RequiresToken(t *testing.T) {
+	handler := newBackupTestHandler(t)
+
+	w := performRequest(t, handler, http.MethodGet, "/api/v2/admin/backups", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status code 401, got %d", w.Code)
+	}
+
+	w = performRequest(t, newTestHandler(), http.MethodGet, "/api/v2/admin/backups", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected admin routes to be off without a token, got %d", w.Code)
+	}
+}
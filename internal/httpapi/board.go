@@ -0,0 +1,262 @@
+package httpapi
+
+import (
+	"MiniJira/internal/events"
+	"MiniJira/internal/httpapi/middleware"
+	"MiniJira/internal/logic"
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	boardSendBuffer     = 64
+	boardEventBuffer    = 256
+	boardMaxMessageSize = 4096
+	boardHeartbeat      = 30 * time.Second
+	boardPongTimeout    = 10 * time.Second
+	boardWriteTimeout   = 10 * time.Second
+)
+
+const (
+	BoardSubscribe   = "subscribe"
+	BoardUnsubscribe = "unsubscribe"
+	BoardTransition  = "transition"
+	BoardRank        = "rank"
+
+	BoardResponse = "response"
+	BoardError    = "error"
+	BoardEvent    = "event"
+)
+
+type BoardRequest struct {
+	Type       string `json:"type" example:"transition" enums:"subscribe,unsubscribe,transition,rank"`
+	RequestID  string `json:"request_id,omitempty" example:"c1"`
+	ProjectKey string `json:"project_key,omitempty" example:"PAY"`
+	IssueID    int    `json:"issue_id,omitempty" example:"1"`
+	ToStatus   string `json:"to_status,omitempty" example:"IN_PROGRESS"`
+	// Resolution is required to move an issue into a DONE status.
+	Resolution string `json:"resolution,omitempty" example:"FIXED" enums:"FIXED,WONT_DO,DUPLICATE,CANNOT_REPRODUCE,DONE"`
+	BeforeID   int    `json:"before_id,omitempty" example:"2"`
+}
+
+type BoardMessage struct {
+	Type       string         `json:"type" example:"event" enums:"response,error,event"`
+	RequestID  string         `json:"request_id,omitempty" example:"c1"`
+	Error      string         `json:"error,omitempty"`
+	Code       string         `json:"code,omitempty" example:"invalid_transition"`
+	Event      string         `json:"event,omitempty" example:"issue.transitioned"`
+	ProjectKey string         `json:"project_key,omitempty" example:"PAY"`
+	FromStatus string         `json:"from_status,omitempty" example:"OPEN"`
+	ToStatus   string         `json:"to_status,omitempty" example:"IN_PROGRESS"`
+	Issue      *IssueResponse `json:"issue,omitempty"`
+}
+
+type boardConn struct {
+	h    *Handler
+	ws   *websocket.Conn
+	rid  string
+	send chan BoardMessage
+
+	mu       sync.RWMutex
+	projects map[string]struct{}
+}
+
+// Board godoc
+// @Summary Board collaboration channel
+// @Description WebSocket endpoint. Clients send BoardRequest messages (subscribe, unsubscribe, transition, rank)
+// @Description and receive BoardMessage responses correlated by request_id, plus events for subscribed projects.
+// @Tags board
+// @Success 101
+// @Failure 400 {object} ErrorResponse
+// @Router /ws/board [get]
+func (h *Handler) Board(w http.ResponseWriter, r *http.Request) {
+	ws, err := websocket.Accept(w, r, nil)
+	if err != nil {
+		return
+	}
+	ws.SetReadLimit(boardMaxMessageSize)
+
+	c := &boardConn{
+		h:        h,
+		ws:       ws,
+		rid:      middleware.GetRequestID(r),
+		send:     make(chan BoardMessage, boardSendBuffer),
+		projects: make(map[string]struct{}),
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	sub := h.service.Subscribe(boardEventBuffer, c.watching)
+	defer sub.Close()
+
+	go c.writeLoop(ctx, sub)
+	go c.heartbeat(ctx)
+
+	c.readLoop(ctx)
+}
+
+func (c *boardConn) watching(e events.Event) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.projects[e.ProjectKey]
+	return ok
+}
+
+func (c *boardConn) readLoop(ctx context.Context) {
+	for {
+		var req BoardRequest
+		err := wsjson.Read(ctx, c.ws, &req)
+		if err != nil {
+			c.ws.CloseNow()
+			return
+		}
+
+		if !c.reply(c.handle(ctx, req)) {
+			c.ws.Close(websocket.StatusPolicyViolation, "slow consumer")
+			return
+		}
+	}
+}
+
+func (c *boardConn) handle(ctx context.Context, req BoardRequest) BoardMessage {
+	switch req.Type {
+	case BoardSubscribe, BoardUnsubscribe:
+		key := strings.TrimSpace(req.ProjectKey)
+		if key == "" {
+			return boardFailure(req, ErrorResponse{Status: http.StatusBadRequest, Code: "invalid_request"})
+		}
+		c.mu.Lock()
+		if req.Type == BoardSubscribe {
+			c.projects[key] = struct{}{}
+		} else {
+			delete(c.projects, key)
+		}
+		c.mu.Unlock()
+		return BoardMessage{Type: BoardResponse, RequestID: req.RequestID, ProjectKey: key}
+	case BoardTransition:
+		in := logic.IssueTransition{ToStatus: req.ToStatus, Resolution: req.Resolution}
+		updated, err := c.h.service.TransitionIssueWith(ctx, req.IssueID, in)
+		if err != nil {
+			return boardFailure(req, c.problem(err, "board_transition"))
+		}
+		return boardSuccess(req, updated)
+	case BoardRank:
+		ranked, err := c.h.service.RankIssue(ctx, req.IssueID, req.BeforeID)
+		if err != nil {
+			return boardFailure(req, c.problem(err, "board_rank"))
+		}
+		return boardSuccess(req, ranked)
+	}
+
+	return boardFailure(req, ErrorResponse{Status: http.StatusBadRequest, Code: "unknown_message_type"})
+}
+
+func (c *boardConn) problem(err error, op string) ErrorResponse {
+	p, ok := problemFor(err)
+	if !ok {
+		c.h.logger.WithFields(logrus.Fields{
+			"rid": c.rid,
+			"op":  op,
+		}).WithError(err).Error("operation failed")
+	}
+
+	return p
+}
+
+// reply enqueues a message without blocking; false means the client
+// does not keep up with its own responses and must be disconnected.
+func (c *boardConn) reply(msg BoardMessage) bool {
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *boardConn) writeLoop(ctx context.Context, sub *events.Subscription) {
+	for {
+		var msg BoardMessage
+		select {
+		case <-ctx.Done():
+			return
+		case msg = <-c.send:
+		case e, ok := <-sub.Events():
+			if !ok {
+				if sub.Lagged() {
+					c.ws.Close(websocket.StatusTryAgainLater, "lagged behind, resubscribe")
+				}
+				return
+			}
+			msg = boardEventMessage(e)
+		}
+
+		wctx, cancel := context.WithTimeout(ctx, boardWriteTimeout)
+		err := wsjson.Write(wctx, c.ws, msg)
+		cancel()
+		if err != nil {
+			c.ws.CloseNow()
+			return
+		}
+	}
+}
+
+func (c *boardConn) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(boardHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pctx, cancel := context.WithTimeout(ctx, boardPongTimeout)
+			err := c.ws.Ping(pctx)
+			cancel()
+			if err != nil {
+				c.ws.Close(websocket.StatusGoingAway, "heartbeat timeout")
+				return
+			}
+		}
+	}
+}
+
+func boardSuccess(req BoardRequest, i logic.Issue) BoardMessage {
+	issue := toIssueResponse(i)
+	return BoardMessage{
+		Type:       BoardResponse,
+		RequestID:  req.RequestID,
+		ProjectKey: i.ProjectKey,
+		Issue:      &issue,
+	}
+}
+
+func boardFailure(req BoardRequest, p ErrorResponse) BoardMessage {
+	return BoardMessage{
+		Type:      BoardError,
+		RequestID: req.RequestID,
+		Error:     legacyMessage(p.Status),
+		Code:      p.Code,
+	}
+}
+
+func boardEventMessage(e events.Event) BoardMessage {
+	issue := toIssueResponse(e.Issue)
+	return BoardMessage{
+		Type:       BoardEvent,
+		Event:      string(e.Type),
+		ProjectKey: e.ProjectKey,
+		FromStatus: e.FromStatus,
+		ToStatus:   e.ToStatus,
+		Issue:      &issue,
+	}
+}
@@ -0,0 +1,148 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+)
+
+func dialBoard(t *testing.T, srv *httptest.Server) *websocket.Conn {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws/board"
+	conn, _, err := websocket.Dial(ctx, url, nil)
+	if err != nil {
+		t.Fatalf("failed to dial board: %v", err)
+	}
+	t.Cleanup(func() { conn.CloseNow() })
+
+	return conn
+}
+
+func boardRoundTrip(t *testing.T, conn *websocket.Conn, req BoardRequest) BoardMessage {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := wsjson.Write(ctx, conn, req)
+	if err != nil {
+		t.Fatalf("failed to write board request: %v", err)
+	}
+
+	return readBoard(t, conn)
+}
+
+func readBoard(t *testing.T, conn *websocket.Conn) BoardMessage {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var msg BoardMessage
+	err := wsjson.Read(ctx, conn, &msg)
+	if err != nil {
+		t.Fatalf("failed to read board message: %v", err)
+	}
+
+	return msg
+}
+
+func TestBoard_SubscribeReceivesEvents(t *testing.T) {
+	handler := newTestHandler()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	createProject(t, handler, "PAY", "Payments")
+	conn := dialBoard(t, srv)
+
+	resp := boardRoundTrip(t, conn, BoardRequest{Type: BoardSubscribe, RequestID: "s1", ProjectKey: "PAY"})
+	if resp.Type != BoardResponse || resp.RequestID != "s1" {
+		t.Fatalf("expected response to s1, got %+v", resp)
+	}
+
+	w := performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Fix checkout"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	event := readBoard(t, conn)
+	if event.Type != BoardEvent || event.Event != "issue.created" {
+		t.Fatalf("expected issue.created event, got %+v", event)
+	}
+
+	if event.Issue == nil || event.Issue.Title != "Fix checkout" {
+		t.Fatalf("expected event issue Fix checkout, got %+v", event.Issue)
+	}
+}
+
+func TestBoard_TransitionCorrelatesRequestID(t *testing.T) {
+	handler := newTestHandler()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	createProject(t, handler, "PAY", "Payments")
+	w := performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Fix checkout"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	var created IssueResponse
+	decodeJSON(t, w.Body, &created)
+
+	conn := dialBoard(t, srv)
+
+	resp := boardRoundTrip(t, conn, BoardRequest{
+		Type:      BoardTransition,
+		RequestID: "t1",
+		IssueID:   created.ID,
+		ToStatus:  logic.StatusInProgress,
+	})
+	if resp.Type != BoardResponse || resp.RequestID != "t1" {
+		t.Fatalf("expected response to t1, got %+v", resp)
+	}
+
+	if resp.Issue == nil || resp.Issue.Status != logic.StatusInProgress {
+		t.Fatalf("expected status %s, got %+v", logic.StatusInProgress, resp.Issue)
+	}
+
+	resp = boardRoundTrip(t, conn, BoardRequest{
+		Type:      BoardTransition,
+		RequestID: "t2",
+		IssueID:   created.ID,
+		ToStatus:  logic.StatusOpen,
+	})
+	if resp.Type != BoardError || resp.RequestID != "t2" || resp.Error != "conflict" {
+		t.Fatalf("expected conflict error for t2, got %+v", resp)
+	}
+}
+
+func TestBoard_Rank(t *testing.T) {
+	handler := newTestHandler()
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	createProject(t, handler, "PAY", "Payments")
+	performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"First"}`)
+	performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Second"}`)
+
+	conn := dialBoard(t, srv)
+
+	resp := boardRoundTrip(t, conn, BoardRequest{Type: BoardRank, RequestID: "r1", IssueID: 2, BeforeID: 1})
+	if resp.Type != BoardResponse || resp.Issue == nil || resp.Issue.Rank != 1 {
+		t.Fatalf("expected issue 2 ranked first, got %+v", resp)
+	}
+
+	w := performRequest(t, handler, http.MethodGet, "/issues?project_key=PAY", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+
+	if len(issues) != 2 || issues[0].Title != "Second" {
+		t.Fatalf("expected Second to be listed first, got %+v", issues)
+	}
+}
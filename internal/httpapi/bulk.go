@@ -0,0 +1,96 @@
+package httpapi
+
+import (
+	"MiniJira/internal/usecase"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// BulkQuery selects the issues of a project like the issue list filters.
+type BulkQuery struct {
+	ProjectKey string `json:"project_key" example:"PAY"`
+	Status     string `json:"status,omitempty" example:"IN_PROGRESS"`
+	Assignee   string `json:"assignee,omitempty" example:"alice"`
+	Label      string `json:"label,omitempty" example:"release-1.4"`
+	SprintID   *int   `json:"sprint_id,omitempty" example:"3"`
+}
+
+// BulkRequest applies one action to issue_ids or to the result of query.
+// Fields other than the chosen action's are ignored.
+type BulkRequest struct {
+	IssueIDs []int      `json:"issue_ids,omitempty" example:"10,11,12"`
+	Query    *BulkQuery `json:"query,omitempty"`
+	Atomic   bool       `json:"atomic" example:"false"`
+
+	Action       string   `json:"action" example:"transition" enums:"transition,assign,label,move_to_sprint"`
+	ToStatus     string   `json:"to_status,omitempty" example:"DONE"`
+	Resolution   string   `json:"resolution,omitempty" example:"FIXED" enums:"FIXED,WONT_DO,DUPLICATE,CANNOT_REPRODUCE,DONE"`
+	Assignee     string   `json:"assignee,omitempty" example:"alice"`
+	AddLabels    []string `json:"add_labels,omitempty" example:"released"`
+	RemoveLabels []string `json:"remove_labels,omitempty" example:"triage"`
+	SprintID     int      `json:"sprint_id,omitempty" example:"3"`
+}
+
+type BulkItemError struct {
+	Code   string `json:"code" example:"invalid_transition"`
+	Detail string `json:"detail" example:"invalid transition"`
+}
+
+type BulkItemResponse struct {
+	IssueID int            `json:"issue_id" example:"10"`
+	OK      bool           `json:"ok" example:"true"`
+	Issue   *IssueResponse `json:"issue,omitempty"`
+	Error   *BulkItemError `json:"error,omitempty"`
+}
+
+type BulkResponse struct {
+	Committed bool               `json:"committed" example:"true"`
+	Succeeded int                `json:"succeeded" example:"3"`
+	Failed    int                `json:"failed" example:"0"`
+	Results   []BulkItemResponse `json:"results"`
+}
+
+// BulkIssuesV2 godoc
+// @Summary Apply one change to many issues
+// @Description Applies a transition, assignee change, label change or sprint move to issue_ids or to a query result (at most 500 issues).
+// @Description Each item reports its own outcome. With atomic=true the first failure rolls back the whole batch; committed is then false and the other items fail with code rolled_back.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param request body BulkRequest true "Bulk operation"
+// @Success 200 {object} BulkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/bulk [post]
+func (h *Handler) BulkIssuesV2(w http.ResponseWriter, r *http.Request) {
+	var req BulkRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	target := usecase.BulkTarget{IssueIDs: req.IssueIDs}
+	if req.Query != nil {
+		if len(req.IssueIDs) > 0 {
+			WriteProblem(w, r, ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_bulk",
+				Title:  "Invalid bulk operation",
+				Detail: "issue_ids and query are mutually exclusive",
+			})
+			return
+		}
+		q := toIssueQuery(*req.Query)
+		target.Query = &q
+	}
+
+	report, err := h.service.Bulk(r.Context(), target, toBulkAction(req), req.Atomic)
+	if err != nil {
+		h.writeServiceError(w, r, err, "bulk")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toBulkResponse(report))
+}
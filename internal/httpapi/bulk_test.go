@@ -0,0 +1,123 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func createIssue(t *testing.T, handler http.Handler, projectKey, title string) IssueResponse {
+	w := performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"`+projectKey+`","title":"`+title+`"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("failed to create issue: %v", w.Code)
+	}
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+
+	return issue
+}
+
+func TestBulk_PerItemResults(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "One")
+	createIssue(t, handler, "PAY", "Two")
+
+	performRequest(t, handler, http.MethodPost, "/issues/transition", `{"issue_id":1,"to_status":"IN_PROGRESS"}`)
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/bulk",
+		`{"issue_ids":[1,2],"action":"transition","to_status":"DONE","resolution":"FIXED"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var resp BulkResponse
+	decodeJSON(t, w.Body, &resp)
+
+	if !resp.Committed || resp.Succeeded != 1 || resp.Failed != 1 {
+		t.Fatalf("unexpected summary: %+v", resp)
+	}
+	if !resp.Results[0].OK || resp.Results[0].Issue.Status != "DONE" {
+		t.Fatalf("expected issue 1 done, got %+v", resp.Results[0])
+	}
+	if resp.Results[1].OK || resp.Results[1].Error.Code != "invalid_transition" {
+		t.Fatalf("expected issue 2 to fail with invalid_transition, got %+v", resp.Results[1])
+	}
+}
+
+func TestBulk_AtomicRollsBack(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "One")
+	createIssue(t, handler, "PAY", "Two")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/bulk",
+		`{"issue_ids":[1,2,99],"atomic":true,"action":"assign","assignee":"alice"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var resp BulkResponse
+	decodeJSON(t, w.Body, &resp)
+
+	if resp.Committed || resp.Failed != 3 {
+		t.Fatalf("expected rolled back batch, got %+v", resp)
+	}
+	if resp.Results[0].Error.Code != "rolled_back" || resp.Results[2].Error.Code != "issue_not_found" {
+		t.Fatalf("unexpected item errors: %+v", resp.Results)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/issues/1", "")
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+	if issue.Assignee != "" {
+		t.Fatalf("expected assignment to be rolled back, got %q", issue.Assignee)
+	}
+}
+
+func TestBulk_QueryMoveToSprint(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "One")
+	createIssue(t, handler, "PAY", "Two")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/sprints", `{"name":"Sprint 1"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	performRequest(t, handler, http.MethodPatch, "/api/v2/issues/2", `{"add_labels":["release"]}`)
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/issues/bulk",
+		`{"query":{"project_key":"PAY","label":"release"},"atomic":true,"action":"move_to_sprint","sprint_id":1}`)
+
+	var resp BulkResponse
+	decodeJSON(t, w.Body, &resp)
+	if !resp.Committed || resp.Succeeded != 1 || resp.Results[0].IssueID != 2 {
+		t.Fatalf("unexpected result: %+v", resp)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues?sprint=1", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 || issues[0].ID != 2 {
+		t.Fatalf("expected only issue 2 in sprint 1, got %+v", issues)
+	}
+}
+
+func TestBulk_InvalidRequest(t *testing.T) {
+	handler := newTestHandler()
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/bulk", `{"issue_ids":[1],"action":"delete"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Code != "invalid_bulk" {
+		t.Fatalf("expected code invalid_bulk, got %s", resp.Code)
+	}
+}
@@ -0,0 +1,228 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+type CommentResponse struct {
+	ID        int       `json:"id" example:"1"`
+	IssueID   int       `json:"issue_id" example:"10"`
+	Author    string    `json:"author" example:"alice"`
+	Body      string    `json:"body" example:"Reproduced on staging."`
+	CreatedAt time.Time `json:"created_at" example:"2026-10-18T09:30:00Z"`
+}
+
+type AddCommentRequest struct {
+	Author string `json:"author" example:"alice"`
+	Body   string `json:"body" example:"Reproduced on staging."`
+}
+
+type LinkResponse struct {
+	ID     int    `json:"id" example:"1"`
+	Type   string `json:"type" example:"blocks" enums:"blocks,relates,duplicates,clones"`
+	FromID int    `json:"from_id" example:"10"`
+	ToID   int    `json:"to_id" example:"12"`
+}
+
+// LinkIssueRequest links the issue in the path to to_id: "10 blocks 12".
+type LinkIssueRequest struct {
+	Type string `json:"type" example:"blocks" enums:"blocks,relates,duplicates,clones"`
+	ToID int    `json:"to_id" example:"12"`
+}
+
+type WorkflowStatusResponse struct {
+	Name     string `json:"name" example:"IN_REVIEW"`
+	Category string `json:"category" example:"IN_PROGRESS" enums:"TODO,IN_PROGRESS,DONE"`
+}
+
+// WorkflowTransitionResponse names the hooks of a transition: conditions
+// decide who may take it, validators check its input and post functions
+// run once it is taken.
+type WorkflowTransitionResponse struct {
+	From          string   `json:"from" example:"OPEN"`
+	To            string   `json:"to" example:"IN_PROGRESS"`
+	Conditions    []string `json:"conditions,omitempty"`
+	Validators    []string `json:"validators,omitempty" example:"assignee_required"`
+	PostFunctions []string `json:"post_functions,omitempty" example:"clear_assignee"`
+}
+
+// WorkflowResponse lists statuses and allowed transitions; an empty
+// transition list allows moving between any two statuses.
+type WorkflowResponse struct {
+	ProjectKey  string                       `json:"project_key" example:"PAY"`
+	Statuses    []WorkflowStatusResponse     `json:"statuses"`
+	Transitions []WorkflowTransitionResponse `json:"transitions"`
+}
+
+// WorkflowRequest replaces a workflow. Hooks only run on listed
+// transitions, so a workflow with hooks lists its transitions.
+type WorkflowRequest struct {
+	Statuses    []WorkflowStatusResponse     `json:"statuses"`
+	Transitions []WorkflowTransitionResponse `json:"transitions,omitempty"`
+}
+
+// GetWorkflowV2 godoc
+// @Summary Get project workflow
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {object} WorkflowResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/workflow [get]
+func (h *Handler) GetWorkflowV2(w http.ResponseWriter, r *http.Request) {
+	wf, err := h.service.GetWorkflow(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_workflow")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toWorkflowResponse(wf))
+}
+
+// SaveWorkflowV2 godoc
+// @Summary Replace project workflow
+// @Description Transitions may name hooks. Built in are the validator assignee_required and the post function clear_assignee; conditions come only from hooks registered in code. The resolution and resolved_at are always handled by the transition itself.
+// @Description Every issue of the project must stay in a listed status.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body WorkflowRequest true "Workflow"
+// @Success 200 {object} WorkflowResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/workflow [put]
+func (h *Handler) SaveWorkflowV2(w http.ResponseWriter, r *http.Request) {
+	var req WorkflowRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	wf, err := h.service.SaveWorkflow(r.Context(), r.PathValue("key"), toWorkflow(req))
+	if err != nil {
+		h.writeServiceError(w, r, err, "save_workflow")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toWorkflowResponse(wf))
+}
+
+// ListCommentsV2 godoc
+// @Summary List comments of an issue
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {array} CommentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/comments [get]
+func (h *Handler) ListCommentsV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	comments, err := h.service.ListComments(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_comments")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toCommentResponses(comments))
+}
+
+// AddCommentV2 godoc
+// @Summary Comment on an issue
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Param request body AddCommentRequest true "Comment"
+// @Success 201 {object} CommentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/comments [post]
+func (h *Handler) AddCommentV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req AddCommentRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	c, err := h.service.AddComment(r.Context(), id, req.Author, req.Body)
+	if err != nil {
+		h.writeServiceError(w, r, err, "add_comment")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, toCommentResponse(c))
+}
+
+// ListLinksV2 godoc
+// @Summary List links of an issue
+// @Description Links with the issue on either end.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {array} LinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/links [get]
+func (h *Handler) ListLinksV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	links, err := h.service.ListLinks(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_links")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toLinkResponses(links))
+}
+
+// LinkIssueV2 godoc
+// @Summary Link issue to another issue
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Param request body LinkIssueRequest true "Link"
+// @Success 201 {object} LinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/links [post]
+func (h *Handler) LinkIssueV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req LinkIssueRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	l, err := h.service.LinkIssues(r.Context(), req.Type, id, req.ToID)
+	if err != nil {
+		h.writeServiceError(w, r, err, "link_issues")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, toLinkResponse(l))
+}
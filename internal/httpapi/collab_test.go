@@ -0,0 +1,184 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestComments_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Fix checkout")
+	path := "/api/v2/issues/" + strconv.Itoa(issue.ID) + "/comments"
+
+	w := performRequest(t, handler, http.MethodPost, path, `{"author":"alice","body":"Reproduced on staging."}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, path, `{"author":"alice","body":"  "}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, path, "")
+
+	var comments []CommentResponse
+	decodeJSON(t, w.Body, &comments)
+	if len(comments) != 1 || comments[0].Author != "alice" || comments[0].CreatedAt.IsZero() {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/issues/99/comments", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status code 404, got %d", w.Code)
+	}
+}
+
+func TestLinks_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	first := createIssue(t, handler, "PAY", "Fix checkout")
+	second := createIssue(t, handler, "PAY", "Release 1.4")
+
+	body := `{"type":"blocks","to_id":` + strconv.Itoa(second.ID) + `}`
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(first.ID)+"/links", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/issues/"+strconv.Itoa(second.ID)+"/links", "")
+
+	var links []LinkResponse
+	decodeJSON(t, w.Body, &links)
+	if len(links) != 1 || links[0].FromID != first.ID || links[0].Type != "blocks" {
+		t.Fatalf("unexpected links: %+v", links)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(first.ID)+"/links", `{"type":"follows","to_id":2}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+}
+
+func TestIssueTypeAndWorkflow_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	epic := createIssue(t, handler, "PAY", "Checkout")
+	if epic.Type != "TASK" || epic.Priority != "MEDIUM" {
+		t.Fatalf("expected defaults TASK/MEDIUM, got %s/%s", epic.Type, epic.Priority)
+	}
+
+	body := `{"title":"Fix validation","type":"BUG","priority":"HIGH","parent_id":` + strconv.Itoa(epic.ID) + `}`
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	var bug IssueResponse
+	decodeJSON(t, w.Body, &bug)
+	if bug.Type != "BUG" || bug.Priority != "HIGH" || bug.ParentID != epic.ID {
+		t.Fatalf("unexpected issue: %+v", bug)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Idea","type":"IDEA"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/workflow", "")
+
+	var wf WorkflowResponse
+	decodeJSON(t, w.Body, &wf)
+	if len(wf.Statuses) != 3 || wf.Statuses[0].Name != "OPEN" || wf.Statuses[0].Category != "TODO" {
+		t.Fatalf("unexpected workflow: %+v", wf)
+	}
+}
+
+func TestWorkflowHooks_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Checkout")
+	path := "/api/v2/issues/" + strconv.Itoa(issue.ID)
+
+	body := `{"statuses":[{"name":"OPEN","category":"TODO"},{"name":"DONE","category":"DONE"}],
+		"transitions":[{"from":"OPEN","to":"DONE","validators":["assignee_required"],"post_functions":["clear_assignee"]}]}`
+	w := performRequest(t, handler, http.MethodPut, "/api/v2/projects/PAY/workflow", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var wf WorkflowResponse
+	decodeJSON(t, w.Body, &wf)
+	if len(wf.Transitions) != 1 || len(wf.Transitions[0].PostFunctions) != 1 {
+		t.Fatalf("unexpected workflow: %+v", wf)
+	}
+
+	for _, hook := range []string{`"validators":["signed_off"]`, `"conditions":["only_assignee"]`, `"post_functions":["set_resolved_at"]`} {
+		w = performRequest(t, handler, http.MethodPut, "/api/v2/projects/PAY/workflow", `{"statuses":[{"name":"OPEN","category":"TODO"}],"transitions":[{"from":"OPEN","to":"OPEN",`+hook+`}]}`)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected an unknown hook rejected, got %d: %s", hook, w.Code, w.Body.String())
+		}
+	}
+
+	w = performRequest(t, handler, http.MethodPost, path+"/transitions", `{"to_status":"DONE","resolution":"FIXED"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "assignee") {
+		t.Fatalf("expected the assignee required, got %d: %s", w.Code, w.Body.String())
+	}
+
+	performRequest(t, handler, http.MethodPatch, path, `{"assignee":"alice"}`)
+
+	w = performRequest(t, handler, http.MethodPost, path+"/transitions", `{"to_status":"DONE"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodPost, path+"/transitions", `{"to_status":"DONE","resolution":"FIXED"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var done IssueResponse
+	decodeJSON(t, w.Body, &done)
+	if done.Status != "DONE" || done.Assignee != "" || done.Resolution != "FIXED" || done.ResolvedAt.IsZero() {
+		t.Fatalf("expected the post functions applied, got %+v", done)
+	}
+
+	w = performRequest(t, handler, http.MethodPut, "/api/v2/projects/PAY/workflow", `{"statuses":[{"name":"OPEN","category":"TODO"}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a workflow without DONE rejected while an issue is done, got %d", w.Code)
+	}
+}
+
+func TestResolutionAndReopen_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Checkout")
+	path := "/api/v2/issues/" + strconv.Itoa(issue.ID) + "/transitions"
+
+	performRequest(t, handler, http.MethodPost, path, `{"to_status":"IN_PROGRESS"}`)
+	w := performRequest(t, handler, http.MethodPost, path, `{"to_status":"DONE"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, path, `{"to_status":"DONE","resolution":"DUPLICATE"}`)
+
+	var done IssueResponse
+	decodeJSON(t, w.Body, &done)
+	if done.Resolution != "DUPLICATE" || done.ResolvedAt.IsZero() {
+		t.Fatalf("expected the issue resolved, got %+v", done)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, path, `{"to_status":"OPEN"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var reopened IssueResponse
+	decodeJSON(t, w.Body, &reopened)
+	if reopened.Status != "OPEN" || reopened.Resolution != "" || !reopened.ResolvedAt.IsZero() {
+		t.Fatalf("expected the resolution cleared, got %+v", reopened)
+	}
+}
@@ -0,0 +1,157 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type ComponentResponse struct {
+	ID          int    `json:"id" example:"1"`
+	ProjectKey  string `json:"project_key" example:"PAY"`
+	Name        string `json:"name" example:"Checkout"`
+	Description string `json:"description,omitempty" example:"Cart, checkout and receipts"`
+	Lead        string `json:"lead,omitempty" example:"alice"`
+}
+
+// CreateComponentRequest names a component; new issues of the component
+// without an assignee go to the lead.
+type CreateComponentRequest struct {
+	Name        string `json:"name" example:"Checkout"`
+	Description string `json:"description,omitempty" example:"Cart, checkout and receipts"`
+	Lead        string `json:"lead,omitempty" example:"alice"`
+}
+
+// UpdateComponentRequest changes only the fields present; an empty lead
+// removes it.
+type UpdateComponentRequest struct {
+	Name        *string `json:"name,omitempty" example:"Checkout"`
+	Description *string `json:"description,omitempty" example:"Cart, checkout and receipts"`
+	Lead        *string `json:"lead,omitempty" example:"bob"`
+}
+
+// ListComponentsV2 godoc
+// @Summary List components of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} ComponentResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/components [get]
+func (h *Handler) ListComponentsV2(w http.ResponseWriter, r *http.Request) {
+	components, err := h.service.ListComponents(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_components")
+		return
+	}
+
+	res := make([]ComponentResponse, len(components))
+	for i, c := range components {
+		res[i] = toComponentResponse(c)
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CreateComponentV2 godoc
+// @Summary Create a component in a project
+// @Description Names are unique within a project, ignoring case.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateComponentRequest true "Component"
+// @Success 201 {object} ComponentResponse
+// @Header 201 {string} Location "URL of the created component"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/components [post]
+func (h *Handler) CreateComponentV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateComponentRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	created, err := h.service.CreateComponent(r.Context(), r.PathValue("key"), req.Name, req.Description, req.Lead)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_component")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/components/"+strconv.Itoa(created.ID))
+	WriteJSON(w, http.StatusCreated, toComponentResponse(created))
+}
+
+// GetComponentV2 godoc
+// @Summary Get a component
+// @Tags v2
+// @Produce json
+// @Param id path int true "Component ID"
+// @Success 200 {object} ComponentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/components/{id} [get]
+func (h *Handler) GetComponentV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := h.service.GetComponent(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_component")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toComponentResponse(c))
+}
+
+// UpdateComponentV2 godoc
+// @Summary Update a component
+// @Description A new lead applies to issues created afterwards; existing issues keep their assignee.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Component ID"
+// @Param request body UpdateComponentRequest true "Fields to change"
+// @Success 200 {object} ComponentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/components/{id} [patch]
+func (h *Handler) UpdateComponentV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateComponentRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.ComponentPatch{Name: req.Name, Description: req.Description, Lead: req.Lead}
+	c, err := h.service.UpdateComponent(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_component")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toComponentResponse(c))
+}
+
+func toComponentResponse(c logic.Component) ComponentResponse {
+	return ComponentResponse{
+		ID:          c.ID,
+		ProjectKey:  c.ProjectKey,
+		Name:        c.Name,
+		Description: c.Description,
+		Lead:        c.Lead,
+	}
+}
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestComponents_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/components", `{"name":"Checkout","lead":"alice"}`)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/api/v2/components/1" {
+		t.Fatalf("expected status code 201 with a location, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/components", `{"name":"checkout"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status code 409, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Fix totals","component_ids":[1]}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+	if issue.Assignee != "alice" || !slices.Equal(issue.ComponentIDs, []int{1}) {
+		t.Fatalf("expected the issue assigned to the component lead, got %+v", issue)
+	}
+	createIssue(t, handler, "PAY", "Receipts")
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/components/1", `{"lead":"bob"}`)
+
+	var component ComponentResponse
+	decodeJSON(t, w.Body, &component)
+	if component.Lead != "bob" || component.Name != "Checkout" {
+		t.Fatalf("unexpected component: %+v", component)
+	}
+
+	for filter, want := range map[string]int{"component=1": 1, "component=0": 1, "component=x": 0} {
+		w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues?"+filter, "")
+		if want == 0 {
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("%s: expected status code 400, got %d", filter, w.Code)
+			}
+			continue
+		}
+
+		var issues []IssueResponse
+		decodeJSON(t, w.Body, &issues)
+		if len(issues) != want {
+			t.Fatalf("%s: expected %d issues, got %+v", filter, want, issues)
+		}
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/issues/"+strconv.Itoa(issue.ID), `{"component_ids":[]}`)
+
+	var updated IssueResponse
+	decodeJSON(t, w.Body, &updated)
+	if len(updated.ComponentIDs) != 0 || updated.Assignee != "alice" {
+		t.Fatalf("expected the components removed and the assignee kept, got %+v", updated)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cycle-time?component=1", "")
+
+	var rep FlowReportResponse
+	decodeJSON(t, w.Body, &rep)
+	if w.Code != http.StatusOK || len(rep.Issues) != 0 {
+		t.Fatalf("expected an empty report for the component, got %d: %s", w.Code, w.Body.String())
+	}
+}
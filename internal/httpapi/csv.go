@@ -0,0 +1,200 @@
+package httpapi
+
+import (
+	"MiniJira/internal/issuecsv"
+	"MiniJira/internal/logic"
+	"errors"
+	"io"
+	"net/http"
+)
+
+const (
+	ContentTypeCSV = "text/csv"
+
+	maxCSVImportSize = 64 << 20
+	// csvImportChunk is how many rows are read before they are created.
+	csvImportChunk = 500
+	// maxCSVRowErrors caps the error list; failures past it are only counted.
+	maxCSVRowErrors = 1000
+)
+
+type CSVRowError struct {
+	Row     int    `json:"row" example:"7"`
+	Field   string `json:"field,omitempty" example:"title"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"must not be empty"`
+}
+
+type CSVImportResponse struct {
+	Created   int           `json:"created" example:"120"`
+	Failed    int           `json:"failed" example:"2"`
+	Errors    []CSVRowError `json:"errors"`
+	Truncated bool          `json:"truncated" example:"false"`
+}
+
+// ExportIssuesCSV godoc
+// @Summary Export issues as CSV
+// @Description Issues in board order with the same filters as the issue list.
+// @Tags v2
+// @Produce text/csv
+// @Param key path string true "Project key"
+// @Param columns query string false "Comma-separated columns: id,project_key,title,status,rank,assignee,labels,sprint_id"
+// @Param status query string false "Status" Enums(OPEN,IN_PROGRESS,DONE)
+// @Param assignee query string false "Assignee"
+// @Param label query string false "Label"
+// @Param sprint query int false "Sprint ID"
+// @Param fix_version query int false "Fix version ID, 0 for issues without one"
+// @Param component query int false "Component ID, 0 for issues without one"
+// @Success 200 {string} string "CSV with a header line"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/issues/csv [get]
+func (h *Handler) ExportIssuesCSV(w http.ResponseWriter, r *http.Request) {
+	columns, err := issuecsv.ParseColumns(r.URL.Query().Get("columns"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "export_csv")
+		return
+	}
+
+	q, ok := issueQuery(w, r, r.PathValue("key"), r.URL.Query())
+	if !ok {
+		return
+	}
+
+	issues, err := h.service.FindIssues(r.Context(), q)
+	if err != nil {
+		h.writeServiceError(w, r, err, "export_csv")
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+q.ProjectKey+`-issues.csv"`)
+	w.WriteHeader(http.StatusOK)
+
+	err = issuecsv.Write(w, columns, issues)
+	if err != nil {
+		h.logger.WithError(err).Warn("export_csv: write failed")
+	}
+}
+
+// ImportIssuesCSV godoc
+// @Summary Import issues from CSV
+// @Description Creates one issue per row. The header names the columns; title, assignee, labels and cf.<key> custom fields are read, other columns are ignored.
+// @Description map renames headers, e.g. Summary:title,Owner:assignee. Rows are created in chunks of 500 as the file is read, each chunk in one transaction; each failing row is reported without stopping the import.
+// @Tags v2
+// @Accept text/csv
+// @Produce json
+// @Param key path string true "Project key"
+// @Param map query string false "Header to field mapping"
+// @Param request body string true "CSV file"
+// @Success 200 {object} CSVImportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/issues/csv [post]
+func (h *Handler) ImportIssuesCSV(w http.ResponseWriter, r *http.Request) {
+	mapping, err := issuecsv.ParseMapping(r.URL.Query().Get("map"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "import_csv")
+		return
+	}
+
+	p, err := h.service.GetProject(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "import_csv")
+		return
+	}
+
+	reader, err := issuecsv.NewReader(http.MaxBytesReader(w, r.Body, maxCSVImportSize), mapping)
+	if err != nil {
+		h.writeServiceError(w, r, err, "import_csv")
+		return
+	}
+
+	resp := CSVImportResponse{Errors: []CSVRowError{}}
+	fail := func(errs ...CSVRowError) {
+		resp.Failed++
+		if len(resp.Errors)+len(errs) > maxCSVRowErrors {
+			resp.Truncated = true
+			return
+		}
+		resp.Errors = append(resp.Errors, errs...)
+	}
+
+	// Rows are created in chunks as they are read, so neither the file nor
+	// the store lock is held for the whole import.
+	type importRow struct {
+		line  int
+		issue logic.NewIssue
+		errs  []CSVRowError
+	}
+	var chunk []importRow
+	flush := func() {
+		var (
+			ins []logic.NewIssue
+			at  []int
+		)
+		for i, row := range chunk {
+			if row.errs == nil {
+				ins = append(ins, row.issue)
+				at = append(at, i)
+			}
+		}
+		created, errs := h.service.CreateIssues(r.Context(), p.Key, ins)
+		resp.Created += len(created)
+		for n, err := range errs {
+			if err != nil {
+				chunk[at[n]].errs = csvRowErrors(chunk[at[n]].line, err)
+			}
+		}
+		for _, row := range chunk {
+			if row.errs != nil {
+				fail(row.errs...)
+			}
+		}
+		chunk = chunk[:0]
+	}
+
+	var readErr error
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+
+		var perr *issuecsv.ParseError
+		if err != nil && !errors.As(err, &perr) {
+			// The body itself failed (too large, connection lost); keep
+			// the rows read so far and report the failure.
+			readErr = err
+			break
+		}
+		if perr != nil {
+			chunk = append(chunk, importRow{line: perr.Line, errs: []CSVRowError{{Row: perr.Line, Code: "malformed_row", Message: perr.Err.Error()}}})
+		} else {
+			chunk = append(chunk, importRow{line: row.Line, issue: row.Issue})
+		}
+		if len(chunk) == csvImportChunk {
+			flush()
+		}
+	}
+	flush()
+	if readErr != nil {
+		fail(CSVRowError{Code: "read_failed", Message: readErr.Error()})
+	}
+
+	WriteJSON(w, http.StatusOK, resp)
+}
+
+func csvRowErrors(line int, err error) []CSVRowError {
+	var verr *logic.ValidationError
+	if errors.As(err, &verr) && len(verr.Fields) > 0 {
+		res := make([]CSVRowError, len(verr.Fields))
+		for i, f := range verr.Fields {
+			res[i] = CSVRowError{Row: line, Field: f.Field, Code: f.Code, Message: f.Message}
+		}
+		return res
+	}
+
+	p, _ := problemFor(err)
+	return []CSVRowError{{Row: line, Code: p.Code, Message: p.Detail}}
+}
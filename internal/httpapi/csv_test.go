@@ -0,0 +1,95 @@
+package httpapi
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestImportIssuesCSV_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	body := "Summary,Owner,labels\n" +
+		"Fix checkout,alice,backend\n" +
+		",bob,\n" +
+		"Refund flow,,bad;ok\n"
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues/csv?map=Summary:title,Owner:assignee", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var resp CSVImportResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Created != 2 || resp.Failed != 1 {
+		t.Fatalf("unexpected report: %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Row != 3 || resp.Errors[0].Field != "title" {
+		t.Fatalf("unexpected row errors: %+v", resp.Errors)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues/csv?columns=id,title,assignee,labels", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	want := "id,title,assignee,labels\n1,Fix checkout,alice,backend\n2,Refund flow,,bad ok\n"
+	if w.Body.String() != want {
+		t.Fatalf("expected %q, got %q", want, w.Body.String())
+	}
+}
+
+func TestImportIssuesCSV_HTTP_MissingTitleColumn(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues/csv", "name\nx\n")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Code != "invalid_csv" {
+		t.Fatalf("expected code invalid_csv, got %s", resp.Code)
+	}
+}
+
+func TestImportIssuesCSV_HTTP_Large(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/custom-fields", `{"key":"amount","name":"Amount","type":"number"}`)
+
+	// Every 1000th row has an amount that is not a number, which fails
+	// only once the issue is in the store.
+	const rows = 20000
+	var body strings.Builder
+	body.WriteString("title,cf.amount\n")
+	for i := range rows {
+		amount := strconv.Itoa(i)
+		if i%1000 == 999 {
+			amount = "lots"
+		}
+		fmt.Fprintf(&body, "Issue %d,%s\n", i, amount)
+	}
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues/csv", body.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var resp CSVImportResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Created != rows-20 || resp.Failed != 20 || resp.Errors[0].Row != 1001 || resp.Errors[0].Field != "custom_fields.amount" {
+		t.Fatalf("unexpected report: created %d, failed %d, first errors %+v", resp.Created, resp.Failed, resp.Errors[:2])
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues/csv?columns=id,title", "")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != rows-20+1 || lines[len(lines)-1] != strconv.Itoa(rows-20)+",Issue 19998" {
+		t.Fatalf("expected %d issues with consecutive ids, got %d ending in %q", rows-20, len(lines)-1, lines[len(lines)-1])
+	}
+}
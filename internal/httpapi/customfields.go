@@ -0,0 +1,228 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type CustomFieldResponse struct {
+	ID         int      `json:"id" example:"1"`
+	ProjectKey string   `json:"project_key" example:"PAY"`
+	Key        string   `json:"key" example:"severity"`
+	Name       string   `json:"name" example:"Severity"`
+	Type       string   `json:"type" example:"select" enums:"text,number,select,multi_select,date,user"`
+	Required   bool     `json:"required" example:"true"`
+	Options    []string `json:"options,omitempty" example:"S1,S2,S3"`
+}
+
+// CreateCustomFieldRequest defines a field. options are required for
+// select and multi_select fields and not allowed for the others.
+type CreateCustomFieldRequest struct {
+	Key      string   `json:"key" example:"severity"`
+	Name     string   `json:"name" example:"Severity"`
+	Type     string   `json:"type" example:"select" enums:"text,number,select,multi_select,date,user"`
+	Required bool     `json:"required,omitempty" example:"true"`
+	Options  []string `json:"options,omitempty" example:"S1,S2,S3"`
+}
+
+// UpdateCustomFieldRequest changes only the fields present; options
+// replaces the whole list.
+type UpdateCustomFieldRequest struct {
+	Name     *string   `json:"name,omitempty" example:"Severity"`
+	Required *bool     `json:"required,omitempty" example:"false"`
+	Options  *[]string `json:"options,omitempty" example:"S1,S2,S3,S4"`
+}
+
+// CustomFieldValue is a custom field value in a request: a string, a
+// number, a list of strings, or null to clear the field.
+type CustomFieldValue []string
+
+func (v *CustomFieldValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0:
+		return errors.New("empty custom field value")
+	case bytes.Equal(data, []byte("null")):
+		*v = nil
+	case data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*v = CustomFieldValue{s}
+	case data[0] == '[':
+		var list []string
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*v = list
+	default:
+		var n json.Number
+		if err := json.Unmarshal(data, &n); err != nil {
+			return errors.New("custom field values must be strings, numbers, lists of strings or null")
+		}
+		*v = CustomFieldValue{n.String()}
+	}
+
+	return nil
+}
+
+// ListCustomFieldsV2 godoc
+// @Summary List custom fields of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} CustomFieldResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/custom-fields [get]
+func (h *Handler) ListCustomFieldsV2(w http.ResponseWriter, r *http.Request) {
+	fields, err := h.service.ListCustomFields(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_custom_fields")
+		return
+	}
+
+	res := make([]CustomFieldResponse, len(fields))
+	for i, f := range fields {
+		res[i] = toCustomFieldResponse(f)
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CreateCustomFieldV2 godoc
+// @Summary Create a custom field in a project
+// @Description Keys are lowercase letters, digits and underscores starting with a letter, unique within the project, and cannot change.
+// @Description Issues carry the values under custom_fields by key. New issues must set every required field.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateCustomFieldRequest true "Custom field"
+// @Success 201 {object} CustomFieldResponse
+// @Header 201 {string} Location "URL of the created custom field"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/custom-fields [post]
+func (h *Handler) CreateCustomFieldV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateCustomFieldRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	created, err := h.service.CreateCustomField(r.Context(), r.PathValue("key"), logic.CustomField{
+		Key:      req.Key,
+		Name:     req.Name,
+		Type:     req.Type,
+		Required: req.Required,
+		Options:  req.Options,
+	})
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_custom_field")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/custom-fields/"+strconv.Itoa(created.ID))
+	WriteJSON(w, http.StatusCreated, toCustomFieldResponse(created))
+}
+
+// GetCustomFieldV2 godoc
+// @Summary Get a custom field
+// @Tags v2
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Success 200 {object} CustomFieldResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/custom-fields/{id} [get]
+func (h *Handler) GetCustomFieldV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	f, err := h.service.GetCustomField(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_custom_field")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toCustomFieldResponse(f))
+}
+
+// UpdateCustomFieldV2 godoc
+// @Summary Update a custom field
+// @Description The key and type are fixed. Existing values are checked against a new required flag or options only when an issue next changes the field.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Custom field ID"
+// @Param request body UpdateCustomFieldRequest true "Fields to change"
+// @Success 200 {object} CustomFieldResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/custom-fields/{id} [patch]
+func (h *Handler) UpdateCustomFieldV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateCustomFieldRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.CustomFieldPatch{Name: req.Name, Required: req.Required, Options: req.Options}
+	f, err := h.service.UpdateCustomField(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_custom_field")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toCustomFieldResponse(f))
+}
+
+// DeleteCustomFieldV2 godoc
+// @Summary Delete a custom field
+// @Description Removes the field and its values from every issue of the project.
+// @Tags v2
+// @Param id path int true "Custom field ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/custom-fields/{id} [delete]
+func (h *Handler) DeleteCustomFieldV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteCustomField(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "delete_custom_field")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toCustomFieldResponse(f logic.CustomField) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:         f.ID,
+		ProjectKey: f.ProjectKey,
+		Key:        f.Key,
+		Name:       f.Name,
+		Type:       f.Type,
+		Required:   f.Required,
+		Options:    f.Options,
+	}
+}
@@ -0,0 +1,91 @@
+package httpapi
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCustomFields_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/custom-fields", `{"key":"severity","name":"Severity","type":"select","required":true,"options":["S1","S2"]}`)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/api/v2/custom-fields/1" {
+		t.Fatalf("expected status code 201 with a location, got %d: %s", w.Code, w.Body.String())
+	}
+	performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/custom-fields", `{"key":"amount","name":"Amount","type":"number"}`)
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Refund fails"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "custom_fields.severity") {
+		t.Fatalf("expected the required field reported, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Refund fails"}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "custom_fields.severity") {
+		t.Fatalf("expected the required field reported by API v1, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Refund fails","custom_fields":{"severity":"S1","amount":12.50}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+	if !slices.Equal(issue.CustomFields["severity"], []string{"S1"}) || !slices.Equal(issue.CustomFields["amount"], []string{"12.5"}) {
+		t.Fatalf("unexpected custom fields: %+v", issue.CustomFields)
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/issues/"+strconv.Itoa(issue.ID), `{"custom_fields":{"amount":null}}`)
+
+	var updated IssueResponse
+	decodeJSON(t, w.Body, &updated)
+	if _, ok := updated.CustomFields["amount"]; ok || len(updated.CustomFields) != 1 {
+		t.Fatalf("expected amount cleared, got %+v", updated.CustomFields)
+	}
+
+	for query, want := range map[string]int{"cf.severity=S1": 1, "cf.severity=S2": 0} {
+		w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues?"+query, "")
+
+		var issues []IssueResponse
+		decodeJSON(t, w.Body, &issues)
+		if len(issues) != want {
+			t.Fatalf("%s: expected %d issues, got %+v", query, want, issues)
+		}
+	}
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues?cf.color=red", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_custom_field") {
+		t.Fatalf("expected invalid_custom_field, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodDelete, "/api/v2/custom-fields/1", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status code 204, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/issues/"+strconv.Itoa(issue.ID), "")
+
+	var stripped IssueResponse
+	decodeJSON(t, w.Body, &stripped)
+	if len(stripped.CustomFields) != 0 {
+		t.Fatalf("expected the values of the deleted field removed, got %+v", stripped.CustomFields)
+	}
+}
+
+func TestCustomFields_HTTP_LongText(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/custom-fields", `{"key":"notes","name":"Notes","type":"text"}`)
+
+	notes := strings.Repeat("a", 1000)
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Refund fails","custom_fields":{"notes":"`+notes+`"}}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", w.Code, w.Body)
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/issues/1", `{"custom_fields":{"notes":"`+strings.Repeat("a", 2<<20)+`"}}`)
+	if w.Code != http.StatusRequestEntityTooLarge || !strings.Contains(w.Body.String(), "body_too_large") {
+		t.Fatalf("expected body_too_large, got %d %s", w.Code, w.Body)
+	}
+}
@@ -1,14 +1,17 @@
 package httpapi
 
 import (
+	"MiniJira/internal/backup"
+	"MiniJira/internal/health"
 	"MiniJira/internal/httpapi/middleware"
 	"MiniJira/internal/logic"
+	"MiniJira/internal/scheduler"
 	"MiniJira/internal/usecase"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -20,20 +23,68 @@ type ProjectResponse struct {
 }
 
 type IssueResponse struct {
-	ID         int    `json:"id" example:"10"`
-	ProjectKey string `json:"project_key" example:"PAY"`
-	Title      string `json:"title" example:"Fix checkout validation"`
-	Status     string `json:"status" example:"OPEN" enums:"OPEN,IN_PROGRESS,DONE"`
+	ID         int      `json:"id" example:"10"`
+	ProjectKey string   `json:"project_key" example:"PAY"`
+	Title      string   `json:"title" example:"Fix checkout validation"`
+	Type       string   `json:"type" example:"TASK" enums:"TASK,BUG,STORY,EPIC,SUBTASK"`
+	Priority   string   `json:"priority" example:"MEDIUM" enums:"HIGHEST,HIGH,MEDIUM,LOW,LOWEST"`
+	Status     string   `json:"status" example:"OPEN"`
+	Rank       int      `json:"rank" example:"1"`
+	Assignee   string   `json:"assignee,omitempty" example:"alice"`
+	Labels     []string `json:"labels" example:"backend,checkout"`
+	SprintID   int      `json:"sprint_id,omitempty" example:"3"`
+	ParentID   int      `json:"parent_id,omitempty" example:"4"`
+	// CreatedAt is absent for issues stored before creation times were kept.
+	CreatedAt   time.Time `json:"created_at,omitzero" example:"2026-10-18T09:30:00Z"`
+	StoryPoints int       `json:"story_points,omitempty" example:"3"`
+	// The time estimates are absent for issues without one; time spent is
+	// the sum of the worklogs.
+	OriginalEstimateSeconds  int64 `json:"original_estimate_seconds,omitempty" example:"28800"`
+	RemainingEstimateSeconds int64 `json:"remaining_estimate_seconds,omitempty" example:"14400"`
+	TimeSpentSeconds         int64 `json:"time_spent_seconds,omitempty" example:"18000"`
+	FixVersionID             int   `json:"fix_version_id,omitempty" example:"2"`
+	ComponentIDs             []int `json:"component_ids,omitempty" example:"1,2"`
+	// CustomFields holds the custom field values by field key. Every value
+	// is a list; only multi_select fields have more than one entry.
+	CustomFields map[string][]string `json:"custom_fields,omitempty"`
+	// Resolution and ResolvedAt are set while the issue is in a DONE
+	// status; reopening clears them.
+	Resolution string    `json:"resolution,omitempty" example:"FIXED" enums:"FIXED,WONT_DO,DUPLICATE,CANNOT_REPRODUCE,DONE"`
+	ResolvedAt time.Time `json:"resolved_at,omitzero" example:"2026-10-18T16:00:00Z"`
+	// SLA holds the timers of the project's SLA policies; it is filled in
+	// when issues are fetched one by one or listed.
+	SLA []SLATimerResponse `json:"sla,omitempty"`
+}
+
+// SprintResponse carries the commitment once the sprint has started and
+// the completed work once it has closed.
+type SprintResponse struct {
+	ID                 int       `json:"id" example:"3"`
+	ProjectKey         string    `json:"project_key" example:"PAY"`
+	Name               string    `json:"name" example:"Sprint 12"`
+	State              string    `json:"state" example:"ACTIVE" enums:"FUTURE,ACTIVE,CLOSED"`
+	StartedAt          time.Time `json:"started_at,omitzero" example:"2026-10-06T09:00:00Z"`
+	EndsAt             time.Time `json:"ends_at,omitzero" example:"2026-10-20T09:00:00Z"`
+	ClosedAt           time.Time `json:"closed_at,omitzero" example:"2026-10-20T16:00:00Z"`
+	CommittedIssues    int       `json:"committed_issues" example:"12"`
+	CommittedPoints    int       `json:"committed_points" example:"34"`
+	CompletedIssues    int       `json:"completed_issues" example:"10"`
+	CompletedPoints    int       `json:"completed_points" example:"29"`
+	IncompleteIssueIDs []int     `json:"incomplete_issue_ids,omitempty" example:"14,15"`
 }
 
 type Handler struct {
 	service *usecase.Service
+	probe   *health.Probe
+	backups *backup.Manager
+	jobs    *scheduler.Scheduler
 	logger  *logrus.Logger
 }
 
-func NewHandler(service *usecase.Service, logger *logrus.Logger) *Handler {
+func NewHandler(service *usecase.Service, probe *health.Probe, logger *logrus.Logger) *Handler {
 	return &Handler{
 		service: service,
+		probe:   probe,
 		logger:  logger,
 	}
 }
@@ -49,6 +100,48 @@ func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
 	WriteJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
 }
 
+// Livez godoc
+// @Summary Liveness probe
+// @Description Reports that the process is running; does not check dependencies
+// @Tags system
+// @Produce json
+// @Success 200 {object} HealthResponse
+// @Router /livez [get]
+func (h *Handler) Livez(w http.ResponseWriter, r *http.Request) {
+	WriteJSON(w, http.StatusOK, HealthResponse{Status: health.StatusOK})
+}
+
+// Readyz godoc
+// @Summary Readiness probe
+// @Description Runs dependency checks; returns 503 when any check fails or the server is shutting down
+// @Tags system
+// @Produce json
+// @Success 200 {object} ReadinessResponse
+// @Failure 503 {object} ReadinessResponse
+// @Router /readyz [get]
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.probe.Ready(r.Context())
+
+	status := http.StatusOK
+	if !report.OK() {
+		status = http.StatusServiceUnavailable
+	}
+
+	WriteJSON(w, status, toReadinessResponse(report))
+}
+
+func (h *Handler) writeServiceError(w http.ResponseWriter, r *http.Request, err error, op string) {
+	p, ok := problemFor(err)
+	if !ok {
+		h.logger.WithFields(logrus.Fields{
+			"rid": middleware.GetRequestID(r),
+			"op":  op,
+		}).WithError(err).Error("operation failed")
+	}
+
+	WriteProblem(w, r, p)
+}
+
 func (h *Handler) Projects(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		h.ListProjects(w, r)
@@ -68,9 +161,10 @@ func (h *Handler) Projects(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Success 200 {array} ProjectResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /projects [get]
 func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
-	p := h.service.ListProjects()
+	p := h.service.ListProjects(r.Context())
 	WriteJSON(w, http.StatusOK, toProjectResponses(p))
 	return
 }
@@ -86,28 +180,19 @@ func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /projects [post]
 func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	var req CreateProjectRequest
 	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
 	if err != nil {
-		WriteError(w, http.StatusBadRequest, "invalid request")
+		writeMalformedBody(w, r, err)
 		return
 	}
 
-	created, err := h.service.CreateProject(req.Key, req.Name)
-	if errors.Is(err, logic.ErrInvalidProject) {
-		WriteError(w, http.StatusBadRequest, "invalid request")
-		return
-	} else if errors.Is(err, logic.ErrProjectKeyExists) {
-		WriteError(w, http.StatusConflict, "conflict")
-		return
-	} else if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"rid": middleware.GetRequestID(r),
-			"op":  "create_project",
-		}).WithError(err).Error("operation failed")
-		WriteError(w, http.StatusInternalServerError, "internal error")
+	created, err := h.service.CreateProject(r.Context(), req.Key, req.Name)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_project")
 		return
 	}
 
@@ -126,28 +211,19 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /issues [post]
 func (h *Handler) CreateIssue(w http.ResponseWriter, r *http.Request) {
 	var issue CreateIssueRequest
 	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&issue)
 	if err != nil {
-		WriteError(w, http.StatusBadRequest, "invalid request")
+		writeMalformedBody(w, r, err)
 		return
 	}
 
-	created, err := h.service.CreateIssue(issue.ProjectKey, issue.Title)
-	if errors.Is(err, logic.ErrInvalidIssue) {
-		WriteError(w, http.StatusBadRequest, "invalid request")
-		return
-	} else if errors.Is(err, logic.ErrProjectNotFound) {
-		WriteError(w, http.StatusNotFound, "not found")
-		return
-	} else if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"rid": middleware.GetRequestID(r),
-			"op":  "create_issue",
-		}).WithError(err).Error("operation failed")
-		WriteError(w, http.StatusInternalServerError, "internal error")
+	created, err := h.service.CreateIssue(r.Context(), issue.ProjectKey, issue.Title)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_issue")
 		return
 	}
 
@@ -164,11 +240,12 @@ func (h *Handler) CreateIssue(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {array} IssueResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /issues [get]
 func (h *Handler) ListIssues(w http.ResponseWriter, r *http.Request) {
-	issues, err := h.service.ListIssues(r.URL.Query().Get("project_key"))
-	if errors.Is(err, logic.ErrInvalidIssue) {
-		WriteError(w, http.StatusBadRequest, "invalid request")
+	issues, err := h.service.ListIssues(r.Context(), r.URL.Query().Get("project_key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_issues")
 		return
 	}
 	WriteJSON(w, http.StatusOK, toIssueResponses(issues))
@@ -198,28 +275,19 @@ func (h *Handler) Issues(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /issue [get]
 func (h *Handler) GetIssue(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Query().Get("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil || id < 1 {
-		WriteError(w, http.StatusBadRequest, "invalid request")
+		writeInvalidID(w, r)
 		return
 	}
 
-	issue, err := h.service.GetIssue(id)
-	if errors.Is(err, logic.ErrIssueNotFound) {
-		WriteError(w, http.StatusNotFound, "not found")
-		return
-	} else if errors.Is(err, logic.ErrInvalidID) {
-		WriteError(w, http.StatusBadRequest, "invalid request")
-		return
-	} else if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"rid": middleware.GetRequestID(r),
-			"op":  "get_issue",
-		}).WithError(err).Error("operation failed")
-		WriteError(w, http.StatusInternalServerError, "internal error")
+	issue, err := h.service.GetIssue(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_issue")
 		return
 	}
 
@@ -238,7 +306,7 @@ func (h *Handler) Issue(w http.ResponseWriter, r *http.Request) {
 
 // TransitionIssue godoc
 // @Summary Transition issue status
-// @Description Change issue status following allowed transitions
+// @Description Change issue status following allowed transitions. API v1 has no resolution, so an issue it moves into the DONE category from another is resolved as DONE, and one already there keeps its resolution; use API v2 to choose the resolution.
 // @Tags issues
 // @Accept json
 // @Produce json
@@ -248,31 +316,22 @@ func (h *Handler) Issue(w http.ResponseWriter, r *http.Request) {
 // @Failure 404 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Deprecated
 // @Router /issues/transition [post]
 func (h *Handler) TransitionIssue(w http.ResponseWriter, r *http.Request) {
 	var issue TransitionIssueRequest
 	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&issue)
 	if err != nil {
-		WriteError(w, http.StatusBadRequest, "invalid request")
+		writeMalformedBody(w, r, err)
 		return
 	}
 
-	updated, err := h.service.TransitionIssue(issue.IssueID, issue.ToStatus)
-	if errors.Is(err, logic.ErrInvalidIssue) {
-		WriteError(w, http.StatusBadRequest, "invalid request")
-		return
-	} else if errors.Is(err, logic.ErrIssueNotFound) {
-		WriteError(w, http.StatusNotFound, "not found")
-		return
-	} else if errors.Is(err, logic.ErrInvalidTransition) {
-		WriteError(w, http.StatusConflict, "conflict")
-		return
-	} else if err != nil {
-		h.logger.WithFields(logrus.Fields{
-			"rid": middleware.GetRequestID(r),
-			"op":  "transition_issue",
-		}).WithError(err).Error("operation failed")
-		WriteError(w, http.StatusInternalServerError, "internal error")
+	// v1 clients cannot send a resolution; entering the DONE category
+	// resolves the issue as DONE, as it did before resolutions existed.
+	in := logic.IssueTransition{ToStatus: issue.ToStatus, DefaultResolution: logic.ResolutionDone}
+	updated, err := h.service.TransitionIssueWith(r.Context(), issue.IssueID, in)
+	if err != nil {
+		h.writeServiceError(w, r, err, "transition_issue")
 		return
 	}
 
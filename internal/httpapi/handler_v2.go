@@ -0,0 +1,527 @@
+package httpapi
+
+import (
+	"MiniJira/internal/httpapi/middleware"
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIssueBodySize bounds issue bodies, whose custom field values may be
+// long texts; it matches the idempotency body limit.
+const maxIssueBodySize = 1 << 20
+
+type CreateIssueV2Request struct {
+	Title    string   `json:"title" example:"Fix checkout validation"`
+	Type     string   `json:"type,omitempty" example:"BUG" enums:"TASK,BUG,STORY,EPIC,SUBTASK"`
+	Priority string   `json:"priority,omitempty" example:"HIGH" enums:"HIGHEST,HIGH,MEDIUM,LOW,LOWEST"`
+	Assignee string   `json:"assignee,omitempty" example:"alice"`
+	Labels   []string `json:"labels,omitempty" example:"backend"`
+	ParentID int      `json:"parent_id,omitempty" example:"4"`
+	// StoryPoints is the estimate used by sprint reports.
+	StoryPoints int `json:"story_points,omitempty" example:"3"`
+	// The remaining estimate defaults to the original one.
+	OriginalEstimateSeconds  int64 `json:"original_estimate_seconds,omitempty" example:"28800"`
+	RemainingEstimateSeconds int64 `json:"remaining_estimate_seconds,omitempty" example:"28800"`
+	FixVersionID             int   `json:"fix_version_id,omitempty" example:"2"`
+	// Without an assignee the issue goes to the lead of the first
+	// component that has one.
+	ComponentIDs []int `json:"component_ids,omitempty" example:"1"`
+	// CustomFields must include the required fields of the project.
+	CustomFields map[string]CustomFieldValue `json:"custom_fields,omitempty" swaggertype:"object"`
+}
+
+// UpdateIssueRequest changes only the fields present. labels replaces the
+// whole set; add_labels and remove_labels adjust it.
+type UpdateIssueRequest struct {
+	Title        *string   `json:"title,omitempty" example:"Fix checkout validation"`
+	Type         *string   `json:"type,omitempty" example:"BUG"`
+	Priority     *string   `json:"priority,omitempty" example:"HIGH"`
+	Assignee     *string   `json:"assignee,omitempty" example:"alice"`
+	Labels       *[]string `json:"labels,omitempty"`
+	AddLabels    []string  `json:"add_labels,omitempty" example:"backend"`
+	RemoveLabels []string  `json:"remove_labels,omitempty" example:"triage"`
+	SprintID     *int      `json:"sprint_id,omitempty" example:"3"`
+	ParentID     *int      `json:"parent_id,omitempty" example:"4"`
+	StoryPoints  *int      `json:"story_points,omitempty" example:"5"`
+	// original_estimate_seconds alone also resets the remaining estimate
+	// while no work is logged.
+	OriginalEstimateSeconds  *int64 `json:"original_estimate_seconds,omitempty" example:"28800"`
+	RemainingEstimateSeconds *int64 `json:"remaining_estimate_seconds,omitempty" example:"14400"`
+	// FixVersionID 0 removes the fix version.
+	FixVersionID *int `json:"fix_version_id,omitempty" example:"2"`
+	// ComponentIDs replaces the whole set; an empty list removes them all.
+	ComponentIDs *[]int `json:"component_ids,omitempty"`
+	// CustomFields sets the fields present; null or an empty value clears
+	// one.
+	CustomFields map[string]CustomFieldValue `json:"custom_fields,omitempty" swaggertype:"object"`
+}
+
+type CreateSprintRequest struct {
+	Name string `json:"name" example:"Sprint 12"`
+}
+
+// TransitionRequest moves an issue. Resolution is required to enter a DONE
+// status.
+type TransitionRequest struct {
+	ToStatus   string `json:"to_status" example:"IN_PROGRESS" enums:"OPEN,IN_PROGRESS,DONE"`
+	Resolution string `json:"resolution,omitempty" example:"FIXED" enums:"FIXED,WONT_DO,DUPLICATE,CANNOT_REPRODUCE,DONE"`
+}
+
+func registerV2(mux *http.ServeMux, h *Handler) {
+	mux.HandleFunc("GET /api/v2/projects", h.ListProjectsV2)
+	mux.HandleFunc("POST /api/v2/projects", h.CreateProjectV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}", h.GetProjectV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/issues", h.ListProjectIssuesV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/issues", h.CreateProjectIssueV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/issues/csv", h.ExportIssuesCSV)
+	mux.HandleFunc("POST /api/v2/projects/{key}/issues/csv", h.ImportIssuesCSV)
+	mux.HandleFunc("GET /api/v2/projects/{key}/export", h.ExportProjectV2)
+	mux.HandleFunc("POST /api/v2/projects/import", h.ImportProjectV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/sprints", h.ListSprintsV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/sprints", h.CreateSprintV2)
+	mux.HandleFunc("POST /api/v2/sprints/{id}/start", h.StartSprintV2)
+	mux.HandleFunc("POST /api/v2/sprints/{id}/close", h.CloseSprintV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/components", h.ListComponentsV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/components", h.CreateComponentV2)
+	mux.HandleFunc("GET /api/v2/components/{id}", h.GetComponentV2)
+	mux.HandleFunc("PATCH /api/v2/components/{id}", h.UpdateComponentV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/custom-fields", h.ListCustomFieldsV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/custom-fields", h.CreateCustomFieldV2)
+	mux.HandleFunc("GET /api/v2/custom-fields/{id}", h.GetCustomFieldV2)
+	mux.HandleFunc("PATCH /api/v2/custom-fields/{id}", h.UpdateCustomFieldV2)
+	mux.HandleFunc("DELETE /api/v2/custom-fields/{id}", h.DeleteCustomFieldV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/automation/rules", h.ListRulesV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/automation/rules", h.CreateRuleV2)
+	mux.HandleFunc("GET /api/v2/automation/rules/{id}", h.GetRuleV2)
+	mux.HandleFunc("PATCH /api/v2/automation/rules/{id}", h.UpdateRuleV2)
+	mux.HandleFunc("DELETE /api/v2/automation/rules/{id}", h.DeleteRuleV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/automation/audit", h.RuleAuditV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/slas", h.ListSLAPoliciesV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/slas", h.CreateSLAPolicyV2)
+	mux.HandleFunc("GET /api/v2/slas/{id}", h.GetSLAPolicyV2)
+	mux.HandleFunc("PATCH /api/v2/slas/{id}", h.UpdateSLAPolicyV2)
+	mux.HandleFunc("DELETE /api/v2/slas/{id}", h.DeleteSLAPolicyV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/versions", h.ListVersionsV2)
+	mux.HandleFunc("POST /api/v2/projects/{key}/versions", h.CreateVersionV2)
+	mux.HandleFunc("GET /api/v2/versions/{id}", h.GetVersionV2)
+	mux.HandleFunc("PATCH /api/v2/versions/{id}", h.UpdateVersionV2)
+	mux.HandleFunc("POST /api/v2/versions/{id}/release", h.ReleaseVersionV2)
+	mux.HandleFunc("POST /api/v2/versions/{id}/archive", h.ArchiveVersionV2)
+	mux.HandleFunc("GET /api/v2/versions/{id}/release-notes", h.ReleaseNotesV2)
+	mux.HandleFunc("POST /api/v2/issues/bulk", h.BulkIssuesV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}", h.GetIssueV2)
+	mux.HandleFunc("PATCH /api/v2/issues/{id}", h.UpdateIssueV2)
+	mux.HandleFunc("POST /api/v2/issues/{id}/transitions", h.TransitionIssueV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/workflow", h.GetWorkflowV2)
+	mux.HandleFunc("PUT /api/v2/projects/{key}/workflow", h.SaveWorkflowV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}/comments", h.ListCommentsV2)
+	mux.HandleFunc("POST /api/v2/issues/{id}/comments", h.AddCommentV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}/worklogs", h.ListWorklogsV2)
+	mux.HandleFunc("POST /api/v2/issues/{id}/worklogs", h.LogWorkV2)
+	mux.HandleFunc("PATCH /api/v2/worklogs/{id}", h.UpdateWorklogV2)
+	mux.HandleFunc("DELETE /api/v2/worklogs/{id}", h.DeleteWorklogV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}/time-tracking", h.IssueTimeTrackingV2)
+	mux.HandleFunc("GET /api/v2/sprints/{id}/time-tracking", h.SprintTimeTrackingV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}/links", h.ListLinksV2)
+	mux.HandleFunc("POST /api/v2/issues/{id}/links", h.LinkIssueV2)
+	mux.HandleFunc("GET /api/v2/issues/{id}/history", h.ListHistoryV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/reports/cycle-time", h.CycleTimeReportV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/reports/cumulative-flow", h.CumulativeFlowReportV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/reports/burndown", h.BurndownReportV2)
+	mux.HandleFunc("GET /api/v2/projects/{key}/reports/velocity", h.VelocityReportV2)
+}
+
+// v1Since is when the v1 routes were superseded by /api/v2.
+var v1Since = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+func registerV1(mux *http.ServeMux, h *Handler) {
+	deprecated := func(successor func(r *http.Request) string) func(http.HandlerFunc) http.HandlerFunc {
+		return middleware.Deprecated(v1Since, successor)
+	}
+
+	mux.HandleFunc("/projects", deprecated(successorProjects)(h.Projects))
+	mux.HandleFunc("/issues", deprecated(successorIssues)(h.Issues))
+	mux.HandleFunc("/issues/transition", deprecated(successorNone)(h.IssuesTransition))
+	mux.HandleFunc("/issue", deprecated(successorIssue)(h.Issue))
+}
+
+func successorProjects(r *http.Request) string {
+	return "/api/v2/projects"
+}
+
+func successorIssues(r *http.Request) string {
+	if key := r.URL.Query().Get("project_key"); key != "" {
+		return projectPath(key) + "/issues"
+	}
+
+	return ""
+}
+
+func successorIssue(r *http.Request) string {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id < 1 {
+		return ""
+	}
+
+	return issuePath(id)
+}
+
+// successorNone is for v1 routes whose v2 URL depends on the request body.
+func successorNone(r *http.Request) string {
+	return ""
+}
+
+func projectPath(key string) string {
+	return "/api/v2/projects/" + url.PathEscape(key)
+}
+
+func issuePath(id int) string {
+	return "/api/v2/issues/" + strconv.Itoa(id)
+}
+
+// pathID parses the {id} wildcard; on failure it writes the problem itself.
+func pathID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id < 1 {
+		writeInvalidID(w, r)
+		return 0, false
+	}
+
+	return id, true
+}
+
+// ListProjectsV2 godoc
+// @Summary List projects
+// @Tags v2
+// @Produce json
+// @Success 200 {array} ProjectResponse
+// @Router /api/v2/projects [get]
+func (h *Handler) ListProjectsV2(w http.ResponseWriter, r *http.Request) {
+	h.ListProjects(w, r)
+}
+
+// CreateProjectV2 godoc
+// @Summary Create project
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param request body CreateProjectRequest true "Project payload"
+// @Success 201 {object} ProjectResponse
+// @Header 201 {string} Location "URL of the created project"
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects [post]
+func (h *Handler) CreateProjectV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateProjectRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	created, err := h.service.CreateProject(r.Context(), req.Key, req.Name)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_project")
+		return
+	}
+
+	w.Header().Set("Location", projectPath(created.Key))
+	WriteJSON(w, http.StatusCreated, toProjectResponse(created))
+}
+
+// GetProjectV2 godoc
+// @Summary Get project by key
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {object} ProjectResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key} [get]
+func (h *Handler) GetProjectV2(w http.ResponseWriter, r *http.Request) {
+	p, err := h.service.GetProject(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_project")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toProjectResponse(p))
+}
+
+// ListProjectIssuesV2 godoc
+// @Summary List issues of a project
+// @Description Issues in board order, optionally filtered. sprint=0 selects the backlog.
+// @Description Custom fields filter as cf.<key>=value, for example cf.severity=S1; a multi_select field matches issues that have the option.
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Param status query string false "Status" Enums(OPEN,IN_PROGRESS,DONE)
+// @Param assignee query string false "Assignee"
+// @Param label query string false "Label"
+// @Param sprint query int false "Sprint ID"
+// @Param fix_version query int false "Fix version ID, 0 for issues without one"
+// @Param component query int false "Component ID, 0 for issues without one"
+// @Success 200 {array} IssueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/issues [get]
+func (h *Handler) ListProjectIssuesV2(w http.ResponseWriter, r *http.Request) {
+	q, ok := issueQuery(w, r, r.PathValue("key"), r.URL.Query())
+	if !ok {
+		return
+	}
+
+	issues, err := h.service.FindIssues(r.Context(), q)
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_issues")
+		return
+	}
+
+	res := toIssueResponses(issues)
+	h.withSLAs(r, res, issues...)
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// issueQuery reads issue filters from query parameters; on failure it
+// writes the problem itself.
+func issueQuery(w http.ResponseWriter, r *http.Request, projectKey string, v url.Values) (logic.IssueQuery, bool) {
+	q := logic.IssueQuery{
+		ProjectKey: projectKey,
+		Status:     v.Get("status"),
+		Assignee:   v.Get("assignee"),
+		Label:      v.Get("label"),
+	}
+
+	if raw := v.Get("sprint"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			WriteProblem(w, r, ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_sprint",
+				Title:  "Invalid sprint",
+				Detail: "sprint must be a sprint id or 0",
+				Errors: []FieldErrorResponse{{Field: "sprint", Code: logic.FieldInvalid, Message: "must be a sprint id or 0"}},
+			})
+			return logic.IssueQuery{}, false
+		}
+		q.SprintID = &id
+	}
+	if raw := v.Get("fix_version"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			WriteProblem(w, r, ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_version",
+				Title:  "Invalid version",
+				Detail: "fix_version must be a version id or 0",
+				Errors: []FieldErrorResponse{{Field: "fix_version", Code: logic.FieldInvalid, Message: "must be a version id or 0"}},
+			})
+			return logic.IssueQuery{}, false
+		}
+		q.FixVersionID = &id
+	}
+	if raw := v.Get("component"); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil || id < 0 {
+			WriteProblem(w, r, ErrorResponse{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_component",
+				Title:  "Invalid component",
+				Detail: "component must be a component id or 0",
+				Errors: []FieldErrorResponse{{Field: "component", Code: logic.FieldInvalid, Message: "must be a component id or 0"}},
+			})
+			return logic.IssueQuery{}, false
+		}
+		q.ComponentID = &id
+	}
+	for key := range v {
+		if name, ok := strings.CutPrefix(key, "cf."); ok {
+			if q.CustomFields == nil {
+				q.CustomFields = make(map[string]string)
+			}
+			q.CustomFields[name] = v.Get(key)
+		}
+	}
+
+	return q, true
+}
+
+// ListSprintsV2 godoc
+// @Summary List sprints of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} SprintResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/sprints [get]
+func (h *Handler) ListSprintsV2(w http.ResponseWriter, r *http.Request) {
+	sprints, err := h.service.ListSprints(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_sprints")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toSprintResponses(sprints))
+}
+
+// CreateSprintV2 godoc
+// @Summary Create sprint in a project
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateSprintRequest true "Sprint payload"
+// @Success 201 {object} SprintResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/sprints [post]
+func (h *Handler) CreateSprintV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateSprintRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	created, err := h.service.CreateSprint(r.Context(), r.PathValue("key"), req.Name)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_sprint")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, toSprintResponse(created))
+}
+
+// CreateProjectIssueV2 godoc
+// @Summary Create issue in a project
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateIssueV2Request true "Issue payload"
+// @Success 201 {object} IssueResponse
+// @Header 201 {string} Location "URL of the created issue"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/issues [post]
+func (h *Handler) CreateProjectIssueV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateIssueV2Request
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIssueBodySize)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	created, err := h.service.CreateIssueFrom(r.Context(), r.PathValue("key"), toNewIssue(req))
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_issue")
+		return
+	}
+
+	w.Header().Set("Location", issuePath(created.ID))
+	WriteJSON(w, http.StatusCreated, toIssueResponse(created))
+}
+
+// GetIssueV2 godoc
+// @Summary Get issue by id
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {object} IssueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id} [get]
+func (h *Handler) GetIssueV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	issue, err := h.service.GetIssue(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_issue")
+		return
+	}
+
+	res := []IssueResponse{toIssueResponse(issue)}
+	h.withSLAs(r, res, issue)
+	WriteJSON(w, http.StatusOK, res[0])
+}
+
+// UpdateIssueV2 godoc
+// @Summary Update issue fields
+// @Description Only fields present in the body are changed. Status changes go through transitions.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Param request body UpdateIssueRequest true "Fields to change"
+// @Success 200 {object} IssueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Router /api/v2/issues/{id} [patch]
+func (h *Handler) UpdateIssueV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateIssueRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIssueBodySize)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	updated, err := h.service.UpdateIssue(r.Context(), id, toIssuePatch(req))
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_issue")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toIssueResponse(updated))
+}
+
+// TransitionIssueV2 godoc
+// @Summary Transition issue status
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Description The hooks of the workflow transition run first: a failed condition answers 403, a failed validator 400.
+// @Param request body TransitionRequest true "Target status"
+// @Success 200 {object} IssueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/transitions [post]
+func (h *Handler) TransitionIssueV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req TransitionRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	in := logic.IssueTransition{ToStatus: req.ToStatus, Resolution: req.Resolution}
+	updated, err := h.service.TransitionIssueWith(r.Context(), id, in)
+	if err != nil {
+		h.writeServiceError(w, r, err, "transition_issue")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toIssueResponse(updated))
+}
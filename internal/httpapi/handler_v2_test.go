@@ -0,0 +1,111 @@
+package httpapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestV2_ProjectIssueLifecycle(t *testing.T) {
+	handler := newTestHandler()
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects", `{"key":"PAY","name":"Payments"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v2/projects/PAY" {
+		t.Fatalf("expected project Location, got %q", loc)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Fix checkout"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v2/issues/1" {
+		t.Fatalf("expected issue Location, got %q", loc)
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/issues/1", `{"title":"Fix checkout totals"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+	if issue.Title != "Fix checkout totals" {
+		t.Fatalf("expected updated title, got %q", issue.Title)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/issues/1/transitions", `{"to_status":"IN_PROGRESS"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 || issues[0].Status != "IN_PROGRESS" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+}
+
+func TestV2_Errors(t *testing.T) {
+	handler := newTestHandler()
+
+	cases := []struct {
+		method, path, body string
+		status             int
+		code               string
+	}{
+		{http.MethodGet, "/api/v2/projects/NOPE", "", http.StatusNotFound, "project_not_found"},
+		{http.MethodGet, "/api/v2/issues/abc", "", http.StatusBadRequest, "invalid_id"},
+		{http.MethodGet, "/api/v2/issues/42", "", http.StatusNotFound, "issue_not_found"},
+		{http.MethodPatch, "/api/v2/issues/1", `{`, http.StatusBadRequest, "malformed_body"},
+	}
+
+	for _, tc := range cases {
+		w := performRequest(t, handler, tc.method, tc.path, tc.body)
+		if w.Code != tc.status {
+			t.Fatalf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.status, w.Code)
+		}
+
+		var resp ErrorResponse
+		decodeJSON(t, w.Body, &resp)
+		if resp.Code != tc.code {
+			t.Fatalf("%s %s: expected code %s, got %s", tc.method, tc.path, tc.code, resp.Code)
+		}
+	}
+}
+
+func TestV1_DeprecationHeaders(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodGet, "/issues?project_key=PAY", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	if dep := w.Header().Get("Deprecation"); !strings.HasPrefix(dep, "@") {
+		t.Fatalf("expected Deprecation header, got %q", dep)
+	}
+
+	link := w.Header().Get("Link")
+	if link != `</api/v2/projects/PAY/issues>; rel="successor-version"` {
+		t.Fatalf("unexpected Link header: %q", link)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects", "")
+	if w.Header().Get("Deprecation") != "" {
+		t.Fatal("expected no Deprecation header on v2 routes")
+	}
+}
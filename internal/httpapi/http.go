@@ -2,11 +2,16 @@ package httpapi
 
 import (
 	_ "MiniJira/docs"
+	"MiniJira/internal/backup"
+	"MiniJira/internal/health"
 	"MiniJira/internal/httpapi/middleware"
-	"MiniJira/internal/logic"
+	"MiniJira/internal/metrics"
+	"MiniJira/internal/scheduler"
+	"MiniJira/internal/tracing"
 	"MiniJira/internal/usecase"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
@@ -39,19 +44,80 @@ type HealthResponse struct {
 	Status string `json:"status" example:"ok"`
 }
 
-func NewMux(projectStore logic.ProjectStore, issueStore logic.IssueStore, piStore logic.ProjectIssueStore, logger *logrus.Logger) http.Handler {
-	service := usecase.NewService(projectStore, issueStore, piStore)
-	h := NewHandler(service, logger)
+type CheckResponse struct {
+	Name       string  `json:"name" example:"store"`
+	Status     string  `json:"status" example:"ok" enums:"ok,fail"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms" example:"0.02"`
+}
+
+type ReadinessResponse struct {
+	Status string          `json:"status" example:"ok" enums:"ok,fail"`
+	Checks []CheckResponse `json:"checks"`
+}
+
+type Deps struct {
+	Service *usecase.Service
+	Health  *health.Probe
+	Logger  *logrus.Logger
+	Tracer  *tracing.Tracer
+	Metrics *metrics.Registry
+
+	// Backups and Jobs enable the admin backup and job routes, which also
+	// need AdminToken.
+	Backups    *backup.Manager
+	Jobs       *scheduler.Scheduler
+	AdminToken string
+
+	// IdempotencyTTL is how long responses to requests with an
+	// Idempotency-Key are kept for replay; zero means 24h.
+	IdempotencyTTL time.Duration
+
+	// ReadLimit applies to GET/HEAD/OPTIONS, WriteLimit to mutating methods.
+	// The zero value means unlimited.
+	ReadLimit  middleware.RateLimit
+	WriteLimit middleware.RateLimit
+}
+
+func NewMux(deps Deps) http.Handler {
+	logger := deps.Logger
+	probe := deps.Health
+	if probe == nil {
+		probe = health.NewProbe(0)
+	}
+	h := NewHandler(deps.Service, probe, logger)
+	h.backups = deps.Backups
+	h.jobs = deps.Jobs
+	reg := deps.Metrics
+	if reg == nil {
+		reg = metrics.NewRegistry()
+	}
+	m := registerMetrics(reg, deps.Service)
+	idempotencyTTL := deps.IdempotencyTTL
+	if idempotencyTTL <= 0 {
+		idempotencyTTL = 24 * time.Hour
+	}
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/health", h.Health)
+	mux.HandleFunc("/livez", h.Livez)
+	mux.HandleFunc("/readyz", h.Readyz)
 	mux.HandleFunc("/swagger/", httpSwagger.WrapHandler)
-	mux.HandleFunc("/projects", h.Projects)
-	mux.HandleFunc("/issues", h.Issues)
-	mux.HandleFunc("/issues/transition", h.IssuesTransition)
-	mux.HandleFunc("/issue", h.Issue)
+	registerV1(mux, h)
+	registerV2(mux, h)
+	registerAdmin(mux, h, deps.AdminToken)
+	mux.HandleFunc("/ws/board", h.Board)
+	mux.Handle("/metrics", reg.Handler())
 
 	handler := http.Handler(mux)
+	handler = middleware.Metrics(m.duration, m.inFlight)(handler)
+	handler = middleware.Idempotency(middleware.NewIdempotencyStore(idempotencyTTL))(handler)
+	handler = middleware.RateLimited(
+		middleware.NewRateLimiter(deps.ReadLimit),
+		middleware.NewRateLimiter(deps.WriteLimit),
+		"/health", "/livez", "/readyz", "/metrics",
+	)(handler)
+	handler = middleware.Tracing(deps.Tracer)(handler)
 	handler = middleware.RequestID(handler)
 	handler = middleware.Logging(logger)(handler)
 	handler = middleware.Recovery(logger)(handler)
@@ -1,10 +1,19 @@
 package httpapi
 
 import (
+	"MiniJira/internal/health"
+	"MiniJira/internal/httpapi/middleware"
 	"MiniJira/internal/logic"
+	"MiniJira/internal/store/memory"
+	"MiniJira/internal/tracing"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestCreateProject_HTTP(t *testing.T) {
@@ -219,6 +228,52 @@ func TestTransitionIssue_HTTP(t *testing.T) {
 	}
 }
 
+func TestTransitionIssue_HTTP_DoneResolvesAsDone(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Fix checkout")
+
+	var updated IssueResponse
+	for _, status := range []string{logic.StatusInProgress, logic.StatusDone} {
+		body := fmt.Sprintf(`{"issue_id":%d,"to_status":%q}`, issue.ID, status)
+		w := performRequest(t, handler, http.MethodPost, "/issues/transition", body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+		}
+		decodeJSON(t, w.Body, &updated)
+	}
+
+	if updated.Status != logic.StatusDone || updated.Resolution != logic.ResolutionDone {
+		t.Fatalf("expected the issue resolved as DONE, got %q, %q", updated.Status, updated.Resolution)
+	}
+}
+
+func TestTransitionIssue_HTTP_KeepsResolutionWithinDone(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Fix checkout")
+
+	w := performRequest(t, handler, http.MethodPut, "/api/v2/projects/PAY/workflow",
+		`{"statuses":[{"name":"OPEN","category":"TODO"},{"name":"DONE","category":"DONE"},{"name":"RELEASED","category":"DONE"}]}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+	w = performRequest(t, handler, http.MethodPost, fmt.Sprintf("/api/v2/issues/%d/transitions", issue.ID), `{"to_status":"DONE","resolution":"WONT_DO"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/issues/transition", fmt.Sprintf(`{"issue_id":%d,"to_status":"RELEASED"}`, issue.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body)
+	}
+	var updated IssueResponse
+	decodeJSON(t, w.Body, &updated)
+	if updated.Status != "RELEASED" || updated.Resolution != logic.ResolutionWontDo {
+		t.Fatalf("expected the resolution kept, got %q, %q", updated.Status, updated.Resolution)
+	}
+}
+
 func TestCreateIssue_HTTP_ProjectNotFound(t *testing.T) {
 	handler := newTestHandler()
 
@@ -306,3 +361,222 @@ func TestGetIssue_HTTP_NotFound(t *testing.T) {
 		t.Fatalf("expected error %q, got %q", "not found", resp.Error)
 	}
 }
+
+func TestMetrics_HTTP(t *testing.T) {
+	handler := newTestHandler()
+
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Fix checkout"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	var created IssueResponse
+	decodeJSON(t, w.Body, &created)
+
+	body := fmt.Sprintf(`{"issue_id":%d,"to_status":"IN_PROGRESS"}`, created.ID)
+	performRequest(t, handler, http.MethodPost, "/issues/transition", body)
+
+	w = performRequest(t, handler, http.MethodGet, "/metrics", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	out := w.Body.String()
+	for _, line := range []string{
+		`minijira_http_request_duration_seconds_count{route="/projects",method="POST",status="201"} 1`,
+		`minijira_http_requests_in_flight 1`,
+		`minijira_issues_created_total{project="PAY"} 1`,
+		`minijira_issue_transitions_total{from="OPEN",to="IN_PROGRESS"} 1`,
+		`minijira_issues{project="PAY",status="IN_PROGRESS"} 1`,
+	} {
+		if !strings.Contains(out, line) {
+			t.Fatalf("expected metrics to contain %q, got:\n%s", line, out)
+		}
+	}
+}
+
+func TestTracing_HTTP(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	handler := newTestHandlerWithTracer(tracing.NewTracer(exporter))
+
+	createProject(t, handler, "PAY", "Payments")
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodPost, "/issues", strings.NewReader(`{"project_key":"PAY","title":"Fix checkout"}`))
+	req.Header.Set(tracing.HeaderTraceparent, "00-"+traceID+"-00f067aa0ba902b7-01")
+	req.Header.Set(middleware.HeaderRequestID, "rid-1")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", w.Code)
+	}
+
+	names := make(map[string]tracing.SpanData)
+	for _, span := range exporter.Spans() {
+		if span.SpanContext.TraceID.String() == traceID {
+			names[span.Name] = span
+		}
+	}
+
+	server, ok := names["HTTP POST /issues"]
+	if !ok {
+		t.Fatalf("expected server span in trace, got %v", names)
+	}
+
+	var rid string
+	for _, a := range server.Attributes {
+		if a.Key == "request.id" {
+			rid = a.Value
+		}
+	}
+	if rid != "rid-1" {
+		t.Fatalf("expected request.id rid-1, got %q", rid)
+	}
+
+	usecaseSpan, ok := names["usecase.CreateIssue"]
+	if !ok || usecaseSpan.Parent != server.SpanContext.SpanID {
+		t.Fatalf("expected usecase span under server span, got %v", names)
+	}
+
+	storeSpan, ok := names["store.CreateIssue"]
+	if !ok || storeSpan.Parent != usecaseSpan.SpanContext.SpanID {
+		t.Fatalf("expected store span under usecase span, got %v", names)
+	}
+}
+
+func TestLivez_HTTP(t *testing.T) {
+	handler := newTestHandler()
+
+	w := performRequest(t, handler, http.MethodGet, "/livez", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestReadyz_HTTP(t *testing.T) {
+	failing := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		checks   []health.Check
+		draining bool
+		status   int
+		failed   string
+	}{
+		{
+			name:   "all checks pass",
+			checks: []health.Check{health.PingCheck("store", memory.NewStore())},
+			status: http.StatusOK,
+		},
+		{
+			name: "failing check",
+			checks: []health.Check{
+				health.PingCheck("store", memory.NewStore()),
+				health.NewCheck("broken", func(ctx context.Context) error { return failing }),
+			},
+			status: http.StatusServiceUnavailable,
+			failed: "broken",
+		},
+		{
+			name:     "draining",
+			draining: true,
+			status:   http.StatusServiceUnavailable,
+			failed:   "shutdown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probe := health.NewProbe(time.Second, tt.checks...)
+			if tt.draining {
+				probe.SetDraining()
+			}
+
+			deps := newTestDeps()
+			deps.Health = probe
+			handler := NewMux(deps)
+
+			w := performRequest(t, handler, http.MethodGet, "/readyz", "")
+			if w.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, w.Code)
+			}
+
+			var resp ReadinessResponse
+			decodeJSON(t, w.Body, &resp)
+
+			if len(resp.Checks) != len(tt.checks)+1 {
+				t.Fatalf("expected %d checks, got %d", len(tt.checks)+1, len(resp.Checks))
+			}
+
+			for _, c := range resp.Checks {
+				if c.Name == tt.failed && c.Status != health.StatusFail {
+					t.Fatalf("expected check %s to fail, got %s", c.Name, c.Status)
+				}
+				if c.Name != tt.failed && c.Status != health.StatusOK {
+					t.Fatalf("expected check %s to pass, got %s (%s)", c.Name, c.Status, c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateIssue_HTTP_ProblemDetails(t *testing.T) {
+	handler := newTestHandler()
+
+	createProject(t, handler, "PAY", "Payments")
+
+	req := httptest.NewRequest(http.MethodPost, "/issues", strings.NewReader(`{"project_key":"PAY","title":"  "}`))
+	req.Header.Set(middleware.HeaderRequestID, "rid-42")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != ContentTypeProblem {
+		t.Fatalf("expected Content-Type %s, got %s", ContentTypeProblem, ct)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+
+	if resp.Code != "invalid_issue" {
+		t.Fatalf("expected code invalid_issue, got %q", resp.Code)
+	}
+
+	if resp.Status != http.StatusBadRequest {
+		t.Fatalf("expected status field 400, got %d", resp.Status)
+	}
+
+	if resp.RequestID != "rid-42" {
+		t.Fatalf("expected request id rid-42, got %q", resp.RequestID)
+	}
+
+	if resp.Instance != "/issues" {
+		t.Fatalf("expected instance /issues, got %q", resp.Instance)
+	}
+
+	if len(resp.Errors) != 1 || resp.Errors[0].Field != "title" || resp.Errors[0].Code != logic.FieldRequired {
+		t.Fatalf("expected a single required error on title, got %+v", resp.Errors)
+	}
+}
+
+func TestCreateProject_HTTP_MalformedBody(t *testing.T) {
+	handler := newTestHandler()
+
+	w := performRequest(t, handler, http.MethodPost, "/projects", `{"key":`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+
+	if resp.Code != "malformed_body" {
+		t.Fatalf("expected code malformed_body, got %q", resp.Code)
+	}
+}
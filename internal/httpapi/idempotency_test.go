@@ -0,0 +1,108 @@
+package httpapi
+
+import (
+	"MiniJira/internal/httpapi/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func performIdempotent(handler http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.HeaderIdempotencyKey, key)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	body := `{"project_key":"PAY","title":"Fix checkout"}`
+
+	first := performIdempotent(handler, "/issues", "ci-run-1", body)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", first.Code)
+	}
+
+	second := performIdempotent(handler, "/issues", "ci-run-1", body)
+	if second.Code != http.StatusCreated {
+		t.Fatalf("expected replayed status code 201, got %d", second.Code)
+	}
+	if second.Header().Get(middleware.HeaderReplayed) != "true" {
+		t.Fatal("expected Idempotent-Replayed header")
+	}
+	if second.Body.String() != first.Body.String() {
+		t.Fatalf("expected identical body, got %q and %q", first.Body.String(), second.Body.String())
+	}
+
+	w := performRequest(t, handler, http.MethodGet, "/issues?project_key=PAY", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+}
+
+func TestIdempotency_DifferentBodyConflicts(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	performIdempotent(handler, "/issues", "ci-run-1", `{"project_key":"PAY","title":"Fix checkout"}`)
+
+	w := performIdempotent(handler, "/issues", "ci-run-1", `{"project_key":"PAY","title":"Other"}`)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status code 409, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Code != "idempotency_key_reused" {
+		t.Fatalf("expected code idempotency_key_reused, got %s", resp.Code)
+	}
+}
+
+func TestIdempotency_KeysAreScopedPerCaller(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	body := `{"project_key":"PAY","title":"Fix checkout"}`
+	for _, token := range []string{"alice", "bob"} {
+		req := httptest.NewRequest(http.MethodPost, "/issues", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set(middleware.HeaderIdempotencyKey, "same-key")
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusCreated || w.Header().Get(middleware.HeaderReplayed) != "" {
+			t.Fatalf("%s: expected a fresh 201, got %d", token, w.Code)
+		}
+	}
+}
+
+func TestIdempotency_LargeBodyPassesThrough(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	var body strings.Builder
+	body.WriteString("title\n")
+	for body.Len() <= 2<<20 {
+		body.WriteString("Import the ledger of the previous quarter\n")
+	}
+
+	w := performIdempotent(handler, "/api/v2/projects/PAY/issues/csv", "import-1", body.String())
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+	var resp CSVImportResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Created == 0 || resp.Failed != 0 {
+		t.Fatalf("unexpected report: created %d, failed %d", resp.Created, resp.Failed)
+	}
+}
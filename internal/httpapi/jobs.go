@@ -0,0 +1,97 @@
+package httpapi
+
+import (
+	"MiniJira/internal/scheduler"
+	"net/http"
+	"time"
+)
+
+type JobResponse struct {
+	Name           string    `json:"name" example:"sla-check"`
+	Schedule       string    `json:"schedule" example:"@every 1m0s"`
+	Running        bool      `json:"running" example:"false"`
+	NextRunAt      time.Time `json:"next_run_at,omitzero" example:"2026-10-18T12:01:00Z"`
+	LastStartedAt  time.Time `json:"last_started_at,omitzero" example:"2026-10-18T12:00:00Z"`
+	LastFinishedAt time.Time `json:"last_finished_at,omitzero" example:"2026-10-18T12:00:01Z"`
+	LastError      string    `json:"last_error,omitempty" example:"write backup: disk full"`
+	Runs           int       `json:"runs" example:"42"`
+	Failures       int       `json:"failures" example:"1"`
+	Skipped        int       `json:"skipped" example:"0"`
+}
+
+// ListJobsV2 godoc
+// @Summary List background jobs
+// @Description Scheduled background jobs with their next run and the outcome of their latest run.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {array} JobResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /api/v2/admin/jobs [get]
+func (h *Handler) ListJobsV2(w http.ResponseWriter, r *http.Request) {
+	jobs := h.jobs.Jobs()
+	res := make([]JobResponse, len(jobs))
+	for i, j := range jobs {
+		res[i] = toJobResponse(j)
+	}
+
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// GetJobV2 godoc
+// @Summary Get background job
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Job name"
+// @Success 200 {object} JobResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/admin/jobs/{name} [get]
+func (h *Handler) GetJobV2(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Job(r.PathValue("name"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_job")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toJobResponse(job))
+}
+
+// RunJobV2 godoc
+// @Summary Run background job
+// @Description Starts a job now, outside its schedule, and returns without waiting for it. Fails if the job is already running.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Job name"
+// @Success 202 {object} JobResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /api/v2/admin/jobs/{name}/run [post]
+func (h *Handler) RunJobV2(w http.ResponseWriter, r *http.Request) {
+	job, err := h.jobs.Run(r.PathValue("name"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "run_job")
+		return
+	}
+
+	WriteJSON(w, http.StatusAccepted, toJobResponse(job))
+}
+
+func toJobResponse(s scheduler.Status) JobResponse {
+	return JobResponse{
+		Name:           s.Name,
+		Schedule:       s.Schedule,
+		Running:        s.Running,
+		NextRunAt:      s.NextRun,
+		LastStartedAt:  s.LastStarted,
+		LastFinishedAt: s.LastFinished,
+		LastError:      s.LastError,
+		Runs:           s.Runs,
+		Failures:       s.Failures,
+		Skipped:        s.Skipped,
+	}
+}
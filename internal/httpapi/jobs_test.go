@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"MiniJira/internal/scheduler"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestJobs_HTTP(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	release := make(chan struct{})
+	jobs := scheduler.New(logger)
+	err := jobs.Add("backup", scheduler.Every(time.Hour), func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	jobs.Start()
+	defer jobs.Shutdown(context.Background())
+
+	deps := newTestDeps()
+	deps.Jobs = jobs
+	deps.AdminToken = "secret"
+	handler := NewMux(deps)
+
+	w := performRequest(t, handler, http.MethodGet, "/api/v2/admin/jobs", "")
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected status code 401, got %d", w.Code)
+	}
+
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/jobs/backup/run", "")
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected status code 202, got %d", w.Code)
+	}
+	var job JobResponse
+	decodeJSON(t, w.Body, &job)
+	if job.Name != "backup" || !job.Running || job.Schedule != "@every 1h0m0s" {
+		t.Fatalf("unexpected job %+v", job)
+	}
+
+	w = adminRequest(t, handler, http.MethodPost, "/api/v2/admin/jobs/backup/run", "")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status code 409, got %d", w.Code)
+	}
+	close(release)
+
+	w = adminRequest(t, handler, http.MethodGet, "/api/v2/admin/jobs", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+	var list []JobResponse
+	decodeJSON(t, w.Body, &list)
+	if len(list) != 1 || list[0].Name != "backup" {
+		t.Fatalf("unexpected jobs %+v", list)
+	}
+
+	w = adminRequest(t, handler, http.MethodGet, "/api/v2/admin/jobs/missing", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status code 404, got %d", w.Code)
+	}
+}
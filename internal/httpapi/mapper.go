@@ -1,6 +1,12 @@
 package httpapi
 
-import "MiniJira/internal/logic"
+import (
+	"MiniJira/internal/archive"
+	"MiniJira/internal/health"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/usecase"
+	"slices"
+)
 
 func toProjectResponse(p logic.Project) ProjectResponse {
 	return ProjectResponse{
@@ -21,10 +27,27 @@ func toProjectResponses(ps []logic.Project) []ProjectResponse {
 
 func toIssueResponse(i logic.Issue) IssueResponse {
 	return IssueResponse{
-		ID:         i.ID,
-		ProjectKey: i.ProjectKey,
-		Title:      i.Title,
-		Status:     i.Status,
+		ID:                       i.ID,
+		ProjectKey:               i.ProjectKey,
+		Title:                    i.Title,
+		Type:                     i.Type,
+		Priority:                 i.Priority,
+		Status:                   i.Status,
+		Rank:                     i.Rank,
+		Assignee:                 i.Assignee,
+		Labels:                   append([]string{}, i.Labels...),
+		SprintID:                 i.SprintID,
+		ParentID:                 i.ParentID,
+		CreatedAt:                i.CreatedAt,
+		StoryPoints:              i.StoryPoints,
+		OriginalEstimateSeconds:  seconds(i.OriginalEstimate),
+		RemainingEstimateSeconds: seconds(i.RemainingEstimate),
+		TimeSpentSeconds:         seconds(i.TimeSpent),
+		FixVersionID:             i.FixVersionID,
+		ComponentIDs:             slices.Clone(i.ComponentIDs),
+		CustomFields:             i.CustomFields,
+		Resolution:               i.Resolution,
+		ResolvedAt:               i.ResolvedAt,
 	}
 }
 
@@ -36,3 +59,279 @@ func toIssueResponses(is []logic.Issue) []IssueResponse {
 
 	return res
 }
+
+func toIssuePatch(req UpdateIssueRequest) logic.IssuePatch {
+	patch := logic.IssuePatch{
+		Title:        req.Title,
+		Type:         req.Type,
+		Priority:     req.Priority,
+		Assignee:     req.Assignee,
+		Labels:       req.Labels,
+		AddLabels:    req.AddLabels,
+		RemoveLabels: req.RemoveLabels,
+		SprintID:     req.SprintID,
+		ParentID:     req.ParentID,
+		StoryPoints:  req.StoryPoints,
+		FixVersionID: req.FixVersionID,
+		ComponentIDs: req.ComponentIDs,
+		CustomFields: customFieldValues(req.CustomFields),
+	}
+	if req.OriginalEstimateSeconds != nil {
+		d := durationOf(*req.OriginalEstimateSeconds)
+		patch.OriginalEstimate = &d
+	}
+	if req.RemainingEstimateSeconds != nil {
+		d := durationOf(*req.RemainingEstimateSeconds)
+		patch.RemainingEstimate = &d
+	}
+
+	return patch
+}
+
+func toNewIssue(req CreateIssueV2Request) logic.NewIssue {
+	return logic.NewIssue{
+		Title:             req.Title,
+		Type:              req.Type,
+		Priority:          req.Priority,
+		Assignee:          req.Assignee,
+		Labels:            req.Labels,
+		ParentID:          req.ParentID,
+		StoryPoints:       req.StoryPoints,
+		OriginalEstimate:  durationOf(req.OriginalEstimateSeconds),
+		RemainingEstimate: durationOf(req.RemainingEstimateSeconds),
+		FixVersionID:      req.FixVersionID,
+		ComponentIDs:      req.ComponentIDs,
+		CustomFields:      customFieldValues(req.CustomFields),
+	}
+}
+
+func toCommentResponse(c logic.Comment) CommentResponse {
+	return CommentResponse{
+		ID:        c.ID,
+		IssueID:   c.IssueID,
+		Author:    c.Author,
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+func toCommentResponses(cs []logic.Comment) []CommentResponse {
+	res := make([]CommentResponse, len(cs))
+	for i, c := range cs {
+		res[i] = toCommentResponse(c)
+	}
+
+	return res
+}
+
+func toWorklogResponse(w logic.Worklog) WorklogResponse {
+	return WorklogResponse{
+		ID:               w.ID,
+		IssueID:          w.IssueID,
+		Author:           w.Author,
+		TimeSpentSeconds: seconds(w.Spent),
+		StartedAt:        w.StartedAt,
+		Comment:          w.Comment,
+		CreatedAt:        w.CreatedAt,
+		UpdatedAt:        w.UpdatedAt,
+	}
+}
+
+func toWorklogResponses(ws []logic.Worklog) []WorklogResponse {
+	res := make([]WorklogResponse, len(ws))
+	for i, w := range ws {
+		res[i] = toWorklogResponse(w)
+	}
+
+	return res
+}
+
+func toTimeTrackingResponse(t logic.TimeTracking) TimeTrackingResponse {
+	return TimeTrackingResponse{
+		Issues:                   t.Issues,
+		OriginalEstimateSeconds:  seconds(t.OriginalEstimate),
+		RemainingEstimateSeconds: seconds(t.RemainingEstimate),
+		TimeSpentSeconds:         seconds(t.TimeSpent),
+	}
+}
+
+func toLinkResponse(l logic.IssueLink) LinkResponse {
+	return LinkResponse{
+		ID:     l.ID,
+		Type:   l.Type,
+		FromID: l.FromID,
+		ToID:   l.ToID,
+	}
+}
+
+func toLinkResponses(ls []logic.IssueLink) []LinkResponse {
+	res := make([]LinkResponse, len(ls))
+	for i, l := range ls {
+		res[i] = toLinkResponse(l)
+	}
+
+	return res
+}
+
+func toWorkflowResponse(w logic.Workflow) WorkflowResponse {
+	res := WorkflowResponse{
+		ProjectKey:  w.ProjectKey,
+		Statuses:    make([]WorkflowStatusResponse, len(w.Statuses)),
+		Transitions: make([]WorkflowTransitionResponse, len(w.Transitions)),
+	}
+	for i, s := range w.Statuses {
+		res.Statuses[i] = WorkflowStatusResponse{Name: s.Name, Category: s.Category}
+	}
+	for i, t := range w.Transitions {
+		res.Transitions[i] = WorkflowTransitionResponse{
+			From:          t.From,
+			To:            t.To,
+			Conditions:    t.Conditions,
+			Validators:    t.Validators,
+			PostFunctions: t.PostFunctions,
+		}
+	}
+
+	return res
+}
+
+func toWorkflow(req WorkflowRequest) logic.Workflow {
+	res := logic.Workflow{
+		Statuses:    make([]logic.WorkflowStatus, len(req.Statuses)),
+		Transitions: make([]logic.WorkflowTransition, len(req.Transitions)),
+	}
+	for i, s := range req.Statuses {
+		res.Statuses[i] = logic.WorkflowStatus{Name: s.Name, Category: s.Category}
+	}
+	for i, t := range req.Transitions {
+		res.Transitions[i] = logic.WorkflowTransition{
+			From:          t.From,
+			To:            t.To,
+			Conditions:    t.Conditions,
+			Validators:    t.Validators,
+			PostFunctions: t.PostFunctions,
+		}
+	}
+
+	return res
+}
+
+func toSprintResponse(sp logic.Sprint) SprintResponse {
+	state := sp.State
+	if state == "" {
+		state = logic.SprintFuture
+	}
+
+	return SprintResponse{
+		ID:                 sp.ID,
+		ProjectKey:         sp.ProjectKey,
+		Name:               sp.Name,
+		State:              state,
+		StartedAt:          sp.StartedAt,
+		EndsAt:             sp.EndsAt,
+		ClosedAt:           sp.ClosedAt,
+		CommittedIssues:    sp.CommittedIssues,
+		CommittedPoints:    sp.CommittedPoints,
+		CompletedIssues:    sp.CompletedIssues,
+		CompletedPoints:    sp.CompletedPoints,
+		IncompleteIssueIDs: sp.Incomplete,
+	}
+}
+
+func toSprintResponses(sps []logic.Sprint) []SprintResponse {
+	res := make([]SprintResponse, len(sps))
+	for i, sp := range sps {
+		res[i] = toSprintResponse(sp)
+	}
+
+	return res
+}
+
+func customFieldValues(values map[string]CustomFieldValue) map[string][]string {
+	if values == nil {
+		return nil
+	}
+
+	res := make(map[string][]string, len(values))
+	for k, v := range values {
+		res[k] = v
+	}
+
+	return res
+}
+
+func toIssueQuery(q BulkQuery) logic.IssueQuery {
+	return logic.IssueQuery{
+		ProjectKey: q.ProjectKey,
+		Status:     q.Status,
+		Assignee:   q.Assignee,
+		Label:      q.Label,
+		SprintID:   q.SprintID,
+	}
+}
+
+func toBulkAction(req BulkRequest) logic.BulkAction {
+	return logic.BulkAction{
+		Action:       req.Action,
+		ToStatus:     req.ToStatus,
+		Resolution:   req.Resolution,
+		Assignee:     req.Assignee,
+		AddLabels:    req.AddLabels,
+		RemoveLabels: req.RemoveLabels,
+		SprintID:     req.SprintID,
+	}
+}
+
+func toBulkResponse(report usecase.BulkReport) BulkResponse {
+	resp := BulkResponse{
+		Committed: report.Committed,
+		Results:   make([]BulkItemResponse, len(report.Results)),
+	}
+
+	for i, r := range report.Results {
+		item := BulkItemResponse{IssueID: r.IssueID, OK: r.Err == nil}
+		if r.Err == nil {
+			issue := toIssueResponse(r.Issue)
+			item.Issue = &issue
+			resp.Succeeded++
+		} else {
+			p, _ := problemFor(r.Err)
+			item.Error = &BulkItemError{Code: p.Code, Detail: p.Detail}
+			resp.Failed++
+		}
+		resp.Results[i] = item
+	}
+
+	return resp
+}
+
+func toImportReportResponse(rep archive.Report, dryRun bool) ImportReportResponse {
+	return ImportReportResponse{
+		DryRun:    dryRun,
+		Project:   toProjectResponse(rep.Project),
+		Sprints:   len(rep.SprintIDs),
+		Issues:    len(rep.IssueIDs),
+		Comments:  rep.Comments,
+		Worklogs:  rep.Worklogs,
+		Links:     rep.Links,
+		SprintIDs: rep.SprintIDs,
+		IssueIDs:  rep.IssueIDs,
+	}
+}
+
+func toReadinessResponse(r health.Report) ReadinessResponse {
+	checks := make([]CheckResponse, len(r.Checks))
+	for i, c := range r.Checks {
+		checks[i] = CheckResponse{
+			Name:       c.Name,
+			Status:     c.Status,
+			Error:      c.Error,
+			DurationMS: float64(c.Duration.Microseconds()) / 1000,
+		}
+	}
+
+	return ReadinessResponse{
+		Status: r.Status,
+		Checks: checks,
+	}
+}
@@ -0,0 +1,84 @@
+package httpapi
+
+import (
+	"MiniJira/internal/events"
+	"MiniJira/internal/metrics"
+	"MiniJira/internal/usecase"
+	"context"
+)
+
+type httpMetrics struct {
+	duration *metrics.HistogramVec
+	inFlight *metrics.GaugeVec
+}
+
+func registerMetrics(reg *metrics.Registry, service *usecase.Service) httpMetrics {
+	m := httpMetrics{
+		duration: reg.NewHistogramVec(
+			"minijira_http_request_duration_seconds",
+			"HTTP request latency by route, method and status.",
+			metrics.DefaultBuckets,
+			"route", "method", "status",
+		),
+		inFlight: reg.NewGaugeVec(
+			"minijira_http_requests_in_flight",
+			"HTTP requests currently being served.",
+		),
+	}
+
+	created := reg.NewCounterVec(
+		"minijira_issues_created_total",
+		"Issues created, by project.",
+		"project",
+	)
+	transitions := reg.NewCounterVec(
+		"minijira_issue_transitions_total",
+		"Issue status transitions, by source and target status.",
+		"from", "to",
+	)
+
+	service.Listen(func(e events.Event) {
+		switch e.Type {
+		case events.IssueCreated:
+			created.Inc(e.ProjectKey)
+		case events.IssueTransitioned:
+			transitions.Inc(e.FromStatus, e.ToStatus)
+		}
+	})
+
+	reg.NewGaugeFunc(
+		"minijira_issues",
+		"Issues per project and status.",
+		[]string{"project", "status"},
+		func() []metrics.Sample {
+			return issueStatusSamples(service)
+		},
+	)
+
+	return m
+}
+
+func issueStatusSamples(service *usecase.Service) []metrics.Sample {
+	ctx := context.Background()
+
+	var samples []metrics.Sample
+	for _, p := range service.ListProjects(ctx) {
+		issues, err := service.ListIssues(ctx, p.Key)
+		if err != nil {
+			continue
+		}
+
+		counts := make(map[string]int)
+		for _, i := range issues {
+			counts[i.Status]++
+		}
+		for status, n := range counts {
+			samples = append(samples, metrics.Sample{
+				Labels: []string{p.Key, status},
+				Value:  float64(n),
+			})
+		}
+	}
+
+	return samples
+}
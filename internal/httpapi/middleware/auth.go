@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// RequireToken lets through only requests with "Authorization: Bearer
+// <token>". It guards the admin routes, which have no per-user access
+// control.
+func RequireToken(token string) func(next http.HandlerFunc) http.HandlerFunc {
+	want := []byte(token)
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(got), want) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="minijira-admin"`)
+				writeProblem(w, r, http.StatusUnauthorized, "unauthorized", "Unauthorized", "a valid admin bearer token is required")
+				return
+			}
+
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Deprecated marks responses of a superseded route with the RFC 9745
+// Deprecation header and a Link to its successor, if one is known.
+func Deprecated(since time.Time, successor func(r *http.Request) string) func(next http.HandlerFunc) http.HandlerFunc {
+	value := "@" + strconv.FormatInt(since.Unix(), 10)
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", value)
+			if link := successor(r); link != "" {
+				w.Header().Add("Link", "<"+link+`>; rel="successor-version"`)
+			}
+
+			next(w, r)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderReplayed       = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLen  = 255
+	maxIdempotentBodySize = 1 << 20
+
+	// maxIdempotencyCacheBytes bounds the stored response bodies of all
+	// callers together; the entries closest to expiry make room first.
+	maxIdempotencyCacheBytes = 64 << 20
+)
+
+type idempotentEntry struct {
+	bodyHash string
+	done     bool
+	expires  time.Time
+
+	status int
+	header http.Header
+	body   []byte
+}
+
+// IdempotencyStore keeps the first response per caller and Idempotency-Key
+// for ttl, so a retried request gets the same answer instead of running twice.
+type IdempotencyStore struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]*idempotentEntry
+	size      int
+	maxSize   int
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     ttl,
+		entries: make(map[string]*idempotentEntry),
+		maxSize: maxIdempotencyCacheBytes,
+		now:     time.Now,
+	}
+}
+
+// begin returns the stored entry for key, or reserves key for a new request
+// and returns nil.
+func (s *IdempotencyStore) begin(key, bodyHash string) *idempotentEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if e, ok := s.entries[key]; ok {
+		if now.Before(e.expires) {
+			return e
+		}
+		s.remove(key, e)
+	}
+
+	s.entries[key] = &idempotentEntry{bodyHash: bodyHash, expires: now.Add(s.ttl)}
+	return nil
+}
+
+func (s *IdempotencyStore) finish(key string, status int, header http.Header, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return
+	}
+
+	// Server errors are not stored: the retry should get a real second
+	// attempt. Neither is a body that would not fit in the cache at all.
+	if status >= http.StatusInternalServerError || len(body) > s.maxSize {
+		delete(s.entries, key)
+		return
+	}
+
+	e.done = true
+	e.status = status
+	e.header = header
+	e.body = body
+	e.expires = s.now().Add(s.ttl)
+	s.size += len(body)
+	s.shrink()
+}
+
+func (s *IdempotencyStore) abort(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+}
+
+// remove drops a stored entry; entries still running hold no body.
+func (s *IdempotencyStore) remove(key string, e *idempotentEntry) {
+	s.size -= len(e.body)
+	delete(s.entries, key)
+}
+
+func (s *IdempotencyStore) sweep(now time.Time) {
+	if now.Before(s.nextSweep) {
+		return
+	}
+
+	for k, e := range s.entries {
+		if e.done && !now.Before(e.expires) {
+			s.remove(k, e)
+		}
+	}
+	s.nextSweep = now.Add(s.ttl)
+}
+
+// shrink evicts the stored responses closest to expiry until the cache
+// fits in maxSize.
+func (s *IdempotencyStore) shrink() {
+	for s.size > s.maxSize {
+		var oldestKey string
+		var oldest *idempotentEntry
+		for k, e := range s.entries {
+			if e.done && (oldest == nil || e.expires.Before(oldest.expires)) {
+				oldestKey, oldest = k, e
+			}
+		}
+		s.remove(oldestKey, oldest)
+	}
+}
+
+// Idempotency replays the stored response of mutating requests that carry an
+// Idempotency-Key. Reusing a key with a different body, or while the first
+// request is still running, is a conflict. Bodies over 1 MiB, such as large
+// CSV imports, are passed through without it rather than held in memory.
+func Idempotency(store *IdempotencyStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(HeaderIdempotencyKey)
+			if store == nil || key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLen {
+				writeProblem(w, r, http.StatusBadRequest, "invalid_idempotency_key", "Invalid Idempotency-Key",
+					"Idempotency-Key must be at most 255 characters")
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+			if err != nil {
+				writeProblem(w, r, http.StatusBadRequest, "malformed_body", "Malformed request body", err.Error())
+				return
+			}
+			if len(body) > maxIdempotentBodySize {
+				r.Body = readCloser{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			sum := sha256.Sum256(append([]byte(r.Method+" "+r.URL.RequestURI()+"\n"), body...))
+			bodyHash := hex.EncodeToString(sum[:])
+			storeKey := ClientKey(r) + "\x00" + key
+
+			e := store.begin(storeKey, bodyHash)
+			switch {
+			case e == nil:
+			case e.bodyHash != bodyHash:
+				writeProblem(w, r, http.StatusConflict, "idempotency_key_reused", "Idempotency-Key reused",
+					"the key was already used with a different request")
+				return
+			case !e.done:
+				writeProblem(w, r, http.StatusConflict, "idempotency_in_progress", "Request in progress",
+					"a request with this key is still being processed")
+				return
+			default:
+				replay(w, e)
+				return
+			}
+
+			before := w.Header().Clone()
+			rec := &captureRecorder{ResponseWriter: w}
+
+			completed := false
+			defer func() {
+				if !completed {
+					store.abort(storeKey)
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+			completed = true
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			store.finish(storeKey, status, addedHeaders(before, w.Header()), rec.body.Bytes())
+		})
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func replay(w http.ResponseWriter, e *idempotentEntry) {
+	for k, v := range e.header {
+		w.Header()[k] = v
+	}
+	w.Header().Set(HeaderReplayed, "true")
+	w.WriteHeader(e.status)
+	_, _ = w.Write(e.body)
+}
+
+// addedHeaders returns the headers the handler set, leaving out the ones
+// outer middleware set per request, such as X-Request-Id.
+func addedHeaders(before, after http.Header) http.Header {
+	added := make(http.Header)
+	for k, v := range after {
+		if _, ok := before[k]; !ok {
+			added[k] = append([]string(nil), v...)
+		}
+	}
+
+	return added
+}
+
+// ClientKey identifies the caller that stored responses belong to: a hash
+// of the bearer token if there is one, otherwise the remote IP. The token
+// is not checked, so the key must not be used to limit anything.
+func ClientKey(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok && token != "" {
+		sum := sha256.Sum256([]byte(token))
+		return "token:" + hex.EncodeToString(sum[:8])
+	}
+
+	return "ip:" + remoteIP(r)
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+type captureRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *captureRecorder) WriteHeader(code int) {
+	if r.status == 0 {
+		r.status = code
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *captureRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	r.body.Write(b)
+
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *captureRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
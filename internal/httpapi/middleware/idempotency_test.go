@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyStore_BoundsSize(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewIdempotencyStore(time.Hour)
+	s.now = func() time.Time { return now }
+	s.maxSize = 10
+
+	for _, key := range []string{"a", "b", "c"} {
+		if e := s.begin(key, "hash"); e != nil {
+			t.Fatalf("%s: expected a new entry", key)
+		}
+		s.finish(key, http.StatusCreated, nil, []byte("12345"))
+		now = now.Add(time.Second)
+	}
+
+	if s.size != 10 || len(s.entries) != 2 {
+		t.Fatalf("expected 2 entries of 10 bytes, got %d entries of %d", len(s.entries), s.size)
+	}
+	if _, ok := s.entries["a"]; ok {
+		t.Fatal("expected the oldest entry evicted")
+	}
+
+	s.begin("big", "hash")
+	s.finish("big", http.StatusCreated, nil, []byte("12345678901"))
+	if _, ok := s.entries["big"]; ok || s.size != 10 {
+		t.Fatalf("expected a body over the bound not stored, got size %d", s.size)
+	}
+
+	now = now.Add(2 * time.Hour)
+	s.begin("b", "hash")
+	if s.size != 0 {
+		t.Fatalf("expected expired entries dropped, got size %d", s.size)
+	}
+}
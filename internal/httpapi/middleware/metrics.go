@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"MiniJira/internal/metrics"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Metrics must wrap the mux directly: the route label is read from
+// r.Pattern, which the mux sets on the request it was given.
+func Metrics(duration *metrics.HistogramVec, inFlight *metrics.GaugeVec) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			inFlight.Inc()
+			defer inFlight.Dec()
+
+			rec := &StatusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			route := r.Pattern
+			if route == "" {
+				route = "unmatched"
+			}
+
+			duration.Observe(time.Since(start).Seconds(), route, r.Method, strconv.Itoa(status))
+		})
+	}
+}
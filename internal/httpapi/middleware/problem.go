@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// writeProblem writes the problem+json body for errors raised by middleware,
+// which sits below the httpapi package and cannot use its helpers.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, title, detail string) {
+	body := map[string]any{
+		"type":       "urn:minijira:problem:" + code,
+		"title":      title,
+		"status":     status,
+		"code":       code,
+		"instance":   r.URL.Path,
+		"request_id": GetRequestID(r),
+		"error":      legacyError(status),
+	}
+	if detail != "" {
+		body["detail"] = detail
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// legacyError mirrors the short error text of the httpapi error body.
+func legacyError(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid request"
+	case http.StatusInternalServerError:
+		return "internal error"
+	}
+
+	return strings.ToLower(http.StatusText(status))
+}
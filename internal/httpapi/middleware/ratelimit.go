@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit is a token bucket: Burst requests at once, refilled at Rate
+// requests per second. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter holds one bucket per client. Buckets that have refilled
+// completely carry no state and are evicted.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     RateLimit
+	buckets   map[string]*bucket
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+
+	return &RateLimiter{
+		limit:   limit,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+type decision struct {
+	allowed    bool
+	remaining  int
+	reset      time.Duration
+	retryAfter time.Duration
+}
+
+func (l *RateLimiter) allow(key string) decision {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	burst := float64(l.limit.Burst)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*l.limit.Rate)
+	b.last = now
+
+	d := decision{allowed: b.tokens >= 1}
+	if d.allowed {
+		b.tokens--
+	} else {
+		d.retryAfter = l.refill(1 - b.tokens)
+	}
+	d.remaining = int(b.tokens)
+	d.reset = l.refill(burst - b.tokens)
+
+	return d
+}
+
+func (l *RateLimiter) refill(tokens float64) time.Duration {
+	return time.Duration(tokens / l.limit.Rate * float64(time.Second))
+}
+
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Before(l.nextSweep) {
+		return
+	}
+
+	full := l.refill(float64(l.limit.Burst))
+	for k, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, k)
+		}
+	}
+	l.nextSweep = now.Add(max(full, time.Minute))
+}
+
+// RateLimited limits requests per remote IP, using read for safe methods
+// and write for the rest. Bearer tokens are not checked here, so keying on
+// them would hand a fresh bucket to every made-up token. Paths under exempt
+// are not limited.
+func RateLimited(read, write *RateLimiter, exempt ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			limiter := read
+			if isMutating(r.Method) {
+				limiter = write
+			}
+			if limiter == nil || isExempt(r.URL.Path, exempt) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			d := limiter.allow(remoteIP(r))
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(limiter.limit.Burst))
+			h.Set("RateLimit-Remaining", strconv.Itoa(d.remaining))
+			h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.reset)))
+
+			if !d.allowed {
+				h.Set("Retry-After", strconv.Itoa(ceilSeconds(d.retryAfter)))
+				writeProblem(w, r, http.StatusTooManyRequests, "rate_limited", "Too many requests",
+					"rate limit exceeded, retry after "+h.Get("Retry-After")+"s")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isExempt(path string, exempt []string) bool {
+	for _, p := range exempt {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
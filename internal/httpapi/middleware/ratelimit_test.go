@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_RefillsAndEvicts(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(RateLimit{Rate: 1, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	for i := range 2 {
+		if d := l.allow("ip:10.0.0.1"); !d.allowed {
+			t.Fatalf("request %d: expected allowed", i)
+		}
+	}
+
+	d := l.allow("ip:10.0.0.1")
+	if d.allowed {
+		t.Fatal("expected third request to be limited")
+	}
+	if d.retryAfter != time.Second {
+		t.Fatalf("expected retry after 1s, got %v", d.retryAfter)
+	}
+
+	now = now.Add(time.Second)
+	if d := l.allow("ip:10.0.0.1"); !d.allowed {
+		t.Fatal("expected request to be allowed after refill")
+	}
+
+	now = now.Add(time.Hour)
+	l.allow("ip:10.0.0.2")
+	if _, ok := l.buckets["ip:10.0.0.1"]; ok {
+		t.Fatal("expected idle bucket to be evicted")
+	}
+}
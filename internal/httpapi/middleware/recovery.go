@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"encoding/json"
 	"net/http"
 	"runtime/debug"
 
@@ -25,6 +24,10 @@ func (r *headerRecorder) Write(b []byte) (int, error) {
 	return r.ResponseWriter.Write(b)
 }
 
+func (r *headerRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 func Recovery(logger *logrus.Logger) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -38,9 +41,7 @@ func Recovery(logger *logrus.Logger) func(next http.Handler) http.Handler {
 						"panic": panicVal,
 					}).Errorf("panic recovered\n%s", string(stack))
 					if !rec.wroteHeader {
-						rec.Header().Set("Content-Type", "application/json")
-						rec.WriteHeader(http.StatusInternalServerError)
-						_ = json.NewEncoder(rec).Encode(map[string]string{"error": "internal error"})
+						writeProblem(rec, r, http.StatusInternalServerError, "internal", "Internal error", "")
 					}
 				}
 			}()
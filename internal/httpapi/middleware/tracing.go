@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"MiniJira/internal/tracing"
+	"net/http"
+	"strconv"
+)
+
+// Tracing starts a server span per request, continuing the trace from an
+// incoming traceparent header and tagging the span with the request ID.
+// It must run inside RequestID.
+func Tracing(tracer *tracing.Tracer) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if sc, ok := tracing.ParseTraceparent(r.Header.Get(tracing.HeaderTraceparent)); ok {
+				ctx = tracing.ContextWithRemote(ctx, sc)
+			}
+
+			ctx, span := tracer.Start(ctx, "HTTP "+r.Method, tracing.KindServer,
+				tracing.Attr("http.method", r.Method),
+				tracing.Attr("http.target", r.URL.RequestURI()),
+				tracing.Attr("request.id", GetRequestID(r)),
+			)
+			defer span.End()
+
+			rec := &StatusRecorder{ResponseWriter: w}
+			r = r.WithContext(ctx)
+
+			next.ServeHTTP(rec, r)
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			if r.Pattern != "" {
+				span.SetName("HTTP " + r.Method + " " + r.Pattern)
+			}
+			span.SetAttributes(
+				tracing.Attr("http.route", r.Pattern),
+				tracing.Attr("http.status_code", strconv.Itoa(status)),
+			)
+			if status >= http.StatusInternalServerError {
+				span.SetStatus(tracing.StatusError, http.StatusText(status))
+			}
+		})
+	}
+}
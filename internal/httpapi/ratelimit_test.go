@@ -0,0 +1,81 @@
+package httpapi
+
+import (
+	"MiniJira/internal/httpapi/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestRateLimit_WriteBurstExceeded(t *testing.T) {
+	deps := newTestDeps()
+	deps.WriteLimit = middleware.RateLimit{Rate: 0.01, Burst: 2}
+	handler := NewMux(deps)
+
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"One"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Fatalf("expected RateLimit-Remaining 0, got %q", got)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/issues", `{"project_key":"PAY","title":"Two"}`)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status code 429, got %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Fatal("expected Retry-After header")
+	}
+	if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Fatalf("expected RateLimit-Limit 2, got %q", got)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Code != "rate_limited" {
+		t.Fatalf("expected code rate_limited, got %s", resp.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/issues?project_key=PAY", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected reads to stay unlimited, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_ProbesExempt(t *testing.T) {
+	deps := newTestDeps()
+	deps.ReadLimit = middleware.RateLimit{Rate: 0.01, Burst: 1}
+	handler := NewMux(deps)
+
+	for range 3 {
+		w := performRequest(t, handler, http.MethodGet, "/livez", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d", w.Code)
+		}
+	}
+}
+
+func TestRateLimit_RotatingTokensShareBucket(t *testing.T) {
+	deps := newTestDeps()
+	deps.ReadLimit = middleware.RateLimit{Rate: 0.01, Burst: 2}
+	handler := NewMux(deps)
+
+	for n := range 3 {
+		req := httptest.NewRequest(http.MethodGet, "/projects", nil)
+		req.Header.Set("Authorization", "Bearer made-up-"+strconv.Itoa(n))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		want := http.StatusOK
+		if n == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Fatalf("request %d: expected status code %d, got %d", n+1, want, w.Code)
+		}
+	}
+}
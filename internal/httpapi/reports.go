@@ -0,0 +1,410 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// defaultReportRange is the range of a report requested without from.
+const defaultReportRange = 30 * 24 * time.Hour
+
+type StatusChangeResponse struct {
+	From string    `json:"from,omitempty" example:"OPEN"`
+	To   string    `json:"to" example:"IN_PROGRESS"`
+	At   time.Time `json:"at" example:"2026-10-18T09:30:00Z"`
+}
+
+type PercentilesResponse struct {
+	Count      int   `json:"count" example:"12"`
+	P50Seconds int64 `json:"p50_seconds" example:"172800"`
+	P85Seconds int64 `json:"p85_seconds" example:"432000"`
+	P95Seconds int64 `json:"p95_seconds" example:"604800"`
+}
+
+// IssueTimesResponse gives seconds spent in each status within the range;
+// time in DONE statuses is not counted.
+type IssueTimesResponse struct {
+	IssueID          int              `json:"issue_id" example:"10"`
+	Title            string           `json:"title" example:"Fix checkout validation"`
+	Status           string           `json:"status" example:"DONE"`
+	InStatusSeconds  map[string]int64 `json:"in_status_seconds"`
+	StartedAt        time.Time        `json:"started_at,omitzero" example:"2026-10-02T09:00:00Z"`
+	DoneAt           time.Time        `json:"done_at,omitzero" example:"2026-10-04T09:00:00Z"`
+	CycleTimeSeconds int64            `json:"cycle_time_seconds,omitempty" example:"172800"`
+	LeadTimeSeconds  int64            `json:"lead_time_seconds,omitempty" example:"259200"`
+}
+
+// FlowReportResponse covers issues open or finished in [from, to).
+// Percentiles are over the issues finished in the range.
+type FlowReportResponse struct {
+	ProjectKey string               `json:"project_key" example:"PAY"`
+	From       time.Time            `json:"from" example:"2026-09-18T00:00:00Z"`
+	To         time.Time            `json:"to" example:"2026-10-18T00:00:00Z"`
+	Statuses   []string             `json:"statuses" example:"OPEN,IN_PROGRESS,DONE"`
+	CycleTime  PercentilesResponse  `json:"cycle_time"`
+	LeadTime   PercentilesResponse  `json:"lead_time"`
+	Issues     []IssueTimesResponse `json:"issues"`
+}
+
+type FlowDayResponse struct {
+	Date   string         `json:"date" example:"2026-10-07"`
+	Counts map[string]int `json:"counts"`
+}
+
+// CumulativeFlowResponse has one day per calendar day of timezone from
+// from to to inclusive, ending at the current day.
+type CumulativeFlowResponse struct {
+	ProjectKey string            `json:"project_key" example:"PAY"`
+	Timezone   string            `json:"timezone" example:"Europe/Berlin"`
+	From       string            `json:"from" example:"2026-09-19"`
+	To         string            `json:"to" example:"2026-10-18"`
+	Statuses   []string          `json:"statuses" example:"OPEN,IN_PROGRESS,DONE"`
+	Days       []FlowDayResponse `json:"days"`
+}
+
+// ListHistoryV2 godoc
+// @Summary List status changes of an issue
+// @Description Oldest first. The first change has no from and marks the creation of the issue.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {array} StatusChangeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/history [get]
+func (h *Handler) ListHistoryV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	history, err := h.service.ListHistory(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_history")
+		return
+	}
+
+	res := make([]StatusChangeResponse, len(history))
+	for i, c := range history {
+		res[i] = StatusChangeResponse{From: c.From, To: c.To, At: c.At}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CycleTimeReportV2 godoc
+// @Summary Time in status, cycle time and lead time
+// @Description Cycle time runs from the first move out of a TODO status to the last move into DONE, lead time from creation to DONE.
+// @Description from and to are RFC 3339 times or dates; a date as to includes that day. The default range is the last 30 days.
+// @Description The CSV has one row per issue and a seconds column per status. The issue list filters, such as component, narrow the issues covered.
+// @Tags v2
+// @Produce json
+// @Produce text/csv
+// @Param key path string true "Project key"
+// @Param from query string false "Start of the range" example(2026-09-01)
+// @Param to query string false "End of the range" example(2026-09-30)
+// @Param component query int false "Component ID, 0 for issues without one"
+// @Param format query string false "Response format" Enums(json,csv)
+// @Success 200 {object} FlowReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/reports/cycle-time [get]
+func (h *Handler) CycleTimeReportV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if err := checkFormat(format); err != nil {
+		h.writeServiceError(w, r, err, "cycle_time_report")
+		return
+	}
+
+	from, to, err := reportRange(q.Get("from"), q.Get("to"), time.Now().UTC())
+	if err != nil {
+		h.writeServiceError(w, r, err, "cycle_time_report")
+		return
+	}
+
+	iq, ok := issueQuery(w, r, r.PathValue("key"), q)
+	if !ok {
+		return
+	}
+
+	rep, err := h.service.FlowReport(r.Context(), iq, from, to)
+	if err != nil {
+		h.writeServiceError(w, r, err, "cycle_time_report")
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+rep.ProjectKey+`-cycle-time.csv"`)
+		w.WriteHeader(http.StatusOK)
+		if err := writeFlowCSV(w, rep); err != nil {
+			h.logger.WithError(err).Warn("cycle_time_report: write failed")
+		}
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toFlowReportResponse(rep))
+}
+
+// CumulativeFlowReportV2 godoc
+// @Summary Cumulative flow
+// @Description Issues per status at the end of each day, for spotting bottlenecks. Days are calendar days in tz; the current day counts up to now.
+// @Description from and to are dates, to included; the default range is the last 30 days. Issues created before from are counted from their history.
+// @Description The CSV has one row per day and a column per status. The issue list filters, such as component, narrow the issues counted.
+// @Tags v2
+// @Produce json
+// @Produce text/csv
+// @Param key path string true "Project key"
+// @Param from query string false "First day" example(2026-09-01)
+// @Param to query string false "Last day" example(2026-09-30)
+// @Param tz query string false "IANA time zone (default UTC)" example(Europe/Berlin)
+// @Param component query int false "Component ID, 0 for issues without one"
+// @Param format query string false "Response format" Enums(json,csv)
+// @Success 200 {object} CumulativeFlowResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/reports/cumulative-flow [get]
+func (h *Handler) CumulativeFlowReportV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	format := q.Get("format")
+	if err := checkFormat(format); err != nil {
+		h.writeServiceError(w, r, err, "cumulative_flow_report")
+		return
+	}
+
+	from, to, err := dayRange(q.Get("from"), q.Get("to"), q.Get("tz"), time.Now())
+	if err != nil {
+		h.writeServiceError(w, r, err, "cumulative_flow_report")
+		return
+	}
+
+	iq, ok := issueQuery(w, r, r.PathValue("key"), q)
+	if !ok {
+		return
+	}
+
+	rep, err := h.service.CumulativeFlow(r.Context(), iq, from, to)
+	if err != nil {
+		h.writeServiceError(w, r, err, "cumulative_flow_report")
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", ContentTypeCSV+"; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+rep.ProjectKey+`-cumulative-flow.csv"`)
+		w.WriteHeader(http.StatusOK)
+		if err := writeCumulativeFlowCSV(w, rep); err != nil {
+			h.logger.WithError(err).Warn("cumulative_flow_report: write failed")
+		}
+		return
+	}
+
+	res := CumulativeFlowResponse{
+		ProjectKey: rep.ProjectKey,
+		Timezone:   rep.From.Location().String(),
+		From:       rep.From.Format(time.DateOnly),
+		To:         rep.To.AddDate(0, 0, -1).Format(time.DateOnly),
+		Statuses:   rep.Statuses,
+		Days:       make([]FlowDayResponse, len(rep.Days)),
+	}
+	for i, d := range rep.Days {
+		res.Days[i] = FlowDayResponse{Date: d.Date.Format(time.DateOnly), Counts: d.Counts}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+func checkFormat(format string) error {
+	if format != "" && format != "json" && format != "csv" {
+		return logic.NewValidationError(logic.ErrInvalidReport, logic.FieldError{
+			Field:   "format",
+			Code:    logic.FieldInvalid,
+			Message: "must be json or csv",
+		})
+	}
+
+	return nil
+}
+
+// dayRange parses the from and to dates of a daily report in the time zone
+// tz. The returned to is the midnight after the last day.
+func dayRange(rawFrom, rawTo, tz string, now time.Time) (time.Time, time.Time, error) {
+	loc := time.UTC
+	if tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return time.Time{}, time.Time{}, logic.NewValidationError(logic.ErrInvalidReport, logic.FieldError{
+				Field:   "tz",
+				Code:    logic.FieldInvalid,
+				Message: "must be an IANA time zone such as Europe/Berlin",
+			})
+		}
+	}
+
+	var fields []logic.FieldError
+	parse := func(field, raw string) time.Time {
+		t, err := time.ParseInLocation(time.DateOnly, raw, loc)
+		if err != nil {
+			fields = append(fields, logic.FieldError{Field: field, Code: logic.FieldInvalid, Message: "must be a YYYY-MM-DD date"})
+		}
+		return t
+	}
+
+	y, m, d := now.In(loc).Date()
+	last := time.Date(y, m, d, 0, 0, 0, 0, loc)
+	if rawTo != "" {
+		last = parse("to", rawTo)
+	}
+	first := last.AddDate(0, 0, -int(defaultReportRange/(24*time.Hour))+1)
+	if rawFrom != "" {
+		first = parse("from", rawFrom)
+	}
+	if len(fields) > 0 {
+		return time.Time{}, time.Time{}, logic.NewValidationError(logic.ErrInvalidReport, fields...)
+	}
+
+	return first, last.AddDate(0, 0, 1), nil
+}
+
+// reportRange parses the from and to query values. A date as to means the
+// end of that day.
+func reportRange(rawFrom, rawTo string, now time.Time) (time.Time, time.Time, error) {
+	var fields []logic.FieldError
+	parse := func(field, raw string, endOfDay bool) time.Time {
+		if t, err := time.Parse(time.RFC3339, raw); err == nil {
+			return t.UTC()
+		}
+		if t, err := time.Parse(time.DateOnly, raw); err == nil {
+			if endOfDay {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t
+		}
+		fields = append(fields, logic.FieldError{Field: field, Code: logic.FieldInvalid, Message: "must be an RFC 3339 time or a YYYY-MM-DD date"})
+		return time.Time{}
+	}
+
+	to := now
+	if rawTo != "" {
+		to = parse("to", rawTo, true)
+	}
+	from := to.Add(-defaultReportRange)
+	if rawFrom != "" {
+		from = parse("from", rawFrom, false)
+	}
+	if len(fields) > 0 {
+		return time.Time{}, time.Time{}, logic.NewValidationError(logic.ErrInvalidReport, fields...)
+	}
+
+	return from, to, nil
+}
+
+func toFlowReportResponse(rep logic.FlowReport) FlowReportResponse {
+	res := FlowReportResponse{
+		ProjectKey: rep.ProjectKey,
+		From:       rep.From,
+		To:         rep.To,
+		Statuses:   rep.Statuses,
+		CycleTime:  toPercentilesResponse(rep.CycleTime),
+		LeadTime:   toPercentilesResponse(rep.LeadTime),
+		Issues:     make([]IssueTimesResponse, len(rep.Issues)),
+	}
+	for n, t := range rep.Issues {
+		it := IssueTimesResponse{
+			IssueID:          t.Issue.ID,
+			Title:            t.Issue.Title,
+			Status:           t.Issue.Status,
+			InStatusSeconds:  make(map[string]int64, len(t.InStatus)),
+			StartedAt:        t.StartedAt,
+			DoneAt:           t.DoneAt,
+			CycleTimeSeconds: seconds(t.CycleTime),
+			LeadTimeSeconds:  seconds(t.LeadTime),
+		}
+		for s, d := range t.InStatus {
+			it.InStatusSeconds[s] = seconds(d)
+		}
+		res.Issues[n] = it
+	}
+
+	return res
+}
+
+func toPercentilesResponse(p logic.Percentiles) PercentilesResponse {
+	return PercentilesResponse{
+		Count:      p.Count,
+		P50Seconds: seconds(p.P50),
+		P85Seconds: seconds(p.P85),
+		P95Seconds: seconds(p.P95),
+	}
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+func writeFlowCSV(w io.Writer, rep logic.FlowReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{"issue_id", "title", "status", "started_at", "done_at", "cycle_time_seconds", "lead_time_seconds"}
+	for _, s := range rep.Statuses {
+		header = append(header, strings.ToLower(s)+"_seconds")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	stamp := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+	for _, t := range rep.Issues {
+		row := []string{
+			strconv.Itoa(t.Issue.ID),
+			t.Issue.Title,
+			t.Issue.Status,
+			stamp(t.StartedAt),
+			stamp(t.DoneAt),
+			strconv.FormatInt(seconds(t.CycleTime), 10),
+			strconv.FormatInt(seconds(t.LeadTime), 10),
+		}
+		for _, s := range rep.Statuses {
+			row = append(row, strconv.FormatInt(seconds(t.InStatus[s]), 10))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+func writeCumulativeFlowCSV(w io.Writer, rep logic.CumulativeFlow) error {
+	cw := csv.NewWriter(w)
+	header := []string{"date"}
+	for _, s := range rep.Statuses {
+		header = append(header, strings.ToLower(s))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, d := range rep.Days {
+		row := []string{d.Date.Format(time.DateOnly)}
+		for _, s := range rep.Statuses {
+			row = append(row, strconv.Itoa(d.Counts[s]))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
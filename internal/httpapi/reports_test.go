@@ -0,0 +1,91 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCycleTimeReport_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Fix checkout")
+	createIssue(t, handler, "PAY", "Release 1.4")
+
+	for _, status := range []string{"IN_PROGRESS", "DONE"} {
+		w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(issue.ID)+"/transitions", `{"to_status":"`+status+`","resolution":"FIXED"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d", w.Code)
+		}
+	}
+
+	w := performRequest(t, handler, http.MethodGet, "/api/v2/issues/"+strconv.Itoa(issue.ID)+"/history", "")
+
+	var history []StatusChangeResponse
+	decodeJSON(t, w.Body, &history)
+	if len(history) != 3 || history[0].From != "" || history[2].To != "DONE" {
+		t.Fatalf("unexpected history: %+v", history)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cycle-time", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var rep FlowReportResponse
+	decodeJSON(t, w.Body, &rep)
+	if len(rep.Issues) != 2 || rep.CycleTime.Count != 1 || rep.Issues[0].DoneAt.IsZero() {
+		t.Fatalf("unexpected report: %+v", rep)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cycle-time?format=csv", "")
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if len(lines) != 3 || !strings.HasSuffix(lines[0], "open_seconds,in_progress_seconds,done_seconds") {
+		t.Fatalf("unexpected CSV: %q", w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cycle-time?from=yesterday", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_report") {
+		t.Fatalf("expected invalid_report, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCumulativeFlowReport_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	issue := createIssue(t, handler, "PAY", "Fix checkout")
+	createIssue(t, handler, "PAY", "Release 1.4")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(issue.ID)+"/transitions", `{"to_status":"IN_PROGRESS"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cumulative-flow?tz=Asia/Tokyo", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var rep CumulativeFlowResponse
+	decodeJSON(t, w.Body, &rep)
+	if rep.Timezone != "Asia/Tokyo" || len(rep.Days) != 30 {
+		t.Fatalf("unexpected report: %+v", rep)
+	}
+	if today := rep.Days[29].Counts; today["OPEN"] != 1 || today["IN_PROGRESS"] != 1 || today["DONE"] != 0 {
+		t.Fatalf("unexpected counts for today: %v", today)
+	}
+	if first := rep.Days[0].Counts; first["OPEN"] != 0 || first["IN_PROGRESS"] != 0 {
+		t.Fatalf("expected no issues before they were created, got %v", first)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cumulative-flow?format=csv&from=2026-01-01&to=2026-01-02", "")
+	if got := strings.TrimSpace(w.Body.String()); got != "date,open,in_progress,done\n2026-01-01,0,0,0\n2026-01-02,0,0,0" {
+		t.Fatalf("unexpected CSV: %q", got)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/cumulative-flow?tz=Mars/Olympus", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_report") {
+		t.Fatalf("expected invalid_report, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -1,12 +1,217 @@
 package httpapi
 
-import "net/http"
+import (
+	"MiniJira/internal/archive"
+	"MiniJira/internal/backup"
+	"MiniJira/internal/httpapi/middleware"
+	"MiniJira/internal/issuecsv"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/scheduler"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
+const ContentTypeProblem = "application/problem+json"
+
+const problemTypePrefix = "urn:minijira:problem:"
+
+type FieldErrorResponse struct {
+	Field   string `json:"field" example:"title"`
+	Code    string `json:"code" example:"required"`
+	Message string `json:"message" example:"must not be empty"`
+}
+
+// ErrorResponse is an RFC 7807 problem document. Error repeats the short
+// message earlier clients relied on.
 type ErrorResponse struct {
-	Error string `json:"error" example:"invalid request"`
+	Type      string               `json:"type" example:"urn:minijira:problem:invalid_issue"`
+	Title     string               `json:"title" example:"Invalid issue"`
+	Status    int                  `json:"status" example:"400"`
+	Detail    string               `json:"detail,omitempty" example:"invalid issue: title: must not be empty"`
+	Instance  string               `json:"instance,omitempty" example:"/issues"`
+	Code      string               `json:"code" example:"invalid_issue"`
+	RequestID string               `json:"request_id,omitempty" example:"4f1c2b7d9e0a3c5b6d7e8f90"`
+	Errors    []FieldErrorResponse `json:"errors,omitempty"`
+	Error     string               `json:"error" example:"invalid request"`
+}
+
+type problemKind struct {
+	err    error
+	status int
+	code   string
+	title  string
+}
+
+// problemKinds maps domain errors to stable, machine-readable codes.
+// Codes are part of the API contract: add new ones, never rename.
+var problemKinds = []problemKind{
+	{logic.ErrInvalidProject, http.StatusBadRequest, "invalid_project", "Invalid project"},
+	{logic.ErrProjectKeyExists, http.StatusConflict, "project_key_exists", "Project key already exists"},
+	{logic.ErrInvalidIssue, http.StatusBadRequest, "invalid_issue", "Invalid issue"},
+	{logic.ErrProjectNotFound, http.StatusNotFound, "project_not_found", "Project not found"},
+	{logic.ErrInvalidTransition, http.StatusConflict, "invalid_transition", "Transition not allowed"},
+	{logic.ErrTransitionDenied, http.StatusForbidden, "transition_denied", "Transition denied"},
+	{logic.ErrIssueNotFound, http.StatusNotFound, "issue_not_found", "Issue not found"},
+	{logic.ErrInvalidID, http.StatusBadRequest, "invalid_id", "Invalid id"},
+	{logic.ErrInvalidRank, http.StatusBadRequest, "invalid_rank", "Invalid rank"},
+	{logic.ErrInvalidSprint, http.StatusBadRequest, "invalid_sprint", "Invalid sprint"},
+	{logic.ErrSprintNotFound, http.StatusNotFound, "sprint_not_found", "Sprint not found"},
+	{logic.ErrInvalidSprintState, http.StatusConflict, "invalid_sprint_state", "Sprint state does not allow this"},
+	{logic.ErrNoActiveSprint, http.StatusNotFound, "no_active_sprint", "No active sprint"},
+	{logic.ErrInvalidComponent, http.StatusBadRequest, "invalid_component", "Invalid component"},
+	{logic.ErrComponentNotFound, http.StatusNotFound, "component_not_found", "Component not found"},
+	{logic.ErrComponentExists, http.StatusConflict, "component_exists", "Component already exists"},
+	{logic.ErrInvalidCustomField, http.StatusBadRequest, "invalid_custom_field", "Invalid custom field"},
+	{logic.ErrCustomFieldNotFound, http.StatusNotFound, "custom_field_not_found", "Custom field not found"},
+	{logic.ErrCustomFieldExists, http.StatusConflict, "custom_field_exists", "Custom field already exists"},
+	{logic.ErrInvalidRule, http.StatusBadRequest, "invalid_rule", "Invalid automation rule"},
+	{logic.ErrRuleNotFound, http.StatusNotFound, "rule_not_found", "Automation rule not found"},
+	{logic.ErrInvalidSLA, http.StatusBadRequest, "invalid_sla", "Invalid SLA policy"},
+	{logic.ErrSLANotFound, http.StatusNotFound, "sla_not_found", "SLA policy not found"},
+	{logic.ErrInvalidVersion, http.StatusBadRequest, "invalid_version", "Invalid version"},
+	{logic.ErrVersionNotFound, http.StatusNotFound, "version_not_found", "Version not found"},
+	{logic.ErrVersionExists, http.StatusConflict, "version_exists", "Version already exists"},
+	{logic.ErrInvalidVersionState, http.StatusConflict, "invalid_version_state", "Version state does not allow this"},
+	{logic.ErrUnfinishedIssues, http.StatusConflict, "unfinished_issues", "Version has unfinished issues"},
+	{logic.ErrInvalidBulk, http.StatusBadRequest, "invalid_bulk", "Invalid bulk operation"},
+	{logic.ErrRolledBack, http.StatusConflict, "rolled_back", "Rolled back"},
+	{logic.ErrInvalidWorkflow, http.StatusBadRequest, "invalid_workflow", "Invalid workflow"},
+	{logic.ErrInvalidComment, http.StatusBadRequest, "invalid_comment", "Invalid comment"},
+	{logic.ErrInvalidLink, http.StatusBadRequest, "invalid_link", "Invalid link"},
+	{logic.ErrInvalidWorklog, http.StatusBadRequest, "invalid_worklog", "Invalid worklog"},
+	{logic.ErrWorklogNotFound, http.StatusNotFound, "worklog_not_found", "Worklog not found"},
+	{logic.ErrInvalidReport, http.StatusBadRequest, "invalid_report", "Invalid report parameters"},
+	{archive.ErrInvalidArchive, http.StatusBadRequest, "invalid_archive", "Invalid archive"},
+	{issuecsv.ErrInvalidCSV, http.StatusBadRequest, "invalid_csv", "Invalid CSV"},
+	{backup.ErrInvalidBackup, http.StatusBadRequest, "invalid_backup", "Invalid backup"},
+	{backup.ErrChecksumMismatch, http.StatusBadRequest, "checksum_mismatch", "Backup checksum mismatch"},
+	{backup.ErrBackupNotFound, http.StatusNotFound, "backup_not_found", "Backup not found"},
+	{scheduler.ErrJobNotFound, http.StatusNotFound, "job_not_found", "Job not found"},
+	{scheduler.ErrJobRunning, http.StatusConflict, "job_running", "Job already running"},
+	{scheduler.ErrStopped, http.StatusServiceUnavailable, "scheduler_stopped", "Scheduler stopped"},
+}
+
+func lookupProblem(err error) (problemKind, bool) {
+	for _, k := range problemKinds {
+		if errors.Is(err, k.err) {
+			return k, true
+		}
+	}
+
+	return problemKind{}, false
+}
+
+// legacyMessage is the short text of the pre-problem+json error body.
+func legacyMessage(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid request"
+	case http.StatusNotFound:
+		return "not found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusInternalServerError:
+		return "internal error"
+	}
+
+	return strings.ToLower(http.StatusText(status))
+}
+
+func statusCode(status int) string {
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
+
+func WriteProblem(w http.ResponseWriter, r *http.Request, p ErrorResponse) {
+	if p.Code == "" {
+		p.Code = statusCode(p.Status)
+	}
+	if p.Type == "" {
+		p.Type = problemTypePrefix + p.Code
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Error == "" {
+		p.Error = legacyMessage(p.Status)
+	}
+	if r != nil && p.Instance == "" {
+		p.Instance = r.URL.Path
+	}
+	if p.RequestID == "" {
+		p.RequestID = w.Header().Get(middleware.HeaderRequestID)
+	}
+
+	w.Header().Set("Content-Type", ContentTypeProblem)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
 }
 
 func WriteError(w http.ResponseWriter, status int, msg string) {
-	WriteJSON(w, status, ErrorResponse{Error: msg})
+	WriteProblem(w, nil, ErrorResponse{Status: status, Detail: msg, Error: msg})
 	return
 }
+
+// writeMalformedBody answers a body that failed to decode, or 413 for one
+// cut off by http.MaxBytesReader.
+func writeMalformedBody(w http.ResponseWriter, r *http.Request, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		WriteProblem(w, r, ErrorResponse{
+			Status: http.StatusRequestEntityTooLarge,
+			Code:   "body_too_large",
+			Title:  "Request body too large",
+			Detail: fmt.Sprintf("the body must be at most %d bytes", tooLarge.Limit),
+		})
+		return
+	}
+
+	WriteProblem(w, r, ErrorResponse{
+		Status: http.StatusBadRequest,
+		Code:   "malformed_body",
+		Title:  "Malformed request body",
+		Detail: err.Error(),
+	})
+}
+
+func writeInvalidID(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, ErrorResponse{
+		Status: http.StatusBadRequest,
+		Code:   "invalid_id",
+		Title:  "Invalid id",
+		Detail: "id must be a positive integer",
+		Errors: []FieldErrorResponse{{Field: "id", Code: logic.FieldInvalid, Message: "must be a positive integer"}},
+	})
+}
+
+// problemFor converts a service error into a problem document; ok is false
+// for errors that are not part of the domain contract.
+func problemFor(err error) (ErrorResponse, bool) {
+	kind, ok := lookupProblem(err)
+	if !ok {
+		return ErrorResponse{
+			Status: http.StatusInternalServerError,
+			Code:   "internal",
+			Title:  "Internal error",
+		}, false
+	}
+
+	p := ErrorResponse{
+		Status: kind.status,
+		Code:   kind.code,
+		Title:  kind.title,
+		Detail: err.Error(),
+	}
+
+	var verr *logic.ValidationError
+	if errors.As(err, &verr) {
+		p.Errors = make([]FieldErrorResponse, len(verr.Fields))
+		for i, f := range verr.Fields {
+			p.Errors[i] = FieldErrorResponse{Field: f.Field, Code: f.Code, Message: f.Message}
+		}
+	}
+
+	return p, true
+}
@@ -0,0 +1,307 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultAuditLimit = 100
+
+type RuleCondition struct {
+	Field string `json:"field" example:"priority"`
+	Value string `json:"value" example:"HIGHEST"`
+}
+
+type RuleAction struct {
+	Type       string `json:"type" example:"add_label"`
+	Value      string `json:"value,omitempty" example:"urgent"`
+	Resolution string `json:"resolution,omitempty" example:"FIXED" enums:"FIXED,WONT_DO,DUPLICATE,CANNOT_REPRODUCE,DONE"`
+}
+
+type RuleResponse struct {
+	ID         int             `json:"id" example:"1"`
+	ProjectKey string          `json:"project_key" example:"PAY"`
+	Name       string          `json:"name" example:"Escalate blocker bugs"`
+	Enabled    bool            `json:"enabled" example:"true"`
+	Trigger    string          `json:"trigger" example:"issue.created"`
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+// CreateRuleRequest describes a rule: when the trigger fires and every
+// condition holds, the actions run in order. Rules start enabled unless
+// enabled is false.
+type CreateRuleRequest struct {
+	Name       string          `json:"name" example:"Escalate blocker bugs"`
+	Enabled    *bool           `json:"enabled,omitempty" example:"true"`
+	Trigger    string          `json:"trigger" example:"issue.created"`
+	Conditions []RuleCondition `json:"conditions,omitempty"`
+	Actions    []RuleAction    `json:"actions"`
+}
+
+// UpdateRuleRequest changes only the fields present; conditions and
+// actions are replaced as a whole.
+type UpdateRuleRequest struct {
+	Name       *string          `json:"name,omitempty" example:"Escalate blocker bugs"`
+	Enabled    *bool            `json:"enabled,omitempty" example:"false"`
+	Trigger    *string          `json:"trigger,omitempty" example:"issue.created"`
+	Conditions *[]RuleCondition `json:"conditions,omitempty"`
+	Actions    *[]RuleAction    `json:"actions,omitempty"`
+}
+
+type RuleExecutionResponse struct {
+	ID       int    `json:"id" example:"7"`
+	RuleID   int    `json:"rule_id" example:"1"`
+	RuleName string `json:"rule_name" example:"Escalate blocker bugs"`
+	IssueID  int    `json:"issue_id" example:"42"`
+	Trigger  string `json:"trigger" example:"issue.created"`
+	// Chain lists the rules that led to the execution, this one last.
+	Chain   []int     `json:"chain" example:"1"`
+	Status  string    `json:"status" example:"SUCCEEDED"`
+	Actions int       `json:"actions" example:"2"`
+	Error   string    `json:"error,omitempty"`
+	At      time.Time `json:"at" example:"2024-05-01T12:00:00Z"`
+}
+
+// ListRulesV2 godoc
+// @Summary List automation rules of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} RuleResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/automation/rules [get]
+func (h *Handler) ListRulesV2(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.service.ListRules(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_rules")
+		return
+	}
+
+	res := make([]RuleResponse, len(rules))
+	for i, rule := range rules {
+		res[i] = toRuleResponse(rule)
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CreateRuleV2 godoc
+// @Summary Create an automation rule in a project
+// @Description Triggers: issue.created, issue.updated, issue.transitioned, subtasks.done (the subject is the parent once all its subtasks are done).
+// @Description Conditions: type, priority, status, assignee, label, from_status, to_status.
+// @Description Actions: assign, add_label, remove_label, set_priority, transition, comment.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateRuleRequest true "Rule"
+// @Success 201 {object} RuleResponse
+// @Header 201 {string} Location "URL of the created rule"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/automation/rules [post]
+func (h *Handler) CreateRuleV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateRuleRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	rule := logic.Rule{
+		Name:       req.Name,
+		Enabled:    req.Enabled == nil || *req.Enabled,
+		Trigger:    req.Trigger,
+		Conditions: toRuleConditions(req.Conditions),
+		Actions:    toRuleActions(req.Actions),
+	}
+	created, err := h.service.CreateRule(r.Context(), r.PathValue("key"), rule)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_rule")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/automation/rules/"+strconv.Itoa(created.ID))
+	WriteJSON(w, http.StatusCreated, toRuleResponse(created))
+}
+
+// GetRuleV2 godoc
+// @Summary Get an automation rule
+// @Tags v2
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Success 200 {object} RuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/automation/rules/{id} [get]
+func (h *Handler) GetRuleV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.GetRule(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_rule")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRuleResponse(rule))
+}
+
+// UpdateRuleV2 godoc
+// @Summary Update an automation rule
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Rule ID"
+// @Param request body UpdateRuleRequest true "Fields to change"
+// @Success 200 {object} RuleResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/automation/rules/{id} [patch]
+func (h *Handler) UpdateRuleV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateRuleRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.RulePatch{Name: req.Name, Enabled: req.Enabled, Trigger: req.Trigger}
+	if req.Conditions != nil {
+		conditions := toRuleConditions(*req.Conditions)
+		patch.Conditions = &conditions
+	}
+	if req.Actions != nil {
+		actions := toRuleActions(*req.Actions)
+		patch.Actions = &actions
+	}
+	rule, err := h.service.UpdateRule(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_rule")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toRuleResponse(rule))
+}
+
+// DeleteRuleV2 godoc
+// @Summary Delete an automation rule
+// @Description The audit log keeps the executions of the rule.
+// @Tags v2
+// @Param id path int true "Rule ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/automation/rules/{id} [delete]
+func (h *Handler) DeleteRuleV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "delete_rule")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RuleAuditV2 godoc
+// @Summary Automation audit log of a project
+// @Description Rule executions, newest first. SKIPPED entries are rules stopped by loop protection: a rule runs at most once per chain, and a chain holds at most 5 rules.
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Param limit query int false "Maximum number of entries (default 100, 0 for all kept)"
+// @Success 200 {array} RuleExecutionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/automation/audit [get]
+func (h *Handler) RuleAuditV2(w http.ResponseWriter, r *http.Request) {
+	limit := defaultAuditLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil {
+			h.writeServiceError(w, r, logic.NewValidationError(logic.ErrInvalidRule, logic.FieldError{
+				Field:   "limit",
+				Code:    logic.FieldInvalid,
+				Message: "must be an integer",
+			}), "rule_audit")
+			return
+		}
+	}
+
+	log, err := h.service.ListRuleExecutions(r.Context(), r.PathValue("key"), limit)
+	if err != nil {
+		h.writeServiceError(w, r, err, "rule_audit")
+		return
+	}
+
+	res := make([]RuleExecutionResponse, len(log))
+	for i, x := range log {
+		res[i] = RuleExecutionResponse{
+			ID:       x.ID,
+			RuleID:   x.RuleID,
+			RuleName: x.RuleName,
+			IssueID:  x.IssueID,
+			Trigger:  x.Trigger,
+			Chain:    x.Chain,
+			Status:   x.Status,
+			Actions:  x.Actions,
+			Error:    x.Error,
+			At:       x.At,
+		}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+func toRuleResponse(rule logic.Rule) RuleResponse {
+	res := RuleResponse{
+		ID:         rule.ID,
+		ProjectKey: rule.ProjectKey,
+		Name:       rule.Name,
+		Enabled:    rule.Enabled,
+		Trigger:    rule.Trigger,
+		Conditions: make([]RuleCondition, len(rule.Conditions)),
+		Actions:    make([]RuleAction, len(rule.Actions)),
+	}
+	for i, c := range rule.Conditions {
+		res.Conditions[i] = RuleCondition{Field: c.Field, Value: c.Value}
+	}
+	for i, a := range rule.Actions {
+		res.Actions[i] = RuleAction{Type: a.Type, Value: a.Value, Resolution: a.Resolution}
+	}
+
+	return res
+}
+
+func toRuleConditions(conditions []RuleCondition) []logic.RuleCondition {
+	res := make([]logic.RuleCondition, len(conditions))
+	for i, c := range conditions {
+		res[i] = logic.RuleCondition{Field: c.Field, Value: c.Value}
+	}
+
+	return res
+}
+
+func toRuleActions(actions []RuleAction) []logic.RuleAction {
+	res := make([]logic.RuleAction, len(actions))
+	for i, a := range actions {
+		res[i] = logic.RuleAction{Type: a.Type, Value: a.Value, Resolution: a.Resolution}
+	}
+
+	return res
+}
@@ -0,0 +1,67 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRules_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	body := `{"name":"Escalate","trigger":"issue.created",
+		"conditions":[{"field":"type","value":"BUG"},{"field":"priority","value":"HIGHEST"}],
+		"actions":[{"type":"assign","value":"oncall"},{"type":"add_label","value":"urgent"}]}`
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/automation/rules", body)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/api/v2/automation/rules/1" {
+		t.Fatalf("expected status code 201 with a location, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var rule RuleResponse
+	decodeJSON(t, w.Body, &rule)
+	if !rule.Enabled || len(rule.Conditions) != 2 || len(rule.Actions) != 2 {
+		t.Fatalf("unexpected rule: %+v", rule)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/automation/rules", `{"name":"Bad","trigger":"issue.created","actions":[{"type":"delete"}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/automation/rules/1", `{"enabled":false,"conditions":[]}`)
+
+	var updated RuleResponse
+	decodeJSON(t, w.Body, &updated)
+	if updated.Enabled || len(updated.Conditions) != 0 || updated.Name != "Escalate" {
+		t.Fatalf("unexpected rule: %+v", updated)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/automation/rules", "")
+
+	var rules []RuleResponse
+	decodeJSON(t, w.Body, &rules)
+	if len(rules) != 1 {
+		t.Fatalf("expected one rule, got %+v", rules)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/automation/audit", "")
+
+	var log []RuleExecutionResponse
+	decodeJSON(t, w.Body, &log)
+	if w.Code != http.StatusOK || len(log) != 0 {
+		t.Fatalf("expected an empty audit log, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/automation/audit?limit=-1", "")
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodDelete, "/api/v2/automation/rules/1", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status code 204, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/automation/rules/1", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status code 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
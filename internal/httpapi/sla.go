@@ -0,0 +1,348 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var weekdays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// CalendarBody holds business hours. Without workdays every day counts;
+// without opens and closes the whole day counts; the time zone defaults
+// to UTC.
+type CalendarBody struct {
+	TimeZone string   `json:"time_zone,omitempty" example:"Europe/Berlin"`
+	Workdays []string `json:"workdays,omitempty" example:"MON,TUE,WED,THU,FRI" enums:"MON,TUE,WED,THU,FRI,SAT,SUN"`
+	Opens    string   `json:"opens,omitempty" example:"09:00"`
+	Closes   string   `json:"closes,omitempty" example:"17:00"`
+	Holidays []string `json:"holidays,omitempty" example:"2026-12-25"`
+}
+
+type SLAPolicyResponse struct {
+	ID            int          `json:"id" example:"1"`
+	ProjectKey    string       `json:"project_key" example:"SUP"`
+	Name          string       `json:"name" example:"First response"`
+	TargetSeconds int64        `json:"target_seconds" example:"14400"`
+	StartStatuses []string     `json:"start_statuses" example:"OPEN"`
+	PauseStatuses []string     `json:"pause_statuses"`
+	StopStatuses  []string     `json:"stop_statuses" example:"IN_PROGRESS"`
+	Calendar      CalendarBody `json:"calendar"`
+}
+
+// CreateSLAPolicyRequest describes an SLA: the timer starts when an issue
+// enters a start status, stands still in pause statuses and stops for good
+// in a stop status. target_seconds counts business time only.
+type CreateSLAPolicyRequest struct {
+	Name          string        `json:"name" example:"First response"`
+	TargetSeconds int64         `json:"target_seconds" example:"14400"`
+	StartStatuses []string      `json:"start_statuses" example:"OPEN"`
+	PauseStatuses []string      `json:"pause_statuses,omitempty"`
+	StopStatuses  []string      `json:"stop_statuses" example:"IN_PROGRESS"`
+	Calendar      *CalendarBody `json:"calendar,omitempty"`
+}
+
+// UpdateSLAPolicyRequest changes only the fields present; status lists and
+// the calendar are replaced as a whole.
+type UpdateSLAPolicyRequest struct {
+	Name          *string       `json:"name,omitempty" example:"First response"`
+	TargetSeconds *int64        `json:"target_seconds,omitempty" example:"14400"`
+	StartStatuses *[]string     `json:"start_statuses,omitempty"`
+	PauseStatuses *[]string     `json:"pause_statuses,omitempty"`
+	StopStatuses  *[]string     `json:"stop_statuses,omitempty"`
+	Calendar      *CalendarBody `json:"calendar,omitempty"`
+}
+
+// SLATimerResponse is where an issue stands against an SLA policy.
+// remaining_seconds is negative once the target is breached.
+type SLATimerResponse struct {
+	PolicyID         int       `json:"policy_id" example:"1"`
+	Name             string    `json:"name" example:"First response"`
+	State            string    `json:"state" example:"RUNNING" enums:"NOT_STARTED,RUNNING,PAUSED,STOPPED"`
+	TargetSeconds    int64     `json:"target_seconds" example:"14400"`
+	ElapsedSeconds   int64     `json:"elapsed_seconds" example:"3600"`
+	RemainingSeconds int64     `json:"remaining_seconds" example:"10800"`
+	Breached         bool      `json:"breached" example:"false"`
+	StartedAt        time.Time `json:"started_at,omitzero" example:"2026-10-19T09:00:00Z"`
+	StoppedAt        time.Time `json:"stopped_at,omitzero"`
+	DueAt            time.Time `json:"due_at,omitzero" example:"2026-10-19T13:00:00Z"`
+}
+
+// ListSLAPoliciesV2 godoc
+// @Summary List SLA policies of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} SLAPolicyResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/slas [get]
+func (h *Handler) ListSLAPoliciesV2(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.service.ListSLAPolicies(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_slas")
+		return
+	}
+
+	res := make([]SLAPolicyResponse, len(policies))
+	for i, p := range policies {
+		res[i] = toSLAPolicyResponse(p)
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CreateSLAPolicyV2 godoc
+// @Summary Create an SLA policy in a project
+// @Description Statuses must belong to the project's workflow, and a stop status may not also start or pause the timer. Timers show up in the sla field of issues; a background check publishes an sla.breached event once per issue and policy.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateSLAPolicyRequest true "SLA policy"
+// @Success 201 {object} SLAPolicyResponse
+// @Header 201 {string} Location "URL of the created policy"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/slas [post]
+func (h *Handler) CreateSLAPolicyV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateSLAPolicyRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	policy := logic.SLAPolicy{
+		Name:          req.Name,
+		Target:        time.Duration(req.TargetSeconds) * time.Second,
+		StartStatuses: req.StartStatuses,
+		PauseStatuses: req.PauseStatuses,
+		StopStatuses:  req.StopStatuses,
+	}
+	if req.Calendar != nil {
+		if policy.Calendar, err = toCalendar(*req.Calendar); err != nil {
+			h.writeServiceError(w, r, err, "create_sla")
+			return
+		}
+	}
+
+	created, err := h.service.CreateSLAPolicy(r.Context(), r.PathValue("key"), policy)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_sla")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/slas/"+strconv.Itoa(created.ID))
+	WriteJSON(w, http.StatusCreated, toSLAPolicyResponse(created))
+}
+
+// GetSLAPolicyV2 godoc
+// @Summary Get an SLA policy
+// @Tags v2
+// @Produce json
+// @Param id path int true "SLA policy ID"
+// @Success 200 {object} SLAPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/slas/{id} [get]
+func (h *Handler) GetSLAPolicyV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := h.service.GetSLAPolicy(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_sla")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toSLAPolicyResponse(p))
+}
+
+// UpdateSLAPolicyV2 godoc
+// @Summary Update an SLA policy
+// @Description Timers are recomputed from the status history of issues, so a change applies to past time too.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "SLA policy ID"
+// @Param request body UpdateSLAPolicyRequest true "Fields to change"
+// @Success 200 {object} SLAPolicyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/slas/{id} [patch]
+func (h *Handler) UpdateSLAPolicyV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateSLAPolicyRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.SLAPatch{
+		Name:          req.Name,
+		StartStatuses: req.StartStatuses,
+		PauseStatuses: req.PauseStatuses,
+		StopStatuses:  req.StopStatuses,
+	}
+	if req.TargetSeconds != nil {
+		target := time.Duration(*req.TargetSeconds) * time.Second
+		patch.Target = &target
+	}
+	if req.Calendar != nil {
+		c, err := toCalendar(*req.Calendar)
+		if err != nil {
+			h.writeServiceError(w, r, err, "update_sla")
+			return
+		}
+		patch.Calendar = &c
+	}
+
+	updated, err := h.service.UpdateSLAPolicy(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_sla")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toSLAPolicyResponse(updated))
+}
+
+// DeleteSLAPolicyV2 godoc
+// @Summary Delete an SLA policy
+// @Tags v2
+// @Param id path int true "SLA policy ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/slas/{id} [delete]
+func (h *Handler) DeleteSLAPolicyV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteSLAPolicy(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "delete_sla")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// withSLAs adds the SLA timers of the issues to their responses.
+func (h *Handler) withSLAs(r *http.Request, res []IssueResponse, issues ...logic.Issue) {
+	timers := h.service.SLATimers(r.Context(), issues...)
+	for i := range res {
+		for _, t := range timers[res[i].ID] {
+			res[i].SLA = append(res[i].SLA, toSLATimerResponse(t))
+		}
+	}
+}
+
+func toSLAPolicyResponse(p logic.SLAPolicy) SLAPolicyResponse {
+	c := CalendarBody{
+		TimeZone: p.Calendar.TimeZone,
+		Holidays: make([]string, len(p.Calendar.Holidays)),
+	}
+	for _, d := range p.Calendar.Workdays {
+		c.Workdays = append(c.Workdays, weekdays[d])
+	}
+	if p.Calendar.Opens != 0 || p.Calendar.Closes != 0 {
+		c.Opens, c.Closes = formatClock(p.Calendar.Opens), formatClock(p.Calendar.Closes)
+	}
+	for i, d := range p.Calendar.Holidays {
+		c.Holidays[i] = d.Format(time.DateOnly)
+	}
+
+	return SLAPolicyResponse{
+		ID:            p.ID,
+		ProjectKey:    p.ProjectKey,
+		Name:          p.Name,
+		TargetSeconds: seconds(p.Target),
+		StartStatuses: append([]string{}, p.StartStatuses...),
+		PauseStatuses: append([]string{}, p.PauseStatuses...),
+		StopStatuses:  append([]string{}, p.StopStatuses...),
+		Calendar:      c,
+	}
+}
+
+func toSLATimerResponse(t logic.SLATimer) SLATimerResponse {
+	return SLATimerResponse{
+		PolicyID:         t.PolicyID,
+		Name:             t.Name,
+		State:            t.State,
+		TargetSeconds:    seconds(t.Target),
+		ElapsedSeconds:   seconds(t.Elapsed),
+		RemainingSeconds: seconds(t.Remaining),
+		Breached:         t.Breached,
+		StartedAt:        t.StartedAt,
+		StoppedAt:        t.StoppedAt,
+		DueAt:            t.DueAt,
+	}
+}
+
+func toCalendar(body CalendarBody) (logic.Calendar, error) {
+	c := logic.Calendar{TimeZone: body.TimeZone}
+	var fields []logic.FieldError
+	for _, name := range body.Workdays {
+		d := slices.Index(weekdays, name)
+		if d < 0 {
+			fields = append(fields, logic.FieldError{Field: "calendar.workdays", Code: logic.FieldInvalid, Message: "must be MON, TUE, WED, THU, FRI, SAT or SUN"})
+			break
+		}
+		c.Workdays = append(c.Workdays, time.Weekday(d))
+	}
+
+	var ok1, ok2 bool
+	c.Opens, ok1 = parseClock(body.Opens)
+	c.Closes, ok2 = parseClock(body.Closes)
+	if !ok1 || !ok2 {
+		fields = append(fields, logic.FieldError{Field: "calendar.hours", Code: logic.FieldInvalid, Message: "opens and closes must be HH:MM times"})
+	}
+
+	for _, raw := range body.Holidays {
+		d, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			fields = append(fields, logic.FieldError{Field: "calendar.holidays", Code: logic.FieldInvalid, Message: "must be YYYY-MM-DD dates"})
+			break
+		}
+		c.Holidays = append(c.Holidays, d)
+	}
+	if len(fields) > 0 {
+		return logic.Calendar{}, logic.NewValidationError(logic.ErrInvalidSLA, fields...)
+	}
+
+	return c, nil
+}
+
+// parseClock reads an HH:MM time of day as the time since midnight;
+// "24:00" is the end of the day.
+func parseClock(raw string) (time.Duration, bool) {
+	switch raw {
+	case "":
+		return 0, true
+	case "24:00":
+		return 24 * time.Hour, true
+	}
+
+	t, err := time.Parse("15:04", raw)
+	if err != nil {
+		return 0, false
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", d/time.Hour, d%time.Hour/time.Minute)
+}
@@ -0,0 +1,70 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestSLAPolicies_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "SUP", "Support")
+
+	body := `{"name":"First response","target_seconds":14400,"start_statuses":["OPEN"],"stop_statuses":["IN_PROGRESS"],
+		"calendar":{"time_zone":"Europe/Berlin","workdays":["MON","TUE","WED","THU","FRI"],"opens":"09:00","closes":"17:00","holidays":["2026-12-25"]}}`
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/SUP/slas", body)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/api/v2/slas/1" {
+		t.Fatalf("expected status code 201 with a location, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var policy SLAPolicyResponse
+	decodeJSON(t, w.Body, &policy)
+	if policy.TargetSeconds != 14400 || len(policy.Calendar.Workdays) != 5 || policy.Calendar.Opens != "09:00" ||
+		policy.Calendar.Closes != "17:00" || len(policy.Calendar.Holidays) != 1 {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+
+	for _, bad := range []string{
+		`{"name":"Bad","target_seconds":60,"start_statuses":["WAITING"],"stop_statuses":["DONE"]}`,
+		`{"name":"Bad","target_seconds":60,"start_statuses":["OPEN"],"stop_statuses":["DONE"],"calendar":{"workdays":["MONDAY"]}}`,
+		`{"name":"Bad","target_seconds":60,"start_statuses":["OPEN"],"stop_statuses":["DONE"],"calendar":{"opens":"9am"}}`,
+	} {
+		w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/SUP/slas", bad)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("expected status code 400 for %s, got %d: %s", bad, w.Code, w.Body.String())
+		}
+	}
+
+	w = performRequest(t, handler, http.MethodPatch, "/api/v2/slas/1", `{"target_seconds":60,"calendar":{}}`)
+
+	var updated SLAPolicyResponse
+	decodeJSON(t, w.Body, &updated)
+	if updated.TargetSeconds != 60 || len(updated.Calendar.Workdays) != 0 || updated.Name != "First response" {
+		t.Fatalf("unexpected policy: %+v", updated)
+	}
+
+	issue := createIssue(t, handler, "SUP", "Cannot log in")
+	w = performRequest(t, handler, http.MethodGet, issuePath(issue.ID), "")
+
+	var got IssueResponse
+	decodeJSON(t, w.Body, &got)
+	if len(got.SLA) != 1 || got.SLA[0].PolicyID != 1 || got.SLA[0].State != "RUNNING" || got.SLA[0].DueAt.IsZero() {
+		t.Fatalf("expected a running timer on the issue, got %+v", got.SLA)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/SUP/issues", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 1 || len(issues[0].SLA) != 1 {
+		t.Fatalf("expected timers on listed issues, got %+v", issues)
+	}
+
+	w = performRequest(t, handler, http.MethodDelete, "/api/v2/slas/1", "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status code 204, got %d: %s", w.Code, w.Body.String())
+	}
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/slas/1", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected status code 404, got %d: %s", w.Code, w.Body.String())
+	}
+}
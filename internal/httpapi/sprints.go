@@ -0,0 +1,207 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultVelocitySprints is the number of sprints in a velocity report
+// requested without sprints.
+const defaultVelocitySprints = 5
+
+// StartSprintRequest may be empty; the sprint then lasts two weeks.
+type StartSprintRequest struct {
+	EndsAt time.Time `json:"ends_at,omitzero" example:"2026-11-01T09:00:00Z"`
+}
+
+// CloseSprintRequest may be empty; unfinished issues then go back to the
+// backlog.
+type CloseSprintRequest struct {
+	MoveToSprintID int `json:"move_to_sprint_id,omitempty" example:"4"`
+}
+
+// BurndownDayResponse has a null remaining for days still ahead.
+type BurndownDayResponse struct {
+	Date      string  `json:"date" example:"2026-10-07"`
+	Remaining *int    `json:"remaining" example:"9"`
+	Ideal     float64 `json:"ideal" example:"10.5"`
+}
+
+type BurndownResponse struct {
+	Sprint SprintResponse        `json:"sprint"`
+	Unit   string                `json:"unit" example:"points" enums:"issues,points"`
+	Scope  int                   `json:"scope" example:"34"`
+	Days   []BurndownDayResponse `json:"days"`
+}
+
+type VelocityResponse struct {
+	ProjectKey    string           `json:"project_key" example:"PAY"`
+	Sprints       []SprintResponse `json:"sprints"`
+	AverageIssues float64          `json:"average_issues" example:"10.5"`
+	AveragePoints float64          `json:"average_points" example:"31"`
+}
+
+// StartSprintV2 godoc
+// @Summary Start a sprint
+// @Description Makes a future sprint the active one and records the issues and story points in it as the commitment. A project has at most one active sprint.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Param request body StartSprintRequest false "Planned end"
+// @Success 200 {object} SprintResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/sprints/{id}/start [post]
+func (h *Handler) StartSprintV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req StartSprintRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	sp, err := h.service.StartSprint(r.Context(), id, req.EndsAt)
+	if err != nil {
+		h.writeServiceError(w, r, err, "start_sprint")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toSprintResponse(sp))
+}
+
+// CloseSprintV2 godoc
+// @Summary Close the active sprint
+// @Description Records the completed issues and story points and moves unfinished issues to move_to_sprint_id or the backlog.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Param request body CloseSprintRequest false "Where unfinished issues go"
+// @Success 200 {object} SprintResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/sprints/{id}/close [post]
+func (h *Handler) CloseSprintV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req CloseSprintRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	sp, err := h.service.CloseSprint(r.Context(), id, req.MoveToSprintID)
+	if err != nil {
+		h.writeServiceError(w, r, err, "close_sprint")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toSprintResponse(sp))
+}
+
+// BurndownReportV2 godoc
+// @Summary Sprint burndown
+// @Description Remaining work at the end of each sprint day, counted in issues or story points, with the ideal line from the commitment to zero.
+// @Description Without sprint the active sprint is used.
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Param sprint query int false "Sprint ID"
+// @Param unit query string false "Unit of work" Enums(issues,points)
+// @Success 200 {object} BurndownResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/reports/burndown [get]
+func (h *Handler) BurndownReportV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	sprintID, err := queryInt(q.Get("sprint"), "sprint", 0)
+	if err != nil {
+		h.writeServiceError(w, r, err, "burndown_report")
+		return
+	}
+
+	rep, err := h.service.SprintBurndown(r.Context(), r.PathValue("key"), sprintID, q.Get("unit"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "burndown_report")
+		return
+	}
+
+	res := BurndownResponse{
+		Sprint: toSprintResponse(rep.Sprint),
+		Unit:   rep.Unit,
+		Scope:  rep.Scope,
+		Days:   make([]BurndownDayResponse, len(rep.Days)),
+	}
+	for i, d := range rep.Days {
+		res.Days[i] = BurndownDayResponse{Date: d.Date.Format(time.DateOnly), Remaining: d.Remaining, Ideal: d.Ideal}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// VelocityReportV2 godoc
+// @Summary Sprint velocity
+// @Description Committed and completed issues and story points of the last closed sprints, oldest first, with the average completed work.
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Param sprints query int false "Number of closed sprints (default 5, max 50)"
+// @Success 200 {object} VelocityResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/reports/velocity [get]
+func (h *Handler) VelocityReportV2(w http.ResponseWriter, r *http.Request) {
+	n, err := queryInt(r.URL.Query().Get("sprints"), "sprints", defaultVelocitySprints)
+	if err != nil {
+		h.writeServiceError(w, r, err, "velocity_report")
+		return
+	}
+
+	rep, err := h.service.SprintVelocity(r.Context(), r.PathValue("key"), n)
+	if err != nil {
+		h.writeServiceError(w, r, err, "velocity_report")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, VelocityResponse{
+		ProjectKey:    rep.ProjectKey,
+		Sprints:       toSprintResponses(rep.Sprints),
+		AverageIssues: rep.AverageIssues,
+		AveragePoints: rep.AveragePoints,
+	})
+}
+
+// queryInt parses an optional integer report parameter.
+func queryInt(raw, field string, def int) (int, error) {
+	if raw == "" {
+		return def, nil
+	}
+
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, logic.NewValidationError(logic.ErrInvalidReport, logic.FieldError{
+			Field:   field,
+			Code:    logic.FieldInvalid,
+			Message: "must be an integer",
+		})
+	}
+
+	return v, nil
+}
@@ -0,0 +1,92 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSprintLifecycle_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	for _, name := range []string{"Sprint 1", "Sprint 2"} {
+		w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/sprints", `{"name":"`+name+`"}`)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected status code 201, got %d", w.Code)
+		}
+	}
+
+	done := createIssue(t, handler, "PAY", "Fix checkout")
+	open := createIssue(t, handler, "PAY", "Release 1.4")
+	for n, issue := range []IssueResponse{done, open} {
+		body := `{"sprint_id":1,"story_points":` + strconv.Itoa(3+2*n) + `}`
+		w := performRequest(t, handler, http.MethodPatch, "/api/v2/issues/"+strconv.Itoa(issue.ID), body)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+
+	w := performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/burndown", "")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "no_active_sprint") {
+		t.Fatalf("expected no_active_sprint, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/sprints/1/start", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var sprint SprintResponse
+	decodeJSON(t, w.Body, &sprint)
+	if sprint.State != "ACTIVE" || sprint.CommittedIssues != 2 || sprint.CommittedPoints != 8 {
+		t.Fatalf("unexpected started sprint: %+v", sprint)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/sprints/2/start", "")
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "invalid_sprint_state") {
+		t.Fatalf("expected invalid_sprint_state, got %d %s", w.Code, w.Body.String())
+	}
+
+	for _, status := range []string{"IN_PROGRESS", "DONE"} {
+		w = performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(done.ID)+"/transitions", `{"to_status":"`+status+`","resolution":"FIXED"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d", w.Code)
+		}
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/burndown?unit=points", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var burndown BurndownResponse
+	decodeJSON(t, w.Body, &burndown)
+	if burndown.Scope != 8 || len(burndown.Days) < 14 || burndown.Days[0].Remaining == nil || *burndown.Days[0].Remaining != 5 {
+		t.Fatalf("unexpected burndown: %+v", burndown)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/sprints/1/close", `{"move_to_sprint_id":2}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	decodeJSON(t, w.Body, &sprint)
+	if sprint.State != "CLOSED" || sprint.CompletedPoints != 3 || len(sprint.IncompleteIssueIDs) != 1 {
+		t.Fatalf("unexpected closed sprint: %+v", sprint)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/velocity?sprints=3", "")
+
+	var velocity VelocityResponse
+	decodeJSON(t, w.Body, &velocity)
+	if len(velocity.Sprints) != 1 || velocity.AveragePoints != 3 || velocity.AverageIssues != 1 {
+		t.Fatalf("unexpected velocity: %+v", velocity)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/reports/velocity?sprints=many", "")
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_report") {
+		t.Fatalf("expected invalid_report, got %d %s", w.Code, w.Body.String())
+	}
+}
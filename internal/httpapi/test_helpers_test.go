@@ -1,7 +1,10 @@
 package httpapi
 
 import (
+	"MiniJira/internal/events"
 	"MiniJira/internal/store/memory"
+	"MiniJira/internal/tracing"
+	"MiniJira/internal/usecase"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -13,12 +16,27 @@ import (
 )
 
 func newTestHandler() http.Handler {
+	return newTestHandlerWithTracer(nil)
+}
+
+func newTestHandlerWithTracer(tracer *tracing.Tracer) http.Handler {
+	deps := newTestDeps()
+	deps.Service = usecase.NewService(memory.NewStore(), events.NewBus(), tracer)
+	deps.Tracer = tracer
+
+	return NewMux(deps)
+}
+
+func newTestDeps() Deps {
 	store := memory.NewStore()
 
 	logger := logrus.New()
 	logger.SetOutput(io.Discard)
 
-	return NewMux(store, store, store, logger)
+	return Deps{
+		Service: usecase.NewService(store, events.NewBus(), nil),
+		Logger:  logger,
+	}
 }
 
 func performRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"MiniJira/internal/archive"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxImportSize bounds the archive accepted by ImportProjectV2.
+const maxImportSize = 32 << 20
+
+type ImportReportResponse struct {
+	DryRun    bool            `json:"dry_run" example:"false"`
+	Project   ProjectResponse `json:"project"`
+	Sprints   int             `json:"sprints" example:"2"`
+	Issues    int             `json:"issues" example:"42"`
+	Comments  int             `json:"comments" example:"17"`
+	Worklogs  int             `json:"worklogs" example:"9"`
+	Links     int             `json:"links" example:"5"`
+	SprintIDs map[int]int     `json:"sprint_ids"`
+	IssueIDs  map[int]int     `json:"issue_ids"`
+}
+
+// ExportProjectV2 godoc
+// @Summary Export project
+// @Description Streams the project with its sprints, components, versions, custom fields and issues as a versioned JSON archive (format minijira.project).
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {object} archive.Archive
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/export [get]
+func (h *Handler) ExportProjectV2(w http.ResponseWriter, r *http.Request) {
+	snap, err := h.service.ExportProject(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "export_project")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+snap.Project.Key+`.minijira.json"`)
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are gone by now; a failed write only means the client left.
+	err = archive.Write(w, snap, time.Now())
+	if err != nil {
+		h.logger.WithError(err).Warn("export_project: write failed")
+	}
+}
+
+// ImportProjectV2 godoc
+// @Summary Import project
+// @Description Recreates an exported project. New IDs are assigned and returned as old-to-new maps.
+// @Description key and name override the archived project key and name. With dry_run=true nothing is stored and the report shows what would be created.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key query string false "Target project key"
+// @Param name query string false "Target project name"
+// @Param dry_run query bool false "Validate only"
+// @Param request body archive.Archive true "Project archive"
+// @Success 201 {object} ImportReportResponse
+// @Success 200 {object} ImportReportResponse "Dry run"
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects/import [post]
+func (h *Handler) ImportProjectV2(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	dryRun, _ := strconv.ParseBool(q.Get("dry_run"))
+
+	a, err := archive.Read(http.MaxBytesReader(w, r.Body, maxImportSize))
+	if err != nil {
+		h.writeServiceError(w, r, err, "import_project")
+		return
+	}
+
+	opts := archive.Options{ProjectKey: q.Get("key"), ProjectName: q.Get("name")}
+	rep, err := h.service.ImportProject(r.Context(), a, opts, dryRun)
+	if err != nil {
+		h.writeServiceError(w, r, err, "import_project")
+		return
+	}
+
+	status := http.StatusCreated
+	if dryRun {
+		status = http.StatusOK
+	} else {
+		w.Header().Set("Location", projectPath(rep.Project.Key))
+	}
+
+	WriteJSON(w, status, toImportReportResponse(rep, dryRun))
+}
@@ -0,0 +1,74 @@
+package httpapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestExportImport_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+	createIssue(t, handler, "PAY", "Fix checkout")
+	createIssue(t, handler, "PAY", "Refund flow")
+
+	w := performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/export", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+	archive := w.Body.String()
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/import?key=PAY2&dry_run=true", archive)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d", w.Code)
+	}
+
+	var report ImportReportResponse
+	decodeJSON(t, w.Body, &report)
+	if !report.DryRun || report.Issues != 2 {
+		t.Fatalf("unexpected dry-run report: %+v", report)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY2", "")
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected dry run to store nothing, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/import", archive)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected status code 409 for an existing key, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/projects/import?key=PAY2", archive)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d", w.Code)
+	}
+
+	decodeJSON(t, w.Body, &report)
+	if report.IssueIDs[1] != 3 || report.IssueIDs[2] != 4 {
+		t.Fatalf("unexpected id map: %v", report.IssueIDs)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY2/issues", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 2 || issues[0].Title != "Fix checkout" {
+		t.Fatalf("unexpected imported issues: %+v", issues)
+	}
+}
+
+func TestImport_HTTP_InvalidArchive(t *testing.T) {
+	handler := newTestHandler()
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/import",
+		`{"format":"minijira.project","version":1,"project":{"key":"PAY","name":"Payments"},"issues":[{"id":1,"title":"x","status":"CLOSED"}]}`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code 400, got %d", w.Code)
+	}
+
+	var resp ErrorResponse
+	decodeJSON(t, w.Body, &resp)
+	if resp.Code != "invalid_archive" || len(resp.Errors) != 1 || resp.Errors[0].Field != "issues[0].status" {
+		t.Fatalf("unexpected problem: %+v", resp)
+	}
+}
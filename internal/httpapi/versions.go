@@ -0,0 +1,391 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ContentTypeMarkdown = "text/markdown"
+
+type VersionResponse struct {
+	ID          int       `json:"id" example:"2"`
+	ProjectKey  string    `json:"project_key" example:"PAY"`
+	Name        string    `json:"name" example:"1.4.0"`
+	Description string    `json:"description,omitempty" example:"Checkout rework"`
+	State       string    `json:"state" example:"UNRELEASED" enums:"UNRELEASED,RELEASED,ARCHIVED"`
+	ReleaseDate string    `json:"release_date,omitempty" example:"2026-11-02"`
+	ReleasedAt  time.Time `json:"released_at,omitzero" example:"2026-11-02T15:04:05Z"`
+}
+
+type CreateVersionRequest struct {
+	Name        string `json:"name" example:"1.4.0"`
+	Description string `json:"description,omitempty" example:"Checkout rework"`
+	// ReleaseDate is the planned release day.
+	ReleaseDate string `json:"release_date,omitempty" example:"2026-11-02"`
+}
+
+// UpdateVersionRequest changes only the fields present; an empty
+// release_date clears it.
+type UpdateVersionRequest struct {
+	Name        *string `json:"name,omitempty" example:"1.4.0"`
+	Description *string `json:"description,omitempty" example:"Checkout rework"`
+	ReleaseDate *string `json:"release_date,omitempty" example:"2026-11-02"`
+}
+
+// ReleaseVersionRequest may be empty when every issue of the version is
+// done.
+type ReleaseVersionRequest struct {
+	// ReleaseDate defaults to the planned date, or today without one.
+	ReleaseDate     string `json:"release_date,omitempty" example:"2026-11-02"`
+	MoveToVersionID int    `json:"move_to_version_id,omitempty" example:"3"`
+}
+
+type ReleaseNotesGroupResponse struct {
+	Type   string          `json:"type" example:"BUG"`
+	Issues []IssueResponse `json:"issues"`
+}
+
+type ReleaseNotesResponse struct {
+	Version VersionResponse             `json:"version"`
+	Groups  []ReleaseNotesGroupResponse `json:"groups"`
+}
+
+// ListVersionsV2 godoc
+// @Summary List versions of a project
+// @Tags v2
+// @Produce json
+// @Param key path string true "Project key"
+// @Success 200 {array} VersionResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/versions [get]
+func (h *Handler) ListVersionsV2(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.service.ListVersions(r.Context(), r.PathValue("key"))
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_versions")
+		return
+	}
+
+	res := make([]VersionResponse, len(versions))
+	for i, v := range versions {
+		res[i] = toVersionResponse(v)
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// CreateVersionV2 godoc
+// @Summary Create a version in a project
+// @Description Versions start UNRELEASED. Names are unique within a project, ignoring case.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param key path string true "Project key"
+// @Param request body CreateVersionRequest true "Version"
+// @Success 201 {object} VersionResponse
+// @Header 201 {string} Location "URL of the created version"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/projects/{key}/versions [post]
+func (h *Handler) CreateVersionV2(w http.ResponseWriter, r *http.Request) {
+	var req CreateVersionRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	releaseDate, err := parseReleaseDate(req.ReleaseDate)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_version")
+		return
+	}
+
+	created, err := h.service.CreateVersion(r.Context(), r.PathValue("key"), req.Name, req.Description, releaseDate)
+	if err != nil {
+		h.writeServiceError(w, r, err, "create_version")
+		return
+	}
+
+	w.Header().Set("Location", "/api/v2/versions/"+strconv.Itoa(created.ID))
+	WriteJSON(w, http.StatusCreated, toVersionResponse(created))
+}
+
+// GetVersionV2 godoc
+// @Summary Get a version
+// @Tags v2
+// @Produce json
+// @Param id path int true "Version ID"
+// @Success 200 {object} VersionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/versions/{id} [get]
+func (h *Handler) GetVersionV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	v, err := h.service.GetVersion(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "get_version")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toVersionResponse(v))
+}
+
+// UpdateVersionV2 godoc
+// @Summary Update a version
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Version ID"
+// @Param request body UpdateVersionRequest true "Fields to change"
+// @Success 200 {object} VersionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/versions/{id} [patch]
+func (h *Handler) UpdateVersionV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateVersionRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.VersionPatch{Name: req.Name, Description: req.Description}
+	if req.ReleaseDate != nil {
+		d, err := parseReleaseDate(*req.ReleaseDate)
+		if err != nil {
+			h.writeServiceError(w, r, err, "update_version")
+			return
+		}
+		patch.ReleaseDate = &d
+	}
+
+	updated, err := h.service.UpdateVersion(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_version")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toVersionResponse(updated))
+}
+
+// ReleaseVersionV2 godoc
+// @Summary Release a version
+// @Description Issues of the version that are not done must be moved to move_to_version_id, another unreleased version of the project; without it the release fails with unfinished_issues.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Version ID"
+// @Param request body ReleaseVersionRequest false "Release"
+// @Success 200 {object} VersionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/versions/{id}/release [post]
+func (h *Handler) ReleaseVersionV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ReleaseVersionRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 1024)).Decode(&req)
+	if err != nil && !errors.Is(err, io.EOF) {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	releaseDate, err := parseReleaseDate(req.ReleaseDate)
+	if err != nil {
+		h.writeServiceError(w, r, err, "release_version")
+		return
+	}
+
+	released, err := h.service.ReleaseVersion(r.Context(), id, req.MoveToVersionID, releaseDate)
+	if err != nil {
+		h.writeServiceError(w, r, err, "release_version")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toVersionResponse(released))
+}
+
+// ArchiveVersionV2 godoc
+// @Summary Archive a version
+// @Description Archived versions keep their issues but cannot be set as a fix version.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Version ID"
+// @Success 200 {object} VersionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /api/v2/versions/{id}/archive [post]
+func (h *Handler) ArchiveVersionV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	archived, err := h.service.ArchiveVersion(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "archive_version")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toVersionResponse(archived))
+}
+
+// ReleaseNotesV2 godoc
+// @Summary Release notes of a version
+// @Description The done issues of the version grouped by issue type, as JSON or Markdown.
+// @Tags v2
+// @Produce json
+// @Produce text/markdown
+// @Param id path int true "Version ID"
+// @Param format query string false "Response format" Enums(json,markdown)
+// @Success 200 {object} ReleaseNotesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/versions/{id}/release-notes [get]
+func (h *Handler) ReleaseNotesV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "markdown" {
+		h.writeServiceError(w, r, logic.NewValidationError(logic.ErrInvalidVersion, logic.FieldError{
+			Field:   "format",
+			Code:    logic.FieldInvalid,
+			Message: "must be json or markdown",
+		}), "release_notes")
+		return
+	}
+
+	notes, err := h.service.ReleaseNotes(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "release_notes")
+		return
+	}
+
+	if format == "markdown" {
+		w.Header().Set("Content-Type", ContentTypeMarkdown+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		if err := writeReleaseNotesMarkdown(w, notes); err != nil {
+			h.logger.WithError(err).Warn("release_notes: write failed")
+		}
+		return
+	}
+
+	res := ReleaseNotesResponse{
+		Version: toVersionResponse(notes.Version),
+		Groups:  make([]ReleaseNotesGroupResponse, len(notes.Groups)),
+	}
+	for i, g := range notes.Groups {
+		res.Groups[i] = ReleaseNotesGroupResponse{Type: g.Type, Issues: toIssueResponses(g.Issues)}
+	}
+	WriteJSON(w, http.StatusOK, res)
+}
+
+// releaseNotesHeadings names the release note sections by issue type.
+var releaseNotesHeadings = map[string]string{
+	logic.TypeTask:    "Tasks",
+	logic.TypeBug:     "Bug fixes",
+	logic.TypeStory:   "Stories",
+	logic.TypeEpic:    "Epics",
+	logic.TypeSubtask: "Subtasks",
+}
+
+func writeReleaseNotesMarkdown(w io.Writer, notes logic.ReleaseNotes) error {
+	v := notes.Version
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s %s\n\n", v.ProjectKey, v.Name)
+	switch {
+	case v.State != logic.VersionUnreleased && !v.ReleaseDate.IsZero():
+		fmt.Fprintf(&b, "Released %s.\n\n", v.ReleaseDate.Format(time.DateOnly))
+	case !v.ReleaseDate.IsZero():
+		fmt.Fprintf(&b, "Unreleased, planned for %s.\n\n", v.ReleaseDate.Format(time.DateOnly))
+	default:
+		b.WriteString("Unreleased.\n\n")
+	}
+	if v.Description != "" {
+		b.WriteString(v.Description + "\n\n")
+	}
+	if len(notes.Groups) == 0 {
+		b.WriteString("No completed issues.\n")
+	}
+
+	for n, g := range notes.Groups {
+		if n > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", releaseNotesHeadings[g.Type])
+		for _, i := range g.Issues {
+			fmt.Fprintf(&b, "- #%d %s\n", i.ID, markdownLine(i.Title))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// markdownLine keeps a title on one list line and stops it from starting
+// Markdown markup of its own.
+func markdownLine(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`).Replace(s)
+}
+
+// parseReleaseDate reads an optional YYYY-MM-DD release date.
+func parseReleaseDate(raw string) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, logic.NewValidationError(logic.ErrInvalidVersion, logic.FieldError{
+			Field:   "release_date",
+			Code:    logic.FieldInvalid,
+			Message: "must be a YYYY-MM-DD date",
+		})
+	}
+
+	return d, nil
+}
+
+func toVersionResponse(v logic.Version) VersionResponse {
+	res := VersionResponse{
+		ID:          v.ID,
+		ProjectKey:  v.ProjectKey,
+		Name:        v.Name,
+		Description: v.Description,
+		State:       v.State,
+		ReleasedAt:  v.ReleasedAt,
+	}
+	if !v.ReleaseDate.IsZero() {
+		res.ReleaseDate = v.ReleaseDate.Format(time.DateOnly)
+	}
+
+	return res
+}
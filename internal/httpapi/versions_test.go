@@ -0,0 +1,77 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestVersions_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/versions", `{"name":"1.4.0","description":"Checkout rework","release_date":"2026-11-02"}`)
+	if w.Code != http.StatusCreated || w.Header().Get("Location") != "/api/v2/versions/1" {
+		t.Fatalf("expected status code 201 with a location, got %d: %s", w.Code, w.Body.String())
+	}
+	performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/versions", `{"name":"1.5.0"}`)
+
+	done := createIssue(t, handler, "PAY", "Fix *rounding* in totals")
+	open := createIssue(t, handler, "PAY", "Receipts")
+	for _, issue := range []IssueResponse{done, open} {
+		w = performRequest(t, handler, http.MethodPatch, "/api/v2/issues/"+strconv.Itoa(issue.ID), `{"fix_version_id":1,"type":"BUG"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	for _, status := range []string{"IN_PROGRESS", "DONE"} {
+		performRequest(t, handler, http.MethodPost, "/api/v2/issues/"+strconv.Itoa(done.ID)+"/transitions", `{"to_status":"`+status+`","resolution":"FIXED"}`)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/projects/PAY/issues?fix_version=1", "")
+
+	var issues []IssueResponse
+	decodeJSON(t, w.Body, &issues)
+	if len(issues) != 2 {
+		t.Fatalf("expected two issues in the version, got %+v", issues)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/versions/1/release-notes?format=markdown", "")
+	want := "# PAY 1.4.0\n\nUnreleased, planned for 2026-11-02.\n\nCheckout rework\n\n## Bug fixes\n\n- #1 Fix \\*rounding\\* in totals\n"
+	if w.Body.String() != want {
+		t.Fatalf("unexpected release notes:\n%s", w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/versions/1/release", "")
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "unfinished_issues") {
+		t.Fatalf("expected unfinished_issues, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, "/api/v2/versions/1/release", `{"move_to_version_id":2}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var version VersionResponse
+	decodeJSON(t, w.Body, &version)
+	if version.State != "RELEASED" || version.ReleaseDate != "2026-11-02" || version.ReleasedAt.IsZero() {
+		t.Fatalf("unexpected released version: %+v", version)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/versions/1/release-notes", "")
+
+	var notes ReleaseNotesResponse
+	decodeJSON(t, w.Body, &notes)
+	if len(notes.Groups) != 1 || len(notes.Groups[0].Issues) != 1 || notes.Groups[0].Issues[0].ID != done.ID {
+		t.Fatalf("unexpected release notes: %+v", notes)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, "/api/v2/issues/"+strconv.Itoa(open.ID), "")
+
+	var moved IssueResponse
+	decodeJSON(t, w.Body, &moved)
+	if moved.FixVersionID != 2 {
+		t.Fatalf("expected the open issue in version 2, got %+v", moved)
+	}
+}
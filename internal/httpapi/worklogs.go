@@ -0,0 +1,219 @@
+package httpapi
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+)
+
+type WorklogResponse struct {
+	ID               int       `json:"id" example:"1"`
+	IssueID          int       `json:"issue_id" example:"10"`
+	Author           string    `json:"author" example:"alice"`
+	TimeSpentSeconds int64     `json:"time_spent_seconds" example:"5400"`
+	StartedAt        time.Time `json:"started_at" example:"2026-10-18T09:00:00Z"`
+	Comment          string    `json:"comment,omitempty" example:"Reproduced and fixed the rounding."`
+	CreatedAt        time.Time `json:"created_at" example:"2026-10-18T10:30:00Z"`
+	UpdatedAt        time.Time `json:"updated_at" example:"2026-10-18T10:30:00Z"`
+}
+
+// LogWorkRequest records time spent; started_at defaults to now.
+type LogWorkRequest struct {
+	Author           string    `json:"author" example:"alice"`
+	TimeSpentSeconds int64     `json:"time_spent_seconds" example:"5400"`
+	StartedAt        time.Time `json:"started_at,omitzero" example:"2026-10-18T09:00:00Z"`
+	Comment          string    `json:"comment,omitempty" example:"Reproduced and fixed the rounding."`
+}
+
+// UpdateWorklogRequest changes only the fields present.
+type UpdateWorklogRequest struct {
+	TimeSpentSeconds *int64     `json:"time_spent_seconds,omitempty" example:"7200"`
+	StartedAt        *time.Time `json:"started_at,omitempty" example:"2026-10-18T09:00:00Z"`
+	Comment          *string    `json:"comment,omitempty" example:"Also covered the refund path."`
+}
+
+// TimeTrackingResponse sums the estimates and time spent of issues issues.
+type TimeTrackingResponse struct {
+	Issues                   int   `json:"issues" example:"4"`
+	OriginalEstimateSeconds  int64 `json:"original_estimate_seconds" example:"57600"`
+	RemainingEstimateSeconds int64 `json:"remaining_estimate_seconds" example:"21600"`
+	TimeSpentSeconds         int64 `json:"time_spent_seconds" example:"43200"`
+}
+
+// ListWorklogsV2 godoc
+// @Summary List worklogs of an issue
+// @Description In the order they were logged.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {array} WorklogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/worklogs [get]
+func (h *Handler) ListWorklogsV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	worklogs, err := h.service.ListWorklogs(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "list_worklogs")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toWorklogResponses(worklogs))
+}
+
+// LogWorkV2 godoc
+// @Summary Log work on an issue
+// @Description Adds to the time spent on the issue and lowers its remaining estimate by the same amount, never below zero. Issues without a time estimate keep none.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Param request body LogWorkRequest true "Worklog"
+// @Success 201 {object} WorklogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/worklogs [post]
+func (h *Handler) LogWorkV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req LogWorkRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	wl, err := h.service.LogWork(r.Context(), id, req.Author, durationOf(req.TimeSpentSeconds), req.StartedAt, req.Comment)
+	if err != nil {
+		h.writeServiceError(w, r, err, "log_work")
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, toWorklogResponse(wl))
+}
+
+// UpdateWorklogV2 godoc
+// @Summary Edit a worklog
+// @Description A change in time spent moves the remaining estimate of the issue the other way.
+// @Tags v2
+// @Accept json
+// @Produce json
+// @Param id path int true "Worklog ID"
+// @Param request body UpdateWorklogRequest true "Fields to change"
+// @Success 200 {object} WorklogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/worklogs/{id} [patch]
+func (h *Handler) UpdateWorklogV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdateWorklogRequest
+	err := json.NewDecoder(io.LimitReader(r.Body, 64<<10)).Decode(&req)
+	if err != nil {
+		writeMalformedBody(w, r, err)
+		return
+	}
+
+	patch := logic.WorklogPatch{StartedAt: req.StartedAt, Comment: req.Comment}
+	if req.TimeSpentSeconds != nil {
+		spent := durationOf(*req.TimeSpentSeconds)
+		patch.Spent = &spent
+	}
+
+	wl, err := h.service.UpdateWorklog(r.Context(), id, patch)
+	if err != nil {
+		h.writeServiceError(w, r, err, "update_worklog")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toWorklogResponse(wl))
+}
+
+// DeleteWorklogV2 godoc
+// @Summary Delete a worklog
+// @Description Gives the logged time back to the remaining estimate of the issue.
+// @Tags v2
+// @Param id path int true "Worklog ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/worklogs/{id} [delete]
+func (h *Handler) DeleteWorklogV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteWorklog(r.Context(), id); err != nil {
+		h.writeServiceError(w, r, err, "delete_worklog")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// IssueTimeTrackingV2 godoc
+// @Summary Time tracking of an issue
+// @Description Sums the issue and every issue below it, so an epic includes its stories and their subtasks.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Issue ID"
+// @Success 200 {object} TimeTrackingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/issues/{id}/time-tracking [get]
+func (h *Handler) IssueTimeTrackingV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.IssueTimeTracking(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "issue_time_tracking")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTimeTrackingResponse(t))
+}
+
+// SprintTimeTrackingV2 godoc
+// @Summary Time tracking of a sprint
+// @Description Sums the issues currently in the sprint.
+// @Tags v2
+// @Produce json
+// @Param id path int true "Sprint ID"
+// @Success 200 {object} TimeTrackingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /api/v2/sprints/{id}/time-tracking [get]
+func (h *Handler) SprintTimeTrackingV2(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+
+	t, err := h.service.SprintTimeTracking(r.Context(), id)
+	if err != nil {
+		h.writeServiceError(w, r, err, "sprint_time_tracking")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, toTimeTrackingResponse(t))
+}
+
+func durationOf(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,76 @@
+package httpapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWorklogs_HTTP(t *testing.T) {
+	handler := newTestHandler()
+	createProject(t, handler, "PAY", "Payments")
+
+	w := performRequest(t, handler, http.MethodPost, "/api/v2/projects/PAY/issues", `{"title":"Fix checkout","original_estimate_seconds":28800}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var issue IssueResponse
+	decodeJSON(t, w.Body, &issue)
+	if issue.RemainingEstimateSeconds != 28800 {
+		t.Fatalf("expected the remaining estimate to default to the original, got %+v", issue)
+	}
+	path := "/api/v2/issues/" + strconv.Itoa(issue.ID)
+
+	w = performRequest(t, handler, http.MethodPost, path+"/worklogs", `{"author":"alice","time_spent_seconds":7200,"comment":"Reproduced"}`)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status code 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var wl WorklogResponse
+	decodeJSON(t, w.Body, &wl)
+	worklogPath := "/api/v2/worklogs/" + strconv.Itoa(wl.ID)
+
+	w = performRequest(t, handler, http.MethodPatch, worklogPath, `{"time_spent_seconds":10800}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status code 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodGet, path, "")
+	decodeJSON(t, w.Body, &issue)
+	if issue.TimeSpentSeconds != 10800 || issue.RemainingEstimateSeconds != 18000 {
+		t.Fatalf("unexpected issue after logging work: %+v", issue)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, path+"/time-tracking", "")
+
+	var tracking TimeTrackingResponse
+	decodeJSON(t, w.Body, &tracking)
+	if tracking.Issues != 1 || tracking.TimeSpentSeconds != 10800 {
+		t.Fatalf("unexpected time tracking: %+v", tracking)
+	}
+
+	w = performRequest(t, handler, http.MethodDelete, worklogPath, "")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected status code 204, got %d", w.Code)
+	}
+
+	w = performRequest(t, handler, http.MethodGet, path+"/worklogs", "")
+
+	var worklogs []WorklogResponse
+	decodeJSON(t, w.Body, &worklogs)
+	if len(worklogs) != 0 {
+		t.Fatalf("expected no worklogs, got %+v", worklogs)
+	}
+
+	w = performRequest(t, handler, http.MethodDelete, worklogPath, "")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "worklog_not_found") {
+		t.Fatalf("expected worklog_not_found, got %d %s", w.Code, w.Body.String())
+	}
+
+	w = performRequest(t, handler, http.MethodPost, path+"/worklogs", `{"author":"alice","time_spent_seconds":-60}`)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "invalid_worklog") {
+		t.Fatalf("expected invalid_worklog, got %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,230 @@
+// Package issuecsv reads and writes issues as CSV for spreadsheet users.
+package issuecsv
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidCSV = errors.New("invalid csv")
+
+// Columns is the export column set, in default order.
+var Columns = []string{"id", "project_key", "title", "status", "rank", "assignee", "labels", "sprint_id"}
+
+// ImportFields are the issue fields a CSV import can set. Custom fields
+// are set by columns named cf.<key>.
+var ImportFields = []string{"title", "assignee", "labels"}
+
+// CustomFieldPrefix starts the names of custom field columns.
+const CustomFieldPrefix = "cf."
+
+// ParseColumns reads a comma-separated column list; empty means Columns.
+func ParseColumns(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return Columns, nil
+	}
+
+	var cols []string
+	for _, c := range strings.Split(raw, ",") {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if !slices.Contains(Columns, c) {
+			return nil, logic.NewValidationError(ErrInvalidCSV, logic.FieldError{
+				Field:   "columns",
+				Code:    logic.FieldInvalid,
+				Message: "unknown column " + strconv.Quote(c) + ", expected any of " + strings.Join(Columns, ", "),
+			})
+		}
+		cols = append(cols, c)
+	}
+
+	return cols, nil
+}
+
+// Write writes a header and one row per issue, flushing as it goes.
+func Write(w io.Writer, columns []string, issues []logic.Issue) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return err
+	}
+
+	record := make([]string, len(columns))
+	for _, i := range issues {
+		for n, c := range columns {
+			record[n] = value(i, c)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func value(i logic.Issue, column string) string {
+	switch column {
+	case "id":
+		return strconv.Itoa(i.ID)
+	case "project_key":
+		return i.ProjectKey
+	case "title":
+		return i.Title
+	case "status":
+		return i.Status
+	case "rank":
+		return strconv.Itoa(i.Rank)
+	case "assignee":
+		return i.Assignee
+	case "labels":
+		return strings.Join(i.Labels, " ")
+	case "sprint_id":
+		if i.SprintID == 0 {
+			return ""
+		}
+		return strconv.Itoa(i.SprintID)
+	}
+
+	return ""
+}
+
+// ParseMapping reads "Header:field,Other:field" pairs mapping CSV headers
+// to import fields.
+func ParseMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		header, field, ok := strings.Cut(pair, ":")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || strings.TrimSpace(header) == "" || !importField(field) {
+			return nil, logic.NewValidationError(ErrInvalidCSV, logic.FieldError{
+				Field:   "map",
+				Code:    logic.FieldInvalid,
+				Message: "expected Header:field pairs with field one of " + strings.Join(ImportFields, ", ") + " or cf.<key>",
+			})
+		}
+		mapping[strings.ToLower(strings.TrimSpace(header))] = field
+	}
+
+	return mapping, nil
+}
+
+// Row is one data line of an import. Line is the 1-based line in the file,
+// counting the header.
+type Row struct {
+	Line  int
+	Issue logic.NewIssue
+}
+
+// Reader streams rows of an import file, one record at a time.
+type Reader struct {
+	csv    *csv.Reader
+	fields []string
+}
+
+// NewReader reads the header and resolves each column to an import field,
+// either through mapping or by matching the field name. Unmapped columns
+// are ignored; a title column is required.
+func NewReader(r io.Reader, mapping map[string]string) (*Reader, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, logic.NewValidationError(ErrInvalidCSV, logic.FieldError{
+			Field:   "header",
+			Code:    logic.FieldRequired,
+			Message: "the first line must name the columns",
+		})
+	}
+
+	// Spreadsheets often prefix UTF-8 files with a byte order mark.
+	fields := make([]string, len(header))
+	hasTitle := false
+	for n, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		field, ok := mapping[h]
+		if !ok && importField(h) {
+			field = h
+		}
+		fields[n] = field
+		hasTitle = hasTitle || field == "title"
+	}
+	if !hasTitle {
+		return nil, logic.NewValidationError(ErrInvalidCSV, logic.FieldError{
+			Field:   "header",
+			Code:    logic.FieldRequired,
+			Message: "no column maps to title",
+		})
+	}
+
+	return &Reader{csv: cr, fields: fields}, nil
+}
+
+// Next returns the next row, or io.EOF after the last one. A malformed
+// line is reported as a *ParseError so the caller can skip it.
+func (r *Reader) Next() (Row, error) {
+	record, err := r.csv.Read()
+	if err == io.EOF {
+		return Row{}, io.EOF
+	}
+
+	if err != nil {
+		var perr *csv.ParseError
+		if errors.As(err, &perr) {
+			return Row{}, &ParseError{Line: perr.Line, Err: perr.Err}
+		}
+		return Row{}, err
+	}
+
+	line, _ := r.csv.FieldPos(0)
+	row := Row{Line: line}
+	for n, v := range record {
+		if n >= len(r.fields) {
+			break
+		}
+		switch r.fields[n] {
+		case "title":
+			row.Issue.Title = v
+		case "assignee":
+			row.Issue.Assignee = v
+		case "labels":
+			row.Issue.Labels = strings.FieldsFunc(v, func(c rune) bool {
+				return c == ',' || c == ';' || c == ' ' || c == '\t'
+			})
+		default:
+			if key, ok := strings.CutPrefix(r.fields[n], CustomFieldPrefix); ok {
+				if row.Issue.CustomFields == nil {
+					row.Issue.CustomFields = make(map[string][]string)
+				}
+				row.Issue.CustomFields[key] = []string{v}
+			}
+		}
+	}
+
+	return row, nil
+}
+
+func importField(field string) bool {
+	key, ok := strings.CutPrefix(field, CustomFieldPrefix)
+
+	return slices.Contains(ImportFields, field) || (ok && key != "")
+}
+
+// ParseError is a line the CSV parser could not read; reading may go on.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
@@ -0,0 +1,102 @@
+package issuecsv
+
+import (
+	"MiniJira/internal/logic"
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWrite_SelectedColumns(t *testing.T) {
+	issues := []logic.Issue{
+		{ID: 1, ProjectKey: "PAY", Title: "Fix, checkout", Status: logic.StatusOpen, Labels: []string{"a", "b"}},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, []string{"id", "title", "labels", "sprint_id"}, issues); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := "id,title,labels,sprint_id\n1,\"Fix, checkout\",a b,\n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestParseColumns_Unknown(t *testing.T) {
+	_, err := ParseColumns("id,estimate")
+	if !errors.Is(err, ErrInvalidCSV) {
+		t.Fatalf("expected ErrInvalidCSV, got %v", err)
+	}
+}
+
+func TestReader_MappingAndBadLines(t *testing.T) {
+	mapping, err := ParseMapping("Summary:title,Owner:assignee")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	in := "\ufeffSummary,Owner,Labels,Estimate\n" +
+		"Fix checkout,alice,\"backend, release\",3\n" +
+		"Bad \"quote,bob,,\n" +
+		"Refund flow,,,\n"
+
+	r, err := NewReader(strings.NewReader(in), mapping)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	row, err := r.Next()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if row.Line != 2 || row.Issue.Title != "Fix checkout" || row.Issue.Assignee != "alice" ||
+		!slices.Equal(row.Issue.Labels, []string{"backend", "release"}) {
+		t.Fatalf("unexpected row: %+v", row)
+	}
+
+	_, err = r.Next()
+	var perr *ParseError
+	if !errors.As(err, &perr) || perr.Line != 3 {
+		t.Fatalf("expected parse error on line 3, got %v", err)
+	}
+
+	row, err = r.Next()
+	if err != nil || row.Line != 4 || row.Issue.Title != "Refund flow" {
+		t.Fatalf("unexpected row %+v, err %v", row, err)
+	}
+
+	if _, err := r.Next(); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
+func TestNewReader_RequiresTitle(t *testing.T) {
+	_, err := NewReader(strings.NewReader("name,owner\nx,y\n"), nil)
+	if !errors.Is(err, ErrInvalidCSV) {
+		t.Fatalf("expected ErrInvalidCSV, got %v", err)
+	}
+}
+
+func TestReader_CustomFieldColumns(t *testing.T) {
+	mapping, err := ParseMapping("Sev:cf.severity")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r, err := NewReader(strings.NewReader("title,Sev,cf.amount\nRefund fails,S1,12.50\n"), mapping)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	row, err := r.Next()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(row.Issue.CustomFields["severity"], []string{"S1"}) || !slices.Equal(row.Issue.CustomFields["amount"], []string{"12.50"}) {
+		t.Fatalf("unexpected custom fields: %v", row.Issue.CustomFields)
+	}
+}
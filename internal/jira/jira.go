@@ -0,0 +1,508 @@
+// Package jira converts Jira exports (the issues CSV and the REST search
+// JSON dump) into MiniJira project archives, one per Jira project.
+package jira
+
+import (
+	"MiniJira/internal/archive"
+	"MiniJira/internal/logic"
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrInvalidExport = errors.New("invalid jira export")
+
+// Issue is what the importer reads from either export format.
+type Issue struct {
+	Key            string
+	ID             string
+	ProjectKey     string
+	ProjectName    string
+	Summary        string
+	Type           string
+	Subtask        bool
+	Status         string
+	StatusCategory string
+	Priority       string
+	Assignee       string
+	Labels         []string
+	// Resolution is the Jira resolution name and Resolved its date, both
+	// empty for unresolved issues.
+	Resolution string
+	Resolved   time.Time
+	// ParentKey is resolved from the parent id by the CSV parser.
+	ParentKey string
+	Comments  []Comment
+	// Links are outward links only; Jira lists every link on both ends.
+	Links   []Link
+	Created time.Time
+	// History holds status changes with Jira status names, oldest first.
+	History []StatusChange
+}
+
+type Comment struct {
+	Author  string
+	Body    string
+	Created time.Time
+}
+
+type Link struct {
+	Type string
+	To   string
+}
+
+type StatusChange struct {
+	From string
+	To   string
+	At   time.Time
+}
+
+// Mapping overrides the built-in name mapping. Keys are Jira names as
+// they appear in the export, matched case-insensitively.
+type Mapping struct {
+	Projects    map[string]string `json:"projects,omitempty"`
+	Statuses    map[string]string `json:"statuses,omitempty"`
+	Types       map[string]string `json:"types,omitempty"`
+	Priorities  map[string]string `json:"priorities,omitempty"`
+	Links       map[string]string `json:"links,omitempty"`
+	Resolutions map[string]string `json:"resolutions,omitempty"`
+}
+
+// Report tells how Jira names were mapped and what could not be carried
+// over.
+type Report struct {
+	Projects    []ProjectReport   `json:"projects"`
+	Statuses    map[string]string `json:"statuses"`
+	Types       map[string]string `json:"types"`
+	Priorities  map[string]string `json:"priorities"`
+	Links       map[string]string `json:"links"`
+	Resolutions map[string]string `json:"resolutions"`
+	Warnings    []string          `json:"warnings,omitempty"`
+}
+
+type ProjectReport struct {
+	JiraKey  string `json:"jira_key"`
+	Key      string `json:"key"`
+	Issues   int    `json:"issues"`
+	Comments int    `json:"comments"`
+	Links    int    `json:"links"`
+	// IssueIDs maps Jira keys to the issue IDs used in the archive.
+	IssueIDs map[string]int `json:"issue_ids"`
+}
+
+var (
+	typeNames = map[string]string{
+		"task":     logic.TypeTask,
+		"bug":      logic.TypeBug,
+		"story":    logic.TypeStory,
+		"epic":     logic.TypeEpic,
+		"sub-task": logic.TypeSubtask,
+		"subtask":  logic.TypeSubtask,
+	}
+	priorityNames = map[string]string{
+		"highest":  logic.PriorityHighest,
+		"blocker":  logic.PriorityHighest,
+		"critical": logic.PriorityHighest,
+		"high":     logic.PriorityHigh,
+		"major":    logic.PriorityHigh,
+		"medium":   logic.PriorityMedium,
+		"low":      logic.PriorityLow,
+		"minor":    logic.PriorityLow,
+		"lowest":   logic.PriorityLowest,
+		"trivial":  logic.PriorityLowest,
+	}
+	linkNames = map[string]string{
+		"blocks":    logic.LinkBlocks,
+		"relates":   logic.LinkRelates,
+		"duplicate": logic.LinkDuplicates,
+		"cloners":   logic.LinkClones,
+	}
+	resolutionNames = map[string]string{
+		"fixed":            logic.ResolutionFixed,
+		"done":             logic.ResolutionDone,
+		"won't do":         logic.ResolutionWontDo,
+		"won't fix":        logic.ResolutionWontDo,
+		"duplicate":        logic.ResolutionDuplicate,
+		"cannot reproduce": logic.ResolutionCannotReproduce,
+	}
+	categoryNames = map[string]string{
+		"new":           logic.CategoryTodo,
+		"indeterminate": logic.CategoryInProgress,
+		"done":          logic.CategoryDone,
+	}
+)
+
+// Convert builds one archive per Jira project. Statuses become the
+// project workflow with free transitions, since exports do not carry
+// the Jira workflow itself. Unknown types, priorities and resolutions
+// fall back to TASK, MEDIUM and DONE, links to other projects are
+// dropped; both are reported as warnings.
+func Convert(issues []Issue, m Mapping, now time.Time) ([]archive.Archive, Report) {
+	c := converter{
+		m: m,
+		rep: Report{
+			Statuses:    map[string]string{},
+			Types:       map[string]string{},
+			Priorities:  map[string]string{},
+			Links:       map[string]string{},
+			Resolutions: map[string]string{},
+		},
+	}
+
+	var order []string
+	byProject := map[string][]Issue{}
+	for _, i := range issues {
+		if _, ok := byProject[i.ProjectKey]; !ok {
+			order = append(order, i.ProjectKey)
+		}
+		byProject[i.ProjectKey] = append(byProject[i.ProjectKey], i)
+	}
+
+	archives := make([]archive.Archive, 0, len(order))
+	for _, key := range order {
+		a, pr := c.project(key, byProject[key], now)
+		archives = append(archives, a)
+		c.rep.Projects = append(c.rep.Projects, pr)
+	}
+
+	return archives, c.rep
+}
+
+type converter struct {
+	m   Mapping
+	rep Report
+}
+
+func (c *converter) warn(format string, args ...any) {
+	c.rep.Warnings = append(c.rep.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (c *converter) project(jiraKey string, issues []Issue, now time.Time) (archive.Archive, ProjectReport) {
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		return cmp.Compare(keyNumber(a.Key), keyNumber(b.Key))
+	})
+
+	key := lookup(c.m.Projects, jiraKey, jiraKey)
+	name := jiraKey
+	for _, i := range issues {
+		if i.ProjectName != "" {
+			name = i.ProjectName
+			break
+		}
+	}
+
+	pr := ProjectReport{JiraKey: jiraKey, Key: key, IssueIDs: make(map[string]int, len(issues))}
+	used := make(map[int]bool, len(issues))
+	next := 0
+	for _, i := range issues {
+		id := keyNumber(i.Key)
+		if id <= 0 || used[id] {
+			id = 0
+		}
+		if id > next {
+			next = id
+		}
+		if id != 0 {
+			used[id] = true
+			pr.IssueIDs[i.Key] = id
+		}
+	}
+	for _, i := range issues {
+		if _, ok := pr.IssueIDs[i.Key]; !ok {
+			next++
+			pr.IssueIDs[i.Key] = next
+		}
+	}
+
+	a := archive.Archive{
+		Format:     archive.Format,
+		Version:    archive.Version,
+		ExportedAt: now,
+		Project:    archive.Project{Key: key, Name: name},
+		Workflow:   &archive.Workflow{},
+		Sprints:    []archive.Sprint{},
+	}
+
+	categories := map[string]string{}
+	var statuses []string
+	addStatus := func(status, category string) {
+		if _, ok := categories[status]; !ok {
+			categories[status] = category
+			statuses = append(statuses, status)
+		}
+	}
+	for _, i := range issues {
+		addStatus(c.status(i.Status, i.StatusCategory))
+	}
+	for n, i := range issues {
+		status := c.statusName(i.Status)
+
+		ai := archive.Issue{
+			ID:       pr.IssueIDs[i.Key],
+			Title:    strings.TrimSpace(i.Summary),
+			Type:     c.issueType(i),
+			Priority: c.priority(i),
+			Status:   status,
+			Rank:     n + 1,
+			Assignee: strings.TrimSpace(i.Assignee),
+		}
+		ai.CreatedAt = i.Created
+		ai.History = c.history(i, categories, addStatus)
+		if categories[status] == logic.CategoryDone {
+			ai.Resolution = c.resolution(i)
+			ai.ResolvedAt = i.Resolved
+		} else if i.Resolution != "" {
+			c.warn("%s: resolution %q dropped, status %s is not done", i.Key, i.Resolution, status)
+		}
+		if ai.Title == "" {
+			ai.Title = i.Key
+			c.warn("%s: empty summary, using the issue key as title", i.Key)
+		}
+		for _, l := range i.Labels {
+			if logic.ValidLabel(l) {
+				ai.Labels = append(ai.Labels, l)
+			} else {
+				c.warn("%s: label %q dropped", i.Key, l)
+			}
+		}
+		if i.ParentKey != "" {
+			if id, ok := pr.IssueIDs[i.ParentKey]; ok && i.ParentKey != i.Key {
+				ai.ParentID = id
+			} else {
+				c.warn("%s: parent %s is not in the export of project %s, dropped", i.Key, i.ParentKey, jiraKey)
+			}
+		}
+		for _, cm := range i.Comments {
+			if strings.TrimSpace(cm.Body) == "" {
+				continue
+			}
+			author := strings.TrimSpace(cm.Author)
+			if author == "" {
+				author = "jira"
+			}
+			created := cm.Created
+			if created.IsZero() {
+				created = now
+				c.warn("%s: comment by %s has no readable date, dated at import time", i.Key, author)
+			}
+			ai.Comments = append(ai.Comments, archive.Comment{Author: author, Body: cm.Body, CreatedAt: created})
+			pr.Comments++
+		}
+		a.Issues = append(a.Issues, ai)
+
+		for _, l := range i.Links {
+			to, ok := pr.IssueIDs[l.To]
+			if !ok || l.To == i.Key {
+				c.warn("%s: link %q to %s dropped, the target is not in project %s", i.Key, l.Type, l.To, jiraKey)
+				continue
+			}
+			link := archive.Link{Type: c.linkType(l.Type), FromID: ai.ID, ToID: to}
+			if !slices.Contains(a.Links, link) {
+				a.Links = append(a.Links, link)
+			}
+		}
+	}
+	pr.Issues = len(a.Issues)
+	pr.Links = len(a.Links)
+
+	// TODO statuses first so that the workflow's initial status is one a
+	// new issue would start in.
+	rank := map[string]int{logic.CategoryTodo: 0, logic.CategoryInProgress: 1, logic.CategoryDone: 2}
+	slices.SortStableFunc(statuses, func(x, y string) int {
+		return cmp.Compare(rank[categories[x]], rank[categories[y]])
+	})
+	for _, s := range statuses {
+		a.Workflow.Statuses = append(a.Workflow.Statuses, archive.WorkflowStatus{Name: s, Category: categories[s]})
+	}
+	if len(statuses) == 0 || categories[statuses[0]] != logic.CategoryTodo {
+		a.Workflow.Statuses = slices.Insert(a.Workflow.Statuses, 0, archive.WorkflowStatus{Name: logic.StatusOpen, Category: logic.CategoryTodo})
+	}
+
+	return a, pr
+}
+
+func (c *converter) status(jiraName, jiraCategory string) (string, string) {
+	name := c.statusName(jiraName)
+	category, ok := categoryNames[strings.ToLower(jiraCategory)]
+	if !ok {
+		category = guessCategory(name)
+	}
+	c.rep.Statuses[jiraName] = name + " (" + category + ")"
+
+	return name, category
+}
+
+// history maps the status changes of an issue and starts them with the
+// status the issue was created in. Statuses seen only in the history get
+// a guessed category and are added to the workflow through add.
+func (c *converter) history(i Issue, categories map[string]string, add func(status, category string)) []archive.StatusChange {
+	status := func(jiraName string) string {
+		name := c.statusName(jiraName)
+		if _, ok := categories[name]; !ok {
+			add(name, guessCategory(name))
+		}
+		if _, ok := c.rep.Statuses[jiraName]; !ok {
+			c.rep.Statuses[jiraName] = name + " (" + categories[name] + ")"
+		}
+		return name
+	}
+
+	var res []archive.StatusChange
+	for _, h := range i.History {
+		if h.At.IsZero() {
+			c.warn("%s: status change to %q has no readable date, dropped", i.Key, h.To)
+			continue
+		}
+
+		from, to := status(h.From), status(h.To)
+		if len(res) == 0 {
+			created := i.Created
+			if created.IsZero() || created.After(h.At) {
+				created = h.At
+			}
+			res = append(res, archive.StatusChange{To: from, At: created})
+		}
+		res = append(res, archive.StatusChange{From: from, To: to, At: h.At})
+	}
+
+	return res
+}
+
+func (c *converter) statusName(jiraName string) string {
+	name := lookup(c.m.Statuses, jiraName, StatusName(jiraName))
+	if name == "" {
+		name = logic.StatusOpen
+	}
+
+	return name
+}
+
+func (c *converter) issueType(i Issue) string {
+	t, ok := mapped(c.m.Types, typeNames, i.Type)
+	switch {
+	case ok:
+	case i.Subtask:
+		t = logic.TypeSubtask
+	default:
+		t = logic.TypeTask
+		c.warn("%s: unknown issue type %q, imported as %s", i.Key, i.Type, t)
+	}
+	c.rep.Types[i.Type] = t
+
+	return t
+}
+
+func (c *converter) priority(i Issue) string {
+	if i.Priority == "" {
+		return logic.PriorityMedium
+	}
+
+	p, ok := mapped(c.m.Priorities, priorityNames, i.Priority)
+	if !ok {
+		p = logic.PriorityMedium
+		c.warn("%s: unknown priority %q, imported as %s", i.Key, i.Priority, p)
+	}
+	c.rep.Priorities[i.Priority] = p
+
+	return p
+}
+
+// resolution maps the resolution of an issue in a DONE status. Jira
+// allows done issues without one; they are resolved as DONE.
+func (c *converter) resolution(i Issue) string {
+	if i.Resolution == "" {
+		return logic.ResolutionDone
+	}
+
+	r, ok := mapped(c.m.Resolutions, resolutionNames, i.Resolution)
+	if !ok {
+		r = logic.ResolutionDone
+		c.warn("%s: unknown resolution %q, imported as %s", i.Key, i.Resolution, r)
+	}
+	c.rep.Resolutions[i.Resolution] = r
+
+	return r
+}
+
+func (c *converter) linkType(name string) string {
+	t, ok := mapped(c.m.Links, linkNames, name)
+	if !ok {
+		t = logic.LinkRelates
+	}
+	c.rep.Links[name] = t
+
+	return t
+}
+
+// mapped looks name up in the user mapping first, then in the built-in
+// table.
+func mapped(user, builtin map[string]string, name string) (string, bool) {
+	if v := lookup(user, name, ""); v != "" {
+		return v, true
+	}
+	v, ok := builtin[strings.ToLower(strings.TrimSpace(name))]
+
+	return v, ok
+}
+
+func lookup(m map[string]string, name, def string) string {
+	for k, v := range m {
+		if strings.EqualFold(k, strings.TrimSpace(name)) {
+			return v
+		}
+	}
+
+	return def
+}
+
+// StatusName turns a Jira status name into a MiniJira one:
+// "In Review" becomes IN_REVIEW.
+func StatusName(s string) string {
+	var b strings.Builder
+	gap := false
+	for _, r := range strings.TrimSpace(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if gap && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			gap = false
+			b.WriteRune(unicode.ToUpper(r))
+			continue
+		}
+		gap = true
+	}
+
+	return b.String()
+}
+
+// guessCategory is used when the export has no status category, as in
+// older CSV exports.
+func guessCategory(status string) string {
+	switch status {
+	case "DONE", "CLOSED", "RESOLVED", "RELEASED", "CANCELLED", "WONT_DO":
+		return logic.CategoryDone
+	case "OPEN", "TO_DO", "TODO", "BACKLOG", "NEW", "SELECTED_FOR_DEVELOPMENT", "REOPENED":
+		return logic.CategoryTodo
+	}
+
+	return logic.CategoryInProgress
+}
+
+// keyNumber is 12 for PAY-12 and 0 if the key has no number.
+func keyNumber(key string) int {
+	_, num, ok := strings.Cut(key, "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil {
+		return 0
+	}
+
+	return n
+}
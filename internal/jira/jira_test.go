@@ -0,0 +1,166 @@
+package jira
+
+import (
+	"MiniJira/internal/archive"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/store/memory"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func parseFile(t *testing.T, name string, parse func(f *os.File) ([]Issue, error)) []Issue {
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to open fixture: %v", err)
+	}
+	defer f.Close()
+
+	issues, err := parse(f)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return issues
+}
+
+func TestParseJSON_SearchDump(t *testing.T) {
+	issues := parseFile(t, "search.json", func(f *os.File) ([]Issue, error) { return ParseJSON(f) })
+	if len(issues) != 4 {
+		t.Fatalf("expected 4 issues, got %d", len(issues))
+	}
+
+	bug := issues[0]
+	if bug.Key != "PAY-2" || bug.ParentKey != "PAY-1" || bug.Assignee != "Alice Smith" || bug.StatusCategory != "indeterminate" {
+		t.Fatalf("unexpected issue: %+v", bug)
+	}
+	if len(bug.Comments) != 1 || bug.Comments[0].Body != "Reproduced on staging.\nFix is simple." {
+		t.Fatalf("unexpected comments: %+v", bug.Comments)
+	}
+	if !bug.Comments[0].Created.Equal(time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected comment date: %v", bug.Comments[0].Created)
+	}
+	if len(bug.Links) != 2 {
+		t.Fatalf("expected only outward links, got %+v", bug.Links)
+	}
+
+	done := issues[2]
+	if !done.Created.Equal(time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected created date: %v", done.Created)
+	}
+	want := []StatusChange{
+		{From: "To Do", To: "In Progress", At: time.Date(2024, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{From: "In Progress", To: "Done", At: time.Date(2024, 1, 25, 17, 0, 0, 0, time.UTC)},
+	}
+	if !slices.Equal(done.History, want) {
+		t.Fatalf("expected status changes oldest first, got %+v", done.History)
+	}
+}
+
+func TestParseCSV_RepeatedColumns(t *testing.T) {
+	issues := parseFile(t, "issues.csv", func(f *os.File) ([]Issue, error) { return ParseCSV(f) })
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(issues))
+	}
+
+	sub := issues[1]
+	if !slices.Equal(sub.Labels, []string{"backend", "release"}) {
+		t.Fatalf("unexpected labels: %v", sub.Labels)
+	}
+	if sub.ParentKey != "PAY-1" {
+		t.Fatalf("expected parent id resolved to PAY-1, got %q", sub.ParentKey)
+	}
+	if len(sub.Comments) != 1 || sub.Comments[0].Author != "bob" || sub.Comments[0].Created.IsZero() {
+		t.Fatalf("unexpected comments: %+v", sub.Comments)
+	}
+	if len(sub.Links) != 1 || sub.Links[0] != (Link{Type: "duplicate", To: "PAY-3"}) {
+		t.Fatalf("unexpected links: %+v", sub.Links)
+	}
+	if closed := issues[2]; closed.Resolution != "Won't Fix" || !closed.Resolved.Equal(time.Date(2024, 1, 20, 16, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected resolution: %q at %v", closed.Resolution, closed.Resolved)
+	}
+}
+
+func TestParseCSV_MissingColumns(t *testing.T) {
+	_, err := ParseCSV(strings.NewReader("Title,Status\nFix,Open\n"))
+	if !errors.Is(err, ErrInvalidExport) {
+		t.Fatalf("expected ErrInvalidExport, got %v", err)
+	}
+}
+
+func TestConvert_MapsAndReports(t *testing.T) {
+	issues := parseFile(t, "search.json", func(f *os.File) ([]Issue, error) { return ParseJSON(f) })
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	archives, rep := Convert(issues, Mapping{Projects: map[string]string{"OPS": "OPS2"}}, now)
+	if len(archives) != 2 {
+		t.Fatalf("expected one archive per project, got %d", len(archives))
+	}
+
+	pay := archives[0]
+	if pay.Project.Key != "PAY" || len(pay.Issues) != 3 {
+		t.Fatalf("unexpected archive: %+v", pay.Project)
+	}
+	if archives[1].Project.Key != "OPS2" {
+		t.Fatalf("expected the project key mapping applied, got %s", archives[1].Project.Key)
+	}
+
+	var statuses []string
+	for _, s := range pay.Workflow.Statuses {
+		statuses = append(statuses, s.Name+"/"+s.Category)
+	}
+	want := []string{"TO_DO/TODO", "IN_REVIEW/IN_PROGRESS", "IN_PROGRESS/IN_PROGRESS", "DONE/DONE"}
+	if !slices.Equal(statuses, want) {
+		t.Fatalf("expected workflow %v, got %v", want, statuses)
+	}
+
+	bug := pay.Issues[1]
+	if bug.ID != 2 || bug.Type != logic.TypeBug || bug.Priority != logic.PriorityHighest || bug.ParentID != 1 {
+		t.Fatalf("unexpected issue: %+v", bug)
+	}
+	if pay.Issues[2].Type != logic.TypeTask {
+		t.Fatalf("expected unknown type imported as TASK, got %s", pay.Issues[2].Type)
+	}
+	history := pay.Issues[2].History
+	if len(history) != 3 || history[0] != (archive.StatusChange{To: "TO_DO", At: time.Date(2024, 1, 10, 8, 0, 0, 0, time.UTC)}) || history[2].To != "DONE" {
+		t.Fatalf("expected the history to start at creation, got %+v", history)
+	}
+	if done := pay.Issues[2]; done.Resolution != logic.ResolutionFixed || !done.ResolvedAt.Equal(time.Date(2024, 1, 25, 17, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the Jira resolution mapped, got %q at %v", done.Resolution, done.ResolvedAt)
+	}
+	if bug.Resolution != "" {
+		t.Fatalf("expected no resolution outside DONE, got %q", bug.Resolution)
+	}
+	if len(pay.Links) != 1 || pay.Links[0] != (archive.Link{Type: logic.LinkBlocks, FromID: 2, ToID: 3}) {
+		t.Fatalf("unexpected links: %+v", pay.Links)
+	}
+
+	if len(rep.Warnings) != 2 {
+		t.Fatalf("expected warnings for the unknown type and the cross-project link, got %v", rep.Warnings)
+	}
+	if rep.Priorities["Critical"] != logic.PriorityHighest || rep.Resolutions["Fixed"] != logic.ResolutionFixed || rep.Projects[0].IssueIDs["PAY-3"] != 3 {
+		t.Fatalf("unexpected report: %+v", rep)
+	}
+}
+
+func TestConvert_ArchivesImport(t *testing.T) {
+	for _, name := range []string{"search.json", "issues.csv"} {
+		t.Run(name, func(t *testing.T) {
+			parse := func(f *os.File) ([]Issue, error) { return ParseJSON(f) }
+			if strings.HasSuffix(name, ".csv") {
+				parse = func(f *os.File) ([]Issue, error) { return ParseCSV(f) }
+			}
+			archives, _ := Convert(parseFile(t, name, parse), Mapping{}, time.Now())
+
+			store := memory.NewStore()
+			for _, a := range archives {
+				if _, err := archive.Import(store, a, archive.Options{}); err != nil {
+					t.Fatalf("expected the converted archive to import, got %v", err)
+				}
+			}
+		})
+	}
+}
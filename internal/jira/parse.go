@@ -0,0 +1,361 @@
+package jira
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ParseJSON reads the output of the Jira REST search API: an object with
+// an "issues" array, or a JSON array of such pages. Comment bodies may be
+// plain text or Atlassian Document Format. Status history is read from the
+// changelog when the search expanded it.
+func ParseJSON(r io.Reader) ([]Issue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var pages []searchPage
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &pages)
+	} else {
+		pages = make([]searchPage, 1)
+		err = json.Unmarshal(data, &pages[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+	}
+
+	var issues []Issue
+	for _, p := range pages {
+		for _, ji := range p.Issues {
+			if ji.Key == "" {
+				return nil, fmt.Errorf("%w: issue without key", ErrInvalidExport)
+			}
+			issues = append(issues, ji.issue())
+		}
+	}
+
+	return issues, nil
+}
+
+type searchPage struct {
+	Issues []jsonIssue `json:"issues"`
+}
+
+type named struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type jsonIssue struct {
+	ID     string `json:"id"`
+	Key    string `json:"key"`
+	Fields struct {
+		Summary   string `json:"summary"`
+		Created   string `json:"created"`
+		IssueType struct {
+			Name    string `json:"name"`
+			Subtask bool   `json:"subtask"`
+		} `json:"issuetype"`
+		Status struct {
+			Name           string `json:"name"`
+			StatusCategory named  `json:"statusCategory"`
+		} `json:"status"`
+		Resolution     *named   `json:"resolution"`
+		ResolutionDate string   `json:"resolutiondate"`
+		Priority       *named   `json:"priority"`
+		Assignee       *named   `json:"assignee"`
+		Labels         []string `json:"labels"`
+		Parent         *named   `json:"parent"`
+		Project        named    `json:"project"`
+		Comment        struct {
+			Comments []struct {
+				Author  named           `json:"author"`
+				Body    json.RawMessage `json:"body"`
+				Created string          `json:"created"`
+			} `json:"comments"`
+		} `json:"comment"`
+		IssueLinks []struct {
+			Type         named  `json:"type"`
+			OutwardIssue *named `json:"outwardIssue"`
+		} `json:"issuelinks"`
+	} `json:"fields"`
+	// Changelog is present when the search was run with expand=changelog.
+	Changelog struct {
+		Histories []struct {
+			Created string `json:"created"`
+			Items   []struct {
+				Field      string `json:"field"`
+				FromString string `json:"fromString"`
+				ToString   string `json:"toString"`
+			} `json:"items"`
+		} `json:"histories"`
+	} `json:"changelog"`
+}
+
+func (ji jsonIssue) issue() Issue {
+	f := ji.Fields
+	i := Issue{
+		Key:            ji.Key,
+		ID:             ji.ID,
+		ProjectKey:     f.Project.Key,
+		ProjectName:    f.Project.Name,
+		Summary:        f.Summary,
+		Type:           f.IssueType.Name,
+		Subtask:        f.IssueType.Subtask,
+		Status:         f.Status.Name,
+		StatusCategory: f.Status.StatusCategory.Key,
+		Labels:         f.Labels,
+	}
+	if i.ProjectKey == "" {
+		i.ProjectKey = projectOf(ji.Key)
+	}
+	i.Created, _ = parseTime(f.Created)
+	if f.Priority != nil {
+		i.Priority = f.Priority.Name
+	}
+	if f.Resolution != nil {
+		i.Resolution = f.Resolution.Name
+		i.Resolved, _ = parseTime(f.ResolutionDate)
+	}
+	if f.Assignee != nil {
+		i.Assignee = f.Assignee.DisplayName
+	}
+	if f.Parent != nil {
+		i.ParentKey = f.Parent.Key
+	}
+	for _, c := range f.Comment.Comments {
+		created, _ := parseTime(c.Created)
+		i.Comments = append(i.Comments, Comment{Author: c.Author.DisplayName, Body: commentBody(c.Body), Created: created})
+	}
+	for _, l := range f.IssueLinks {
+		if l.OutwardIssue != nil {
+			i.Links = append(i.Links, Link{Type: l.Type.Name, To: l.OutwardIssue.Key})
+		}
+	}
+	for _, h := range ji.Changelog.Histories {
+		at, _ := parseTime(h.Created)
+		for _, item := range h.Items {
+			if item.Field == "status" {
+				i.History = append(i.History, StatusChange{From: item.FromString, To: item.ToString, At: at})
+			}
+		}
+	}
+	// Jira Cloud lists the changelog newest first.
+	slices.SortStableFunc(i.History, func(a, b StatusChange) int {
+		return a.At.Compare(b.At)
+	})
+
+	return i
+}
+
+// commentBody returns a plain-text body: either the string itself or the
+// text of an Atlassian Document Format tree, one line per block.
+func commentBody(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+
+	var doc adfNode
+	if json.Unmarshal(raw, &doc) != nil {
+		return ""
+	}
+	var b strings.Builder
+	doc.text(&b)
+
+	return strings.TrimSpace(b.String())
+}
+
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+func (n adfNode) text(b *strings.Builder) {
+	switch n.Type {
+	case "text":
+		b.WriteString(n.Text)
+	case "hardBreak":
+		b.WriteByte('\n')
+	}
+	for _, c := range n.Content {
+		c.text(b)
+	}
+	switch n.Type {
+	case "paragraph", "heading", "listItem", "codeBlock", "blockquote":
+		b.WriteByte('\n')
+	}
+}
+
+// ParseCSV reads the "Export > CSV (all fields)" file. Jira repeats the
+// Labels, Comment and issue link columns once per value; comments are
+// "date;author;body". The CSV has no status history.
+func ParseCSV(r io.Reader) ([]Issue, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading header: %v", ErrInvalidExport, err)
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	cols := map[string][]int{}
+	var linkCols []string
+	for n, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if cols[h] == nil && strings.HasPrefix(h, "outward issue link (") {
+			linkCols = append(linkCols, h)
+		}
+		cols[h] = append(cols[h], n)
+	}
+	if cols["issue key"] == nil || cols["summary"] == nil {
+		return nil, fmt.Errorf("%w: the header must have Issue key and Summary columns", ErrInvalidExport)
+	}
+
+	var issues []Issue
+	keyByID := map[string]string{}
+	parentIDs := map[int]string{}
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExport, err)
+		}
+
+		get := func(col string) string {
+			for _, n := range cols[col] {
+				if n < len(rec) && strings.TrimSpace(rec[n]) != "" {
+					return strings.TrimSpace(rec[n])
+				}
+			}
+			return ""
+		}
+		all := func(col string) []string {
+			var vs []string
+			for _, n := range cols[col] {
+				if n < len(rec) && strings.TrimSpace(rec[n]) != "" {
+					vs = append(vs, strings.TrimSpace(rec[n]))
+				}
+			}
+			return vs
+		}
+
+		i := Issue{
+			Key:            get("issue key"),
+			ID:             get("issue id"),
+			ProjectKey:     get("project key"),
+			ProjectName:    get("project name"),
+			Summary:        get("summary"),
+			Type:           get("issue type"),
+			Status:         get("status"),
+			StatusCategory: csvCategory(get("status category")),
+			Priority:       get("priority"),
+			Assignee:       get("assignee"),
+			Labels:         all("labels"),
+			Resolution:     get("resolution"),
+		}
+		if i.Key == "" {
+			continue
+		}
+		if i.ProjectKey == "" {
+			i.ProjectKey = projectOf(i.Key)
+		}
+		if i.ID != "" {
+			keyByID[i.ID] = i.Key
+		}
+		i.Created, _ = parseTime(get("created"))
+		i.Resolved, _ = parseTime(get("resolved"))
+		if p := get("parent key"); p != "" {
+			i.ParentKey = p
+		} else if p := cmp.Or(get("parent id"), get("parent")); p != "" {
+			parentIDs[len(issues)] = p
+		}
+		for _, c := range all("comment") {
+			i.Comments = append(i.Comments, csvComment(c))
+		}
+		for _, col := range linkCols {
+			name := strings.TrimSuffix(strings.TrimPrefix(col, "outward issue link ("), ")")
+			for _, to := range all(col) {
+				i.Links = append(i.Links, Link{Type: name, To: to})
+			}
+		}
+		issues = append(issues, i)
+	}
+
+	// Parents are given by Jira issue id, which may refer to a later row;
+	// some exports put the key there instead.
+	for n, p := range parentIDs {
+		if key, ok := keyByID[p]; ok {
+			issues[n].ParentKey = key
+		} else if strings.Contains(p, "-") {
+			issues[n].ParentKey = p
+		}
+	}
+
+	return issues, nil
+}
+
+// csvCategory maps the display names used in CSV to the REST keys.
+func csvCategory(s string) string {
+	switch strings.ToLower(s) {
+	case "to do":
+		return "new"
+	case "in progress":
+		return "indeterminate"
+	}
+
+	return strings.ToLower(s)
+}
+
+func csvComment(raw string) Comment {
+	parts := strings.SplitN(raw, ";", 3)
+	if len(parts) < 3 {
+		return Comment{Body: raw}
+	}
+
+	created, ok := parseTime(parts[0])
+	if !ok {
+		return Comment{Body: raw}
+	}
+
+	return Comment{Author: parts[1], Body: strings.TrimSpace(parts[2]), Created: created}
+}
+
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339,
+	"02/Jan/06 3:04 PM",
+	"02/Jan/06 15:04",
+	"2006-01-02 15:04",
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, strings.TrimSpace(s)); err == nil {
+			return t.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func projectOf(key string) string {
+	p, _, _ := strings.Cut(key, "-")
+	return p
+}
@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	BulkTransition   = "transition"
+	BulkAssign       = "assign"
+	BulkLabel        = "label"
+	BulkMoveToSprint = "move_to_sprint"
+
+	MaxBulkItems = 500
+)
+
+// BulkAction is one change applied to every selected issue. Only the
+// fields of the chosen Action are used.
+type BulkAction struct {
+	Action       string
+	ToStatus     string
+	Resolution   string
+	Assignee     string
+	AddLabels    []string
+	RemoveLabels []string
+	SprintID     int
+}
+
+// BulkResult is the outcome for one issue; Err is nil on success.
+type BulkResult struct {
+	IssueID int
+	Issue   Issue
+	Before  Issue
+	Err     error
+}
+
+// ValidateBulk checks the action and the target list before anything is
+// applied, so a malformed request fails as a whole.
+func ValidateBulk(a BulkAction, issueIDs []int) error {
+	var fields []FieldError
+
+	switch a.Action {
+	case BulkTransition:
+		if strings.TrimSpace(a.ToStatus) == "" {
+			fields = append(fields, required("to_status"))
+		}
+	case BulkAssign:
+	case BulkLabel:
+		if len(a.AddLabels) == 0 && len(a.RemoveLabels) == 0 {
+			fields = append(fields, FieldError{Field: "add_labels", Code: FieldRequired, Message: "add_labels or remove_labels must not be empty"})
+		}
+		fields = append(fields, checkLabels("add_labels", a.AddLabels)...)
+		fields = append(fields, checkLabels("remove_labels", a.RemoveLabels)...)
+	case BulkMoveToSprint:
+		if a.SprintID < 0 {
+			fields = append(fields, FieldError{Field: "sprint_id", Code: FieldInvalid, Message: "must be a sprint id or 0"})
+		}
+	case "":
+		fields = append(fields, required("action"))
+	default:
+		fields = append(fields, FieldError{Field: "action", Code: FieldInvalid, Message: "must be one of transition, assign, label, move_to_sprint"})
+	}
+
+	if len(issueIDs) == 0 {
+		fields = append(fields, FieldError{Field: "issue_ids", Code: FieldRequired, Message: "no issues selected"})
+	}
+	if len(issueIDs) > MaxBulkItems {
+		fields = append(fields, FieldError{Field: "issue_ids", Code: FieldInvalid, Message: "must select at most 500 issues"})
+	}
+
+	return collect(ErrInvalidBulk, fields)
+}
+
+// ApplyBulk applies a to one issue.
+func ApplyBulk(store Store, issueID int, a BulkAction, at time.Time) BulkResult {
+	res := BulkResult{IssueID: issueID}
+	res.Before, _ = store.GetIssueByID(issueID)
+
+	switch a.Action {
+	case BulkTransition:
+		res.Issue, res.Err = TransitionIssueWith(store, issueID, IssueTransition{ToStatus: a.ToStatus, Resolution: a.Resolution}, at)
+	case BulkAssign:
+		res.Issue, res.Err = UpdateIssue(store, issueID, IssuePatch{Assignee: &a.Assignee})
+	case BulkLabel:
+		res.Issue, res.Err = UpdateIssue(store, issueID, IssuePatch{AddLabels: a.AddLabels, RemoveLabels: a.RemoveLabels})
+	case BulkMoveToSprint:
+		res.Issue, res.Err = UpdateIssue(store, issueID, IssuePatch{SprintID: &a.SprintID})
+	}
+
+	return res
+}
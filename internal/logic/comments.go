@@ -0,0 +1,83 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+func AddComment(store Store, issueID int, author, body string, at time.Time) (Comment, error) {
+	author = strings.TrimSpace(author)
+	body = strings.TrimSpace(body)
+
+	var fields []FieldError
+	if issueID <= 0 {
+		fields = append(fields, positive("issue_id"))
+	}
+	if author == "" {
+		fields = append(fields, required("author"))
+	}
+	if body == "" {
+		fields = append(fields, required("body"))
+	}
+	if err := collect(ErrInvalidComment, fields); err != nil {
+		return Comment{}, err
+	}
+
+	if _, ok := store.GetIssueByID(issueID); !ok {
+		return Comment{}, ErrIssueNotFound
+	}
+
+	return store.CreateComment(Comment{IssueID: issueID, Author: author, Body: body, CreatedAt: at}), nil
+}
+
+func ListComments(store Store, issueID int) ([]Comment, error) {
+	if _, err := GetIssue(store, issueID); err != nil {
+		return nil, err
+	}
+
+	return store.ListCommentsByIssueID(issueID), nil
+}
+
+// LinkIssues links two issues. Links may cross projects.
+func LinkIssues(store Store, linkType string, fromID, toID int) (IssueLink, error) {
+	var fields []FieldError
+	if !slices.Contains(LinkTypes, linkType) {
+		fields = append(fields, FieldError{Field: "type", Code: FieldInvalid, Message: "must be one of " + strings.Join(LinkTypes, ", ")})
+	}
+	if fromID <= 0 {
+		fields = append(fields, positive("from_id"))
+	}
+	if toID <= 0 {
+		fields = append(fields, positive("to_id"))
+	}
+	if fromID > 0 && fromID == toID {
+		fields = append(fields, FieldError{Field: "to_id", Code: FieldInvalid, Message: "must differ from from_id"})
+	}
+	if err := collect(ErrInvalidLink, fields); err != nil {
+		return IssueLink{}, err
+	}
+
+	if _, ok := store.GetIssueByID(fromID); !ok {
+		return IssueLink{}, ErrIssueNotFound
+	}
+	if _, ok := store.GetIssueByID(toID); !ok {
+		return IssueLink{}, ErrIssueNotFound
+	}
+
+	for _, l := range store.ListLinksByIssueID(fromID) {
+		if l.Type == linkType && l.FromID == fromID && l.ToID == toID {
+			return l, nil
+		}
+	}
+
+	return store.CreateLink(IssueLink{Type: linkType, FromID: fromID, ToID: toID}), nil
+}
+
+func ListLinks(store Store, issueID int) ([]IssueLink, error) {
+	if _, err := GetIssue(store, issueID); err != nil {
+		return nil, err
+	}
+
+	return store.ListLinksByIssueID(issueID), nil
+}
@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CreateComponent adds a component to a project. Names are unique within
+// a project; lead may be empty.
+func CreateComponent(store Store, projectKey, name, description, lead string) (Component, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Component{}, NewValidationError(ErrInvalidComponent, required("name"))
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Component{}, err
+	}
+	if err := checkComponentName(store, p.Key, 0, name); err != nil {
+		return Component{}, err
+	}
+
+	return store.CreateComponent(Component{
+		ProjectKey:  p.Key,
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		Lead:        strings.TrimSpace(lead),
+	}), nil
+}
+
+func GetComponent(store Store, id int) (Component, error) {
+	if id <= 0 {
+		return Component{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	c, ok := store.GetComponentByID(id)
+	if !ok {
+		return Component{}, ErrComponentNotFound
+	}
+
+	return c, nil
+}
+
+func ListComponents(store Store, projectKey string) ([]Component, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListComponentsByProjectKey(p.Key), nil
+}
+
+// UpdateComponent changes a component. A new lead applies to issues
+// created afterwards only.
+func UpdateComponent(store Store, id int, patch ComponentPatch) (Component, error) {
+	if patch.Name != nil && strings.TrimSpace(*patch.Name) == "" {
+		return Component{}, NewValidationError(ErrInvalidComponent, required("name"))
+	}
+
+	c, err := GetComponent(store, id)
+	if err != nil {
+		return Component{}, err
+	}
+
+	if patch.Name != nil {
+		name := strings.TrimSpace(*patch.Name)
+		if err := checkComponentName(store, c.ProjectKey, c.ID, name); err != nil {
+			return Component{}, err
+		}
+		c.Name = name
+	}
+	if patch.Description != nil {
+		c.Description = strings.TrimSpace(*patch.Description)
+	}
+	if patch.Lead != nil {
+		c.Lead = strings.TrimSpace(*patch.Lead)
+	}
+
+	updated, ok := store.UpdateComponent(c)
+	if !ok {
+		return Component{}, ErrComponentNotFound
+	}
+
+	return updated, nil
+}
+
+// componentLead returns the lead of the first component that has one.
+func componentLead(store Store, ids []int) string {
+	for _, id := range ids {
+		if c, ok := store.GetComponentByID(id); ok && c.Lead != "" {
+			return c.Lead
+		}
+	}
+
+	return ""
+}
+
+// checkComponents rejects components of other projects.
+func checkComponents(store Store, projectKey string, ids []int) error {
+	for _, id := range ids {
+		c, ok := store.GetComponentByID(id)
+		if !ok {
+			return ErrComponentNotFound
+		}
+		if c.ProjectKey != projectKey {
+			return NewValidationError(ErrInvalidComponent, FieldError{
+				Field:   "component_ids",
+				Code:    FieldInvalid,
+				Message: "must be components of the same project",
+			})
+		}
+	}
+
+	return nil
+}
+
+func checkComponentName(store Store, projectKey string, id int, name string) error {
+	if slices.ContainsFunc(store.ListComponentsByProjectKey(projectKey), func(c Component) bool {
+		return c.ID != id && strings.EqualFold(c.Name, name)
+	}) {
+		return fmt.Errorf("%w: %s in %s", ErrComponentExists, name, projectKey)
+	}
+
+	return nil
+}
+
+func checkIDs(field string, ids []int) []FieldError {
+	for _, id := range ids {
+		if id <= 0 {
+			return []FieldError{{Field: field, Code: FieldInvalid, Message: "ids must be positive integers"}}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,387 @@
+package logic
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCustomFieldKeyLen bounds field keys, which appear in URLs.
+	MaxCustomFieldKeyLen = 40
+	MaxCustomTextLen     = 1000
+)
+
+// CreateCustomField adds a custom field to a project. Keys are lowercase
+// letters, digits and underscores starting with a letter, unique within
+// the project. The ID and ProjectKey of f are ignored.
+func CreateCustomField(store Store, projectKey string, f CustomField) (CustomField, error) {
+	if err := ValidateCustomField(&f); err != nil {
+		return CustomField{}, err
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return CustomField{}, err
+	}
+	if slices.ContainsFunc(store.ListCustomFieldsByProjectKey(p.Key), func(c CustomField) bool {
+		return c.Key == f.Key
+	}) {
+		return CustomField{}, fmt.Errorf("%w: %s in %s", ErrCustomFieldExists, f.Key, p.Key)
+	}
+
+	f.ID = 0
+	f.ProjectKey = p.Key
+
+	return store.CreateCustomField(f), nil
+}
+
+// ValidateCustomField trims the definition f and checks its key, name,
+// type and options. It does not look for other fields with the same key.
+func ValidateCustomField(f *CustomField) error {
+	f.Key = strings.TrimSpace(f.Key)
+	f.Name = strings.TrimSpace(f.Name)
+	f.Options = trimOptions(f.Options)
+
+	var fields []FieldError
+	if !validCustomFieldKey(f.Key) {
+		fields = append(fields, FieldError{
+			Field:   "key",
+			Code:    FieldInvalid,
+			Message: fmt.Sprintf("must be 1 to %d lowercase letters, digits and underscores, starting with a letter", MaxCustomFieldKeyLen),
+		})
+	}
+	if f.Name == "" {
+		fields = append(fields, required("name"))
+	}
+	if !slices.Contains(CustomFieldTypes, f.Type) {
+		fields = append(fields, FieldError{Field: "type", Code: FieldInvalid, Message: "must be one of " + strings.Join(CustomFieldTypes, ", ")})
+	} else {
+		fields = append(fields, checkOptions(f.Type, f.Options)...)
+	}
+
+	return collect(ErrInvalidCustomField, fields)
+}
+
+func GetCustomField(store Store, id int) (CustomField, error) {
+	if id <= 0 {
+		return CustomField{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	f, ok := store.GetCustomFieldByID(id)
+	if !ok {
+		return CustomField{}, ErrCustomFieldNotFound
+	}
+
+	return f, nil
+}
+
+func ListCustomFields(store Store, projectKey string) ([]CustomField, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListCustomFieldsByProjectKey(p.Key), nil
+}
+
+// UpdateCustomField changes a custom field. Making a field required or
+// dropping options leaves existing values alone; they are checked again
+// only when an issue changes the field.
+func UpdateCustomField(store Store, id int, patch CustomFieldPatch) (CustomField, error) {
+	if patch.Name != nil && strings.TrimSpace(*patch.Name) == "" {
+		return CustomField{}, NewValidationError(ErrInvalidCustomField, required("name"))
+	}
+
+	f, err := GetCustomField(store, id)
+	if err != nil {
+		return CustomField{}, err
+	}
+
+	if patch.Options != nil {
+		options := trimOptions(*patch.Options)
+		if err := collect(ErrInvalidCustomField, checkOptions(f.Type, options)); err != nil {
+			return CustomField{}, err
+		}
+		f.Options = options
+	}
+	if patch.Name != nil {
+		f.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Required != nil {
+		f.Required = *patch.Required
+	}
+
+	updated, ok := store.UpdateCustomField(f)
+	if !ok {
+		return CustomField{}, ErrCustomFieldNotFound
+	}
+
+	return updated, nil
+}
+
+// DeleteCustomField removes a custom field and its values from every issue
+// of the project. Run it in a transaction to keep the writes atomic; the
+// issues that lost a value are returned.
+func DeleteCustomField(store Store, id int) ([]Issue, error) {
+	f, err := GetCustomField(store, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var cleared []Issue
+	for _, i := range store.ListIssuesByProjectKey(f.ProjectKey) {
+		if _, ok := i.CustomFields[f.Key]; !ok {
+			continue
+		}
+		i.CustomFields = maps.Clone(i.CustomFields)
+		delete(i.CustomFields, f.Key)
+		if len(i.CustomFields) == 0 {
+			i.CustomFields = nil
+		}
+		updated, ok := store.UpdateIssue(i)
+		if !ok {
+			return nil, ErrIssueNotFound
+		}
+		cleared = append(cleared, updated)
+	}
+
+	if !store.DeleteCustomField(f.ID) {
+		return nil, ErrCustomFieldNotFound
+	}
+
+	return cleared, nil
+}
+
+// customValues applies changes to the custom field values of an issue and
+// returns the new values; current is not modified. Each value is checked
+// against the type of its field and normalized.
+func customValues(store Store, projectKey string, current, changes map[string][]string) (map[string][]string, []FieldError) {
+	return applyCustomValues(customFieldsByKey(store, projectKey), current, changes)
+}
+
+// ValidateCustomValues checks the custom field values of a new issue
+// against the fields defs the way issue creation does: unknown fields,
+// invalid values and left out required fields are errors. The values are
+// returned normalized.
+func ValidateCustomValues(defs []CustomField, values map[string][]string) (map[string][]string, error) {
+	byKey := make(map[string]CustomField, len(defs))
+	for _, f := range defs {
+		byKey[f.Key] = f
+	}
+
+	res, fields := applyCustomValues(byKey, nil, values)
+	for _, f := range defs {
+		if _, ok := values[f.Key]; f.Required && !ok {
+			fields = append(fields, required("custom_fields."+f.Key))
+		}
+	}
+	if err := collect(ErrInvalidIssue, fields); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func applyCustomValues(defs map[string]CustomField, current, changes map[string][]string) (map[string][]string, []FieldError) {
+	values := maps.Clone(current)
+	if values == nil {
+		values = make(map[string][]string)
+	}
+
+	var fields []FieldError
+	for _, key := range slices.Sorted(maps.Keys(changes)) {
+		field := "custom_fields." + key
+		f, ok := defs[key]
+		if !ok {
+			fields = append(fields, FieldError{Field: field, Code: FieldInvalid, Message: "is not a custom field of the project"})
+			continue
+		}
+
+		v, msg := normalizeCustomValue(f, changes[key])
+		switch {
+		case msg != "":
+			fields = append(fields, FieldError{Field: field, Code: FieldInvalid, Message: msg})
+		case len(v) == 0 && f.Required:
+			fields = append(fields, required(field))
+		case len(v) == 0:
+			delete(values, key)
+		default:
+			values[key] = v
+		}
+	}
+	if len(values) == 0 {
+		values = nil
+	}
+
+	return values, fields
+}
+
+// missingCustomFields reports the required fields of a project that values
+// leaves out.
+func missingCustomFields(store Store, projectKey string, values map[string][]string) []FieldError {
+	var fields []FieldError
+	for _, f := range store.ListCustomFieldsByProjectKey(projectKey) {
+		if _, ok := values[f.Key]; f.Required && !ok {
+			fields = append(fields, required("custom_fields."+f.Key))
+		}
+	}
+
+	return fields
+}
+
+// customFilters normalizes custom field filters the way values are
+// stored, so that 3.0 finds 3.
+func customFilters(store Store, projectKey string, filters map[string]string) (map[string]string, error) {
+	if len(filters) == 0 {
+		return filters, nil
+	}
+
+	defs := customFieldsByKey(store, projectKey)
+	res := make(map[string]string, len(filters))
+	var fields []FieldError
+	for _, key := range slices.Sorted(maps.Keys(filters)) {
+		f, ok := defs[key]
+		if !ok {
+			fields = append(fields, FieldError{Field: "cf." + key, Code: FieldInvalid, Message: "is not a custom field of the project"})
+			continue
+		}
+
+		// A multi_select filter matches one option, like a select.
+		if f.Type == CustomMultiSelect {
+			f.Type = CustomSelect
+		}
+		v, msg := normalizeCustomValue(f, []string{filters[key]})
+		if msg == "" && len(v) == 0 {
+			msg = "must not be empty"
+		}
+		if msg != "" {
+			fields = append(fields, FieldError{Field: "cf." + key, Code: FieldInvalid, Message: msg})
+			continue
+		}
+		res[key] = v[0]
+	}
+	if err := collect(ErrInvalidCustomField, fields); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// normalizeCustomValue checks raw against the type of f. Empty strings are
+// dropped, so an empty result clears the field. A non-empty message means
+// the value is invalid.
+func normalizeCustomValue(f CustomField, raw []string) ([]string, string) {
+	var values []string
+	for _, v := range raw {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil, ""
+	}
+	if len(values) > 1 && f.Type != CustomMultiSelect {
+		return nil, "must be a single value"
+	}
+
+	v := values[0]
+	switch f.Type {
+	case CustomText:
+		if utf8.RuneCountInString(v) > MaxCustomTextLen {
+			return nil, fmt.Sprintf("must be at most %d characters", MaxCustomTextLen)
+		}
+	case CustomNumber:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || math.IsInf(n, 0) || math.IsNaN(n) {
+			return nil, "must be a number"
+		}
+		v = strconv.FormatFloat(n, 'f', -1, 64)
+	case CustomDate:
+		d, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return nil, "must be a date as YYYY-MM-DD"
+		}
+		v = d.Format(time.DateOnly)
+	case CustomUser:
+		if strings.ContainsFunc(v, unicode.IsSpace) {
+			return nil, "must be a user name without spaces"
+		}
+	case CustomSelect:
+		if !slices.Contains(f.Options, v) {
+			return nil, "must be one of " + strings.Join(f.Options, ", ")
+		}
+	case CustomMultiSelect:
+		for _, o := range values {
+			if !slices.Contains(f.Options, o) {
+				return nil, "must be among " + strings.Join(f.Options, ", ")
+			}
+		}
+		// Keep the order of the options, without repeats.
+		return slices.DeleteFunc(slices.Clone(f.Options), func(o string) bool {
+			return !slices.Contains(values, o)
+		}), ""
+	}
+
+	return []string{v}, ""
+}
+
+func checkOptions(typ string, options []string) []FieldError {
+	if typ != CustomSelect && typ != CustomMultiSelect {
+		if len(options) > 0 {
+			return []FieldError{{Field: "options", Code: FieldInvalid, Message: "only select and multi_select fields have options"}}
+		}
+		return nil
+	}
+
+	if len(options) == 0 {
+		return []FieldError{required("options")}
+	}
+	for i, o := range options {
+		if o == "" || slices.Contains(options[:i], o) {
+			return []FieldError{{Field: "options", Code: FieldInvalid, Message: "options must be non-empty and unique"}}
+		}
+	}
+
+	return nil
+}
+
+func trimOptions(options []string) []string {
+	res := make([]string, len(options))
+	for i, o := range options {
+		res[i] = strings.TrimSpace(o)
+	}
+	if len(res) == 0 {
+		return nil
+	}
+
+	return res
+}
+
+func customFieldsByKey(store Store, projectKey string) map[string]CustomField {
+	defs := make(map[string]CustomField)
+	for _, f := range store.ListCustomFieldsByProjectKey(projectKey) {
+		defs[f.Key] = f
+	}
+
+	return defs
+}
+
+func validCustomFieldKey(key string) bool {
+	if key == "" || len(key) > MaxCustomFieldKeyLen || key[0] < 'a' || key[0] > 'z' {
+		return false
+	}
+	for _, r := range key {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -1,11 +1,103 @@
 package logic
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var ErrInvalidProject = errors.New("invalid project")
 var ErrProjectKeyExists = errors.New("project key already exists")
 var ErrInvalidIssue = errors.New("invalid issue")
 var ErrProjectNotFound = errors.New("project not found")
 var ErrInvalidTransition = errors.New("invalid transition")
+var ErrTransitionDenied = errors.New("transition denied")
 var ErrIssueNotFound = errors.New("issue not found")
 var ErrInvalidID = errors.New("invalid id")
+var ErrInvalidRank = errors.New("invalid rank")
+var ErrInvalidSprint = errors.New("invalid sprint")
+var ErrSprintNotFound = errors.New("sprint not found")
+var ErrInvalidSprintState = errors.New("invalid sprint state")
+var ErrNoActiveSprint = errors.New("no active sprint")
+var ErrInvalidComponent = errors.New("invalid component")
+var ErrComponentNotFound = errors.New("component not found")
+var ErrComponentExists = errors.New("component already exists")
+var ErrInvalidCustomField = errors.New("invalid custom field")
+var ErrCustomFieldNotFound = errors.New("custom field not found")
+var ErrCustomFieldExists = errors.New("custom field already exists")
+var ErrInvalidRule = errors.New("invalid rule")
+var ErrRuleNotFound = errors.New("rule not found")
+var ErrInvalidSLA = errors.New("invalid sla policy")
+var ErrSLANotFound = errors.New("sla policy not found")
+var ErrInvalidVersion = errors.New("invalid version")
+var ErrVersionNotFound = errors.New("version not found")
+var ErrVersionExists = errors.New("version already exists")
+var ErrInvalidVersionState = errors.New("invalid version state")
+var ErrUnfinishedIssues = errors.New("version has unfinished issues")
+var ErrInvalidBulk = errors.New("invalid bulk operation")
+var ErrRolledBack = errors.New("rolled back")
+var ErrInvalidWorkflow = errors.New("invalid workflow")
+var ErrInvalidComment = errors.New("invalid comment")
+var ErrInvalidLink = errors.New("invalid link")
+var ErrInvalidWorklog = errors.New("invalid worklog")
+var ErrWorklogNotFound = errors.New("worklog not found")
+var ErrInvalidReport = errors.New("invalid report")
+
+const (
+	FieldRequired = "required"
+	FieldInvalid  = "invalid"
+)
+
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError carries the field-level failures behind one of the
+// sentinel errors above; errors.Is still matches the sentinel.
+type ValidationError struct {
+	Err    error
+	Fields []FieldError
+}
+
+func NewValidationError(err error, fields ...FieldError) *ValidationError {
+	return &ValidationError{Err: err, Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Err.Error()
+	}
+
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Field + ": " + f.Message
+	}
+
+	return e.Err.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func required(field string) FieldError {
+	return FieldError{Field: field, Code: FieldRequired, Message: "must not be empty"}
+}
+
+func positive(field string) FieldError {
+	return FieldError{Field: field, Code: FieldInvalid, Message: "must be a positive integer"}
+}
+
+func notNegative(field string) FieldError {
+	return FieldError{Field: field, Code: FieldInvalid, Message: "must not be negative"}
+}
+
+// collect returns a ValidationError for err if any field failed, else nil.
+func collect(err error, fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return NewValidationError(err, fields...)
+}
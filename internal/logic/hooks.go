@@ -0,0 +1,142 @@
+package logic
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// Transition is what hooks see of a move in progress. Issue is the issue
+// as it was before the move.
+type Transition struct {
+	Issue    Issue
+	From, To string
+	// Actor is who moves the issue, such as automation; empty when
+	// unknown, as it is for API requests.
+	Actor      string
+	Resolution string
+	At         time.Time
+}
+
+// TransitionCondition decides whether a transition is available at all,
+// typically from who asks. A refusal wraps ErrTransitionDenied.
+type TransitionCondition interface {
+	Allow(t Transition) error
+}
+
+// TransitionValidator checks the input of a transition. A failure is
+// usually a ValidationError.
+type TransitionValidator interface {
+	Validate(t Transition) error
+}
+
+// PostFunction completes a transition that passed its conditions and
+// validators by changing the issue before it is saved.
+type PostFunction interface {
+	Apply(t Transition, issue *Issue)
+}
+
+type ConditionFunc func(t Transition) error
+
+func (f ConditionFunc) Allow(t Transition) error { return f(t) }
+
+type ValidatorFunc func(t Transition) error
+
+func (f ValidatorFunc) Validate(t Transition) error { return f(t) }
+
+type PostFunctionFunc func(t Transition, issue *Issue)
+
+func (f PostFunctionFunc) Apply(t Transition, issue *Issue) { f(t, issue) }
+
+// Built-in hooks, by the names workflows refer to them with. There is no
+// built-in condition: the API has no authenticated user to check, and the
+// resolution and resolved_at are handled by TransitionIssueWith itself.
+const (
+	HookAssigneeRequired = "assignee_required"
+	HookClearAssignee    = "clear_assignee"
+)
+
+var (
+	conditions = map[string]TransitionCondition{}
+	validators = map[string]TransitionValidator{
+		HookAssigneeRequired: ValidatorFunc(func(t Transition) error {
+			if t.Issue.Assignee == "" {
+				return NewValidationError(ErrInvalidIssue, required("assignee"))
+			}
+			return nil
+		}),
+	}
+	postFunctions = map[string]PostFunction{
+		HookClearAssignee: PostFunctionFunc(func(_ Transition, issue *Issue) {
+			issue.Assignee = ""
+		}),
+	}
+)
+
+// RegisterCondition, RegisterValidator and RegisterPostFunction add hooks
+// that workflows can name next to the built-in ones, or replace them. Call
+// them from init functions; the registries are not safe for concurrent
+// writes.
+func RegisterCondition(name string, c TransitionCondition) { conditions[name] = c }
+
+func RegisterValidator(name string, v TransitionValidator) { validators[name] = v }
+
+func RegisterPostFunction(name string, f PostFunction) { postFunctions[name] = f }
+
+// TransitionHooks lists the registered hook names of each kind, sorted.
+func TransitionHooks() (conditionNames, validatorNames, postFunctionNames []string) {
+	return slices.Sorted(maps.Keys(conditions)), slices.Sorted(maps.Keys(validators)), slices.Sorted(maps.Keys(postFunctions))
+}
+
+// allowTransition evaluates the conditions of wt for t. A hook that is no
+// longer registered fails the transition rather than being skipped.
+func allowTransition(wt WorkflowTransition, t Transition) error {
+	if fields := checkHooks(wt); len(fields) > 0 {
+		return collect(ErrInvalidWorkflow, fields)
+	}
+
+	for _, name := range wt.Conditions {
+		if err := conditions[name].Allow(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// completeTransition evaluates the validators of wt for t and, when all
+// pass, applies the post functions in order to issue.
+func completeTransition(wt WorkflowTransition, t Transition, issue *Issue) error {
+	for _, name := range wt.Validators {
+		if err := validators[name].Validate(t); err != nil {
+			return err
+		}
+	}
+	for _, name := range wt.PostFunctions {
+		postFunctions[name].Apply(t, issue)
+	}
+
+	return nil
+}
+
+func checkHooks(wt WorkflowTransition) []FieldError {
+	var fields []FieldError
+	for _, kind := range []struct {
+		field string
+		names []string
+		known func(string) bool
+	}{
+		{"transitions.conditions", wt.Conditions, func(n string) bool { _, ok := conditions[n]; return ok }},
+		{"transitions.validators", wt.Validators, func(n string) bool { _, ok := validators[n]; return ok }},
+		{"transitions.post_functions", wt.PostFunctions, func(n string) bool { _, ok := postFunctions[n]; return ok }},
+	} {
+		for _, n := range kind.names {
+			if !kind.known(n) {
+				fields = append(fields, FieldError{Field: kind.field, Code: FieldInvalid, Message: fmt.Sprintf("unknown hook %q in %s -> %s", n, wt.From, wt.To)})
+			}
+		}
+	}
+
+	return fields
+}
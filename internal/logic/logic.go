@@ -1,15 +1,26 @@
 package logic
 
 import (
+	"slices"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 )
 
 func CreateProject(store ProjectStore, key, name string) (Project, error) {
 	key = strings.TrimSpace(key)
 	name = strings.TrimSpace(name)
 
-	if key == "" || name == "" {
-		return Project{}, ErrInvalidProject
+	var fields []FieldError
+	if key == "" {
+		fields = append(fields, required("key"))
+	}
+	if name == "" {
+		fields = append(fields, required("name"))
+	}
+	if err := collect(ErrInvalidProject, fields); err != nil {
+		return Project{}, err
 	}
 
 	_, ok := store.GetByKey(key)
@@ -21,51 +32,385 @@ func CreateProject(store ProjectStore, key, name string) (Project, error) {
 	return created, nil
 }
 
-type ProjectIssueStore interface {
-	ProjectStore
-	IssueStore
+func GetProject(store ProjectStore, key string) (Project, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return Project{}, NewValidationError(ErrInvalidProject, required("key"))
+	}
+
+	p, ok := store.GetByKey(key)
+	if !ok {
+		return Project{}, ErrProjectNotFound
+	}
+
+	return p, nil
 }
 
-func CreateIssue(store ProjectIssueStore, projectKey, title string) (Issue, error) {
+// CreateIssue creates an issue with only a title. It fails if the project
+// has required custom fields.
+func CreateIssue(store Store, projectKey, title string, at time.Time) (Issue, error) {
+	return createIssue(store, projectKey, title, nil, at)
+}
+
+// createIssue is the one way issues are created; values are the custom
+// fields the issue will get, checked here only for required ones.
+func createIssue(store Store, projectKey, title string, values map[string][]string, at time.Time) (Issue, error) {
 	projectKey = strings.TrimSpace(projectKey)
 	title = strings.TrimSpace(title)
 
-	if projectKey == "" || title == "" {
-		return Issue{}, ErrInvalidIssue
+	var fields []FieldError
+	if projectKey == "" {
+		fields = append(fields, required("project_key"))
+	}
+	if title == "" {
+		fields = append(fields, required("title"))
+	}
+	if err := collect(ErrInvalidIssue, fields); err != nil {
+		return Issue{}, err
 	}
 
 	_, ok := store.GetByKey(projectKey)
 	if !ok {
 		return Issue{}, ErrProjectNotFound
 	}
+	if err := collect(ErrInvalidIssue, missingCustomFields(store, projectKey, values)); err != nil {
+		return Issue{}, err
+	}
 
 	issue := Issue{
 		ProjectKey: projectKey,
 		Title:      title,
-		Status:     StatusOpen,
+		Type:       TypeTask,
+		Priority:   PriorityMedium,
+		Status:     GetWorkflow(store, projectKey).Initial(),
+		Rank:       store.MaxIssueRank(projectKey) + 1,
+		CreatedAt:  at,
 	}
 
 	created := store.CreateIssue(issue)
+	store.AddStatusChange(StatusChange{IssueID: created.ID, To: created.Status, At: at})
 
 	return created, nil
 }
 
-func TransitionIssue(store IssueStore, issueID int, toStatus string) (Issue, error) {
-	toStatus = strings.TrimSpace(toStatus)
-	if toStatus == "" || issueID <= 0 {
-		return Issue{}, ErrInvalidIssue
+// CreateIssueFrom creates an issue like CreateIssue and then applies the
+// optional fields of in. Run it in a transaction to keep the steps atomic.
+func CreateIssueFrom(store Store, projectKey string, in NewIssue, at time.Time) (Issue, error) {
+	if err := ValidateNewIssue(&in); err != nil {
+		return Issue{}, err
+	}
+
+	created, err := createIssue(store, projectKey, in.Title, in.CustomFields, at)
+	if err != nil {
+		return Issue{}, err
+	}
+
+	patch := IssuePatch{Type: &in.Type, Priority: &in.Priority}
+	if in.Assignee != "" {
+		patch.Assignee = &in.Assignee
+	}
+	if len(in.Labels) > 0 {
+		patch.AddLabels = in.Labels
+	}
+	if in.ParentID != 0 {
+		patch.ParentID = &in.ParentID
+	}
+	if in.StoryPoints != 0 {
+		patch.StoryPoints = &in.StoryPoints
+	}
+	if in.FixVersionID != 0 {
+		patch.FixVersionID = &in.FixVersionID
+	}
+	if len(in.ComponentIDs) > 0 {
+		patch.ComponentIDs = &in.ComponentIDs
+		if in.Assignee == "" {
+			if lead := componentLead(store, in.ComponentIDs); lead != "" {
+				patch.Assignee = &lead
+			}
+		}
+	}
+	if len(in.CustomFields) > 0 {
+		patch.CustomFields = in.CustomFields
+	}
+	if in.OriginalEstimate != 0 {
+		patch.OriginalEstimate = &in.OriginalEstimate
+	}
+	if in.RemainingEstimate != 0 {
+		patch.RemainingEstimate = &in.RemainingEstimate
+	}
+
+	return UpdateIssue(store, created.ID, patch)
+}
+
+// ValidateNewIssue checks the fields of in that need no store and fills in
+// the default type and priority.
+func ValidateNewIssue(in *NewIssue) error {
+	var fields []FieldError
+	if strings.TrimSpace(in.Title) == "" {
+		fields = append(fields, required("title"))
+	}
+	fields = append(fields, checkLabels("labels", in.Labels)...)
+	fields = append(fields, checkTypeAndPriority(&in.Type, &in.Priority)...)
+	if in.ParentID < 0 {
+		fields = append(fields, FieldError{Field: "parent_id", Code: FieldInvalid, Message: "must be an issue id or 0"})
+	}
+	if in.StoryPoints < 0 {
+		fields = append(fields, notNegative("story_points"))
+	}
+	fields = append(fields, checkIDs("component_ids", in.ComponentIDs)...)
+	if in.FixVersionID < 0 {
+		fields = append(fields, FieldError{Field: "fix_version_id", Code: FieldInvalid, Message: "must be a version id or 0"})
+	}
+	if in.OriginalEstimate < 0 {
+		fields = append(fields, notNegative("original_estimate_seconds"))
+	}
+	if in.RemainingEstimate < 0 {
+		fields = append(fields, notNegative("remaining_estimate_seconds"))
+	}
+
+	return collect(ErrInvalidIssue, fields)
+}
+
+// checkTypeAndPriority fills in defaults for empty values and reports
+// unknown ones.
+func checkTypeAndPriority(typ, priority *string) []FieldError {
+	var fields []FieldError
+	if *typ == "" {
+		*typ = TypeTask
+	} else if !slices.Contains(IssueTypes, *typ) {
+		fields = append(fields, FieldError{Field: "type", Code: FieldInvalid, Message: "must be one of " + strings.Join(IssueTypes, ", ")})
+	}
+	if *priority == "" {
+		*priority = PriorityMedium
+	} else if !slices.Contains(Priorities, *priority) {
+		fields = append(fields, FieldError{Field: "priority", Code: FieldInvalid, Message: "must be one of " + strings.Join(Priorities, ", ")})
+	}
+
+	return fields
+}
+
+// TransitionIssue moves an issue to another status and records the change.
+// It carries no resolution, so entering a DONE status needs
+// TransitionIssueWith. Run it in a transaction to keep the two writes
+// atomic.
+func TransitionIssue(store Store, issueID int, toStatus string, at time.Time) (Issue, error) {
+	return TransitionIssueWith(store, issueID, IssueTransition{ToStatus: toStatus}, at)
+}
+
+// TransitionIssueWith is TransitionIssue with the actor and input the
+// hooks of the workflow transition may need. Entering a DONE status from
+// another category needs a resolution and stamps ResolvedAt; leaving the
+// DONE category clears both. The hooks run before any write, so a refused
+// transition changes nothing.
+func TransitionIssueWith(store Store, issueID int, in IssueTransition, at time.Time) (Issue, error) {
+	in.ToStatus = strings.TrimSpace(in.ToStatus)
+	in.Actor = strings.TrimSpace(in.Actor)
+	in.Resolution = strings.TrimSpace(in.Resolution)
+
+	var fields []FieldError
+	if issueID <= 0 {
+		fields = append(fields, positive("issue_id"))
+	}
+	if in.ToStatus == "" {
+		fields = append(fields, required("to_status"))
+	}
+	if in.Resolution != "" && !slices.Contains(Resolutions, in.Resolution) {
+		fields = append(fields, FieldError{Field: "resolution", Code: FieldInvalid, Message: "must be one of " + strings.Join(Resolutions, ", ")})
+	}
+	if err := collect(ErrInvalidIssue, fields); err != nil {
+		return Issue{}, err
 	}
 
 	issue, ok := store.GetIssueByID(issueID)
 	if !ok {
 		return Issue{}, ErrIssueNotFound
 	}
-	ok = isAllowed(issue.Status, toStatus)
+	wf := GetWorkflow(store, issue.ProjectKey)
+	wt, ok := wf.Transition(issue.Status, in.ToStatus)
 	if !ok {
 		return Issue{}, ErrInvalidTransition
 	}
+	entersDone := categoryOf(wf, in.ToStatus) == CategoryDone && categoryOf(wf, issue.Status) != CategoryDone
+	if in.Resolution == "" && entersDone {
+		in.Resolution = in.DefaultResolution
+	}
+
+	t := Transition{Issue: issue, From: issue.Status, To: in.ToStatus, Actor: in.Actor, Resolution: in.Resolution, At: at}
+	if err := allowTransition(wt, t); err != nil {
+		return Issue{}, err
+	}
+
+	next := issue
+	next.Status = in.ToStatus
+	switch {
+	case categoryOf(wf, in.ToStatus) != CategoryDone:
+		next.Resolution = ""
+		next.ResolvedAt = time.Time{}
+	case categoryOf(wf, issue.Status) != CategoryDone:
+		if in.Resolution == "" {
+			return Issue{}, NewValidationError(ErrInvalidIssue, FieldError{
+				Field:   "resolution",
+				Code:    FieldRequired,
+				Message: "is required to enter " + in.ToStatus,
+			})
+		}
+		next.Resolution = in.Resolution
+		next.ResolvedAt = at
+	case in.Resolution != "":
+		// Moving between DONE statuses may correct the resolution.
+		next.Resolution = in.Resolution
+	}
+	if err := completeTransition(wt, t, &next); err != nil {
+		return Issue{}, err
+	}
+
+	updated, ok := store.UpdateIssue(next)
+	if !ok {
+		return Issue{}, ErrIssueNotFound
+	}
+	store.AddStatusChange(StatusChange{IssueID: issue.ID, From: issue.Status, To: in.ToStatus, At: at})
+
+	return updated, nil
+}
+
+func UpdateIssue(store Store, id int, patch IssuePatch) (Issue, error) {
+	if id <= 0 {
+		return Issue{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	var fields []FieldError
+	if patch.Title != nil && strings.TrimSpace(*patch.Title) == "" {
+		fields = append(fields, required("title"))
+	}
+	if patch.Labels != nil {
+		fields = append(fields, checkLabels("labels", *patch.Labels)...)
+	}
+	fields = append(fields, checkLabels("add_labels", patch.AddLabels)...)
+	fields = append(fields, checkLabels("remove_labels", patch.RemoveLabels)...)
+	if patch.SprintID != nil && *patch.SprintID < 0 {
+		fields = append(fields, FieldError{Field: "sprint_id", Code: FieldInvalid, Message: "must be a sprint id or 0"})
+	}
+	if patch.Type != nil && !slices.Contains(IssueTypes, *patch.Type) {
+		fields = append(fields, FieldError{Field: "type", Code: FieldInvalid, Message: "must be one of " + strings.Join(IssueTypes, ", ")})
+	}
+	if patch.Priority != nil && !slices.Contains(Priorities, *patch.Priority) {
+		fields = append(fields, FieldError{Field: "priority", Code: FieldInvalid, Message: "must be one of " + strings.Join(Priorities, ", ")})
+	}
+	if patch.ParentID != nil && (*patch.ParentID < 0 || *patch.ParentID == id) {
+		fields = append(fields, FieldError{Field: "parent_id", Code: FieldInvalid, Message: "must be another issue id or 0"})
+	}
+	if patch.StoryPoints != nil && *patch.StoryPoints < 0 {
+		fields = append(fields, notNegative("story_points"))
+	}
+	if patch.ComponentIDs != nil {
+		fields = append(fields, checkIDs("component_ids", *patch.ComponentIDs)...)
+	}
+	if patch.FixVersionID != nil && *patch.FixVersionID < 0 {
+		fields = append(fields, FieldError{Field: "fix_version_id", Code: FieldInvalid, Message: "must be a version id or 0"})
+	}
+	if patch.OriginalEstimate != nil && *patch.OriginalEstimate < 0 {
+		fields = append(fields, notNegative("original_estimate_seconds"))
+	}
+	if patch.RemainingEstimate != nil && *patch.RemainingEstimate < 0 {
+		fields = append(fields, notNegative("remaining_estimate_seconds"))
+	}
+	if err := collect(ErrInvalidIssue, fields); err != nil {
+		return Issue{}, err
+	}
+
+	issue, ok := store.GetIssueByID(id)
+	if !ok {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	if patch.ParentID != nil && *patch.ParentID != 0 {
+		if err := checkParent(store, issue, *patch.ParentID); err != nil {
+			return Issue{}, err
+		}
+	}
+
+	if patch.SprintID != nil && *patch.SprintID != 0 {
+		sprint, ok := store.GetSprintByID(*patch.SprintID)
+		if !ok {
+			return Issue{}, ErrSprintNotFound
+		}
+		if sprint.ProjectKey != issue.ProjectKey {
+			return Issue{}, NewValidationError(ErrInvalidSprint, FieldError{
+				Field:   "sprint_id",
+				Code:    FieldInvalid,
+				Message: "must be a sprint of the same project",
+			})
+		}
+		if sprint.State == SprintClosed && sprint.ID != issue.SprintID {
+			return Issue{}, NewValidationError(ErrInvalidSprint, FieldError{
+				Field:   "sprint_id",
+				Code:    FieldInvalid,
+				Message: "must not be a closed sprint",
+			})
+		}
+	}
+
+	if patch.ComponentIDs != nil {
+		if err := checkComponents(store, issue.ProjectKey, *patch.ComponentIDs); err != nil {
+			return Issue{}, err
+		}
+	}
+	if patch.CustomFields != nil {
+		values, fields := customValues(store, issue.ProjectKey, issue.CustomFields, patch.CustomFields)
+		if err := collect(ErrInvalidIssue, fields); err != nil {
+			return Issue{}, err
+		}
+		issue.CustomFields = values
+	}
+	if patch.FixVersionID != nil && *patch.FixVersionID != 0 && *patch.FixVersionID != issue.FixVersionID {
+		if err := checkFixVersion(store, issue, *patch.FixVersionID); err != nil {
+			return Issue{}, err
+		}
+	}
+
+	if patch.Title != nil {
+		issue.Title = strings.TrimSpace(*patch.Title)
+	}
+	if patch.Type != nil {
+		issue.Type = *patch.Type
+	}
+	if patch.Priority != nil {
+		issue.Priority = *patch.Priority
+	}
+	if patch.Assignee != nil {
+		issue.Assignee = strings.TrimSpace(*patch.Assignee)
+	}
+	if patch.Labels != nil {
+		issue.Labels = addLabels(nil, *patch.Labels)
+	}
+	issue.Labels = addLabels(issue.Labels, patch.AddLabels)
+	issue.Labels = removeLabels(issue.Labels, patch.RemoveLabels)
+	if patch.SprintID != nil {
+		issue.SprintID = *patch.SprintID
+	}
+	if patch.ParentID != nil {
+		issue.ParentID = *patch.ParentID
+	}
+	if patch.StoryPoints != nil {
+		issue.StoryPoints = *patch.StoryPoints
+	}
+	if patch.OriginalEstimate != nil {
+		issue.OriginalEstimate = *patch.OriginalEstimate
+		if patch.RemainingEstimate == nil && issue.TimeSpent == 0 {
+			issue.RemainingEstimate = issue.OriginalEstimate
+		}
+	}
+	if patch.RemainingEstimate != nil {
+		issue.RemainingEstimate = *patch.RemainingEstimate
+	}
+	if patch.FixVersionID != nil {
+		issue.FixVersionID = *patch.FixVersionID
+	}
+	if patch.ComponentIDs != nil {
+		issue.ComponentIDs = slices.Compact(slices.Sorted(slices.Values(*patch.ComponentIDs)))
+	}
 
-	updated, ok := store.UpdateIssueStatus(issue.ID, toStatus)
+	updated, ok := store.UpdateIssue(issue)
 	if !ok {
 		return Issue{}, ErrIssueNotFound
 	}
@@ -73,20 +418,161 @@ func TransitionIssue(store IssueStore, issueID int, toStatus string) (Issue, err
 	return updated, nil
 }
 
-func isAllowed(status string, toStatus string) bool {
-	if status == StatusOpen && toStatus == StatusInProgress {
-		return true
+// checkParent rejects parents from another project and parent chains that
+// would lead back to the issue.
+func checkParent(store IssueStore, issue Issue, parentID int) error {
+	invalid := func(msg string) error {
+		return NewValidationError(ErrInvalidIssue, FieldError{Field: "parent_id", Code: FieldInvalid, Message: msg})
+	}
+
+	parent, ok := store.GetIssueByID(parentID)
+	if !ok {
+		return invalid("must be an existing issue")
+	}
+	if parent.ProjectKey != issue.ProjectKey {
+		return invalid("must be an issue of the same project")
+	}
+
+	for p := parent; p.ParentID != 0; {
+		if p.ParentID == issue.ID {
+			return invalid("would create a parent cycle")
+		}
+		if p, ok = store.GetIssueByID(p.ParentID); !ok {
+			break
+		}
+	}
+
+	return nil
+}
+
+// ValidLabel reports whether l is non-empty and has no whitespace.
+func ValidLabel(l string) bool {
+	return l != "" && !strings.ContainsFunc(l, unicode.IsSpace)
+}
+
+// checkLabels reports labels that are blank or contain whitespace.
+func checkLabels(field string, labels []string) []FieldError {
+	for _, l := range labels {
+		if !ValidLabel(l) {
+			return []FieldError{{Field: field, Code: FieldInvalid, Message: "labels must be non-empty and contain no spaces"}}
+		}
+	}
+
+	return nil
+}
+
+// addLabels returns a new slice, so the stored issue is never modified
+// in place.
+func addLabels(labels, add []string) []string {
+	res := slices.Clone(labels)
+	for _, l := range add {
+		if !slices.Contains(res, l) {
+			res = append(res, l)
+		}
+	}
+
+	return res
+}
+
+func removeLabels(labels, remove []string) []string {
+	return slices.DeleteFunc(slices.Clone(labels), func(l string) bool {
+		return slices.Contains(remove, l)
+	})
+}
+
+func CreateSprint(store Store, projectKey, name string) (Sprint, error) {
+	projectKey = strings.TrimSpace(projectKey)
+	name = strings.TrimSpace(name)
+
+	var fields []FieldError
+	if projectKey == "" {
+		fields = append(fields, required("project_key"))
+	}
+	if name == "" {
+		fields = append(fields, required("name"))
+	}
+	if err := collect(ErrInvalidSprint, fields); err != nil {
+		return Sprint{}, err
+	}
+
+	_, ok := store.GetByKey(projectKey)
+	if !ok {
+		return Sprint{}, ErrProjectNotFound
+	}
+
+	return store.CreateSprint(Sprint{ProjectKey: projectKey, Name: name, State: SprintFuture}), nil
+}
+
+func ListSprints(store Store, projectKey string) ([]Sprint, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListSprintsByProjectKey(p.Key), nil
+}
+
+// FindIssues returns the issues matching q in board order.
+func FindIssues(store Store, q IssueQuery) ([]Issue, error) {
+	p, err := GetProject(store, q.ProjectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	q.CustomFields, err = customFilters(store, p.Key, q.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Issue
+	for _, i := range store.ListIssuesByProjectKey(p.Key) {
+		if q.matches(i) {
+			res = append(res, i)
+		}
+	}
+	sort.SliceStable(res, func(a, b int) bool {
+		return res[a].Rank < res[b].Rank
+	})
+
+	return res, nil
+}
+
+func (q IssueQuery) matches(i Issue) bool {
+	if q.Status != "" && i.Status != q.Status {
+		return false
+	}
+	if q.Assignee != "" && i.Assignee != q.Assignee {
+		return false
+	}
+	if q.Label != "" && !slices.Contains(i.Labels, q.Label) {
+		return false
+	}
+	if q.SprintID != nil && i.SprintID != *q.SprintID {
+		return false
 	}
-	if status == StatusInProgress && toStatus == StatusDone {
-		return true
+	if q.FixVersionID != nil && i.FixVersionID != *q.FixVersionID {
+		return false
+	}
+	if q.ComponentID != nil {
+		if *q.ComponentID == 0 && len(i.ComponentIDs) > 0 {
+			return false
+		}
+		if *q.ComponentID != 0 && !slices.Contains(i.ComponentIDs, *q.ComponentID) {
+			return false
+		}
+	}
+	for key, v := range q.CustomFields {
+		if !slices.Contains(i.CustomFields[key], v) {
+			return false
+		}
 	}
 
-	return false
+	return true
 }
 
 func GetIssue(store IssueStore, id int) (Issue, error) {
 	if id <= 0 {
-		return Issue{}, ErrInvalidID
+		return Issue{}, NewValidationError(ErrInvalidID, positive("id"))
 	}
 
 	issue, ok := store.GetIssueByID(id)
@@ -96,3 +582,75 @@ func GetIssue(store IssueStore, id int) (Issue, error) {
 
 	return issue, nil
 }
+
+// RankIssue moves the issue right before beforeID within its project board.
+// beforeID == 0 moves the issue to the end. Ranks are renumbered 1..n.
+func RankIssue(store IssueStore, issueID, beforeID int) (Issue, error) {
+	var fields []FieldError
+	if issueID <= 0 {
+		fields = append(fields, positive("issue_id"))
+	}
+	if beforeID < 0 {
+		fields = append(fields, FieldError{Field: "before_id", Code: FieldInvalid, Message: "must be an issue id or 0"})
+	}
+	if issueID > 0 && issueID == beforeID {
+		fields = append(fields, FieldError{Field: "before_id", Code: FieldInvalid, Message: "must differ from issue_id"})
+	}
+	if err := collect(ErrInvalidRank, fields); err != nil {
+		return Issue{}, err
+	}
+
+	issue, ok := store.GetIssueByID(issueID)
+	if !ok {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	board := store.ListIssuesByProjectKey(issue.ProjectKey)
+	sort.SliceStable(board, func(a, b int) bool {
+		return board[a].Rank < board[b].Rank
+	})
+
+	ordered := make([]Issue, 0, len(board))
+	for _, i := range board {
+		if i.ID != issue.ID {
+			ordered = append(ordered, i)
+		}
+	}
+
+	pos := len(ordered)
+	if beforeID != 0 {
+		pos = -1
+		for idx, i := range ordered {
+			if i.ID == beforeID {
+				pos = idx
+				break
+			}
+		}
+		if pos < 0 {
+			return Issue{}, NewValidationError(ErrInvalidRank, FieldError{
+				Field:   "before_id",
+				Code:    FieldInvalid,
+				Message: "must be an issue of the same project",
+			})
+		}
+	}
+
+	ordered = append(ordered[:pos], append([]Issue{issue}, ordered[pos:]...)...)
+
+	moved := issue
+	for idx, i := range ordered {
+		rank := idx + 1
+		if i.Rank == rank {
+			continue
+		}
+		updated, ok := store.UpdateIssueRank(i.ID, rank)
+		if !ok {
+			return Issue{}, ErrIssueNotFound
+		}
+		if updated.ID == issue.ID {
+			moved = updated
+		}
+	}
+
+	return moved, nil
+}
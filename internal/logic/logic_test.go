@@ -2,7 +2,10 @@ package logic
 
 import (
 	"errors"
+	"maps"
+	"slices"
 	"testing"
+	"time"
 )
 
 type projectStore struct {
@@ -131,9 +134,25 @@ func TestCreateProject_DuplicateKey(t *testing.T) {
 	}
 }
 
+// testTime is the clock reading passed to logic calls in tests.
+var testTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
 type fakeStore struct {
 	projects      map[string]Project
 	issues        []Issue
+	sprints       []Sprint
+	versions      []Version
+	components    []Component
+	customFields  []CustomField
+	rules         []Rule
+	executions    []RuleExecution
+	slaPolicies   []SLAPolicy
+	slaBreaches   []SLABreach
+	comments      []Comment
+	worklogs      []Worklog
+	links         []IssueLink
+	workflows     map[string]Workflow
+	history       []StatusChange
 	nextProjectID int
 	nextIssueID   int
 }
@@ -168,6 +187,28 @@ func (s *fakeStore) UpdateIssueStatus(id int, newStatus string) (Issue, bool) {
 	return Issue{}, false
 }
 
+func (s *fakeStore) UpdateIssueRank(id int, rank int) (Issue, bool) {
+	for i := range s.issues {
+		if s.issues[i].ID == id {
+			s.issues[i].Rank = rank
+			return s.issues[i], true
+		}
+	}
+
+	return Issue{}, false
+}
+
+func (s *fakeStore) UpdateIssue(issue Issue) (Issue, bool) {
+	for i := range s.issues {
+		if s.issues[i].ID == issue.ID {
+			s.issues[i] = issue
+			return s.issues[i], true
+		}
+	}
+
+	return Issue{}, false
+}
+
 func (s *fakeStore) ListIssuesByProjectKey(projectKey string) []Issue {
 	res := make([]Issue, 0, len(s.issues))
 	for _, i := range s.issues {
@@ -179,6 +220,17 @@ func (s *fakeStore) ListIssuesByProjectKey(projectKey string) []Issue {
 	return res
 }
 
+func (s *fakeStore) MaxIssueRank(projectKey string) int {
+	rank := 0
+	for _, i := range s.issues {
+		if i.ProjectKey == projectKey {
+			rank = max(rank, i.Rank)
+		}
+	}
+
+	return rank
+}
+
 func (s *fakeStore) GetByKey(key string) (Project, bool) {
 	p, ok := s.projects[key]
 	return p, ok
@@ -199,6 +251,406 @@ func (s *fakeStore) List() []Project {
 	return list
 }
 
+func (s *fakeStore) CreateSprint(sp Sprint) Sprint {
+	sp.ID = len(s.sprints) + 1
+	s.sprints = append(s.sprints, sp)
+	return sp
+}
+
+func (s *fakeStore) GetSprintByID(id int) (Sprint, bool) {
+	for _, sp := range s.sprints {
+		if sp.ID == id {
+			return sp, true
+		}
+	}
+
+	return Sprint{}, false
+}
+
+func (s *fakeStore) UpdateSprint(sp Sprint) (Sprint, bool) {
+	for i := range s.sprints {
+		if s.sprints[i].ID == sp.ID {
+			s.sprints[i] = sp
+			return sp, true
+		}
+	}
+
+	return Sprint{}, false
+}
+
+func (s *fakeStore) ListSprintsByProjectKey(projectKey string) []Sprint {
+	var res []Sprint
+	for _, sp := range s.sprints {
+		if sp.ProjectKey == projectKey {
+			res = append(res, sp)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateComponent(c Component) Component {
+	c.ID = len(s.components) + 1
+	s.components = append(s.components, c)
+	return c
+}
+
+func (s *fakeStore) GetComponentByID(id int) (Component, bool) {
+	for _, c := range s.components {
+		if c.ID == id {
+			return c, true
+		}
+	}
+
+	return Component{}, false
+}
+
+func (s *fakeStore) UpdateComponent(c Component) (Component, bool) {
+	for i := range s.components {
+		if s.components[i].ID == c.ID {
+			s.components[i] = c
+			return c, true
+		}
+	}
+
+	return Component{}, false
+}
+
+func (s *fakeStore) ListComponentsByProjectKey(projectKey string) []Component {
+	var res []Component
+	for _, c := range s.components {
+		if c.ProjectKey == projectKey {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateCustomField(f CustomField) CustomField {
+	f.ID = len(s.customFields) + 1
+	s.customFields = append(s.customFields, f)
+	return f
+}
+
+func (s *fakeStore) GetCustomFieldByID(id int) (CustomField, bool) {
+	for _, f := range s.customFields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+
+	return CustomField{}, false
+}
+
+func (s *fakeStore) UpdateCustomField(f CustomField) (CustomField, bool) {
+	for i := range s.customFields {
+		if s.customFields[i].ID == f.ID {
+			s.customFields[i] = f
+			return f, true
+		}
+	}
+
+	return CustomField{}, false
+}
+
+func (s *fakeStore) DeleteCustomField(id int) bool {
+	for i := range s.customFields {
+		if s.customFields[i].ID == id {
+			s.customFields = slices.Delete(s.customFields, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *fakeStore) ListCustomFieldsByProjectKey(projectKey string) []CustomField {
+	var res []CustomField
+	for _, f := range s.customFields {
+		if f.ProjectKey == projectKey {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateRule(r Rule) Rule {
+	r.ID = len(s.rules) + 1
+	s.rules = append(s.rules, r)
+	return r
+}
+
+func (s *fakeStore) GetRuleByID(id int) (Rule, bool) {
+	for _, r := range s.rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+func (s *fakeStore) UpdateRule(r Rule) (Rule, bool) {
+	for i := range s.rules {
+		if s.rules[i].ID == r.ID {
+			s.rules[i] = r
+			return r, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+func (s *fakeStore) DeleteRule(id int) bool {
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			s.rules = slices.Delete(s.rules, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *fakeStore) ListRulesByProjectKey(projectKey string) []Rule {
+	var res []Rule
+	for _, r := range s.rules {
+		if r.ProjectKey == projectKey {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) AddRuleExecution(x RuleExecution) RuleExecution {
+	x.ID = len(s.executions) + 1
+	s.executions = append(s.executions, x)
+	return x
+}
+
+func (s *fakeStore) ListRuleExecutions(projectKey string) []RuleExecution {
+	var res []RuleExecution
+	for _, x := range s.executions {
+		if x.ProjectKey == projectKey {
+			res = append(res, x)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateSLAPolicy(p SLAPolicy) SLAPolicy {
+	p.ID = len(s.slaPolicies) + 1
+	s.slaPolicies = append(s.slaPolicies, p)
+	return p
+}
+
+func (s *fakeStore) GetSLAPolicyByID(id int) (SLAPolicy, bool) {
+	for _, p := range s.slaPolicies {
+		if p.ID == id {
+			return p, true
+		}
+	}
+
+	return SLAPolicy{}, false
+}
+
+func (s *fakeStore) UpdateSLAPolicy(p SLAPolicy) (SLAPolicy, bool) {
+	for i := range s.slaPolicies {
+		if s.slaPolicies[i].ID == p.ID {
+			s.slaPolicies[i] = p
+			return p, true
+		}
+	}
+
+	return SLAPolicy{}, false
+}
+
+func (s *fakeStore) DeleteSLAPolicy(id int) bool {
+	for i := range s.slaPolicies {
+		if s.slaPolicies[i].ID == id {
+			s.slaPolicies = slices.Delete(s.slaPolicies, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *fakeStore) ListSLAPoliciesByProjectKey(projectKey string) []SLAPolicy {
+	var res []SLAPolicy
+	for _, p := range s.slaPolicies {
+		if p.ProjectKey == projectKey {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) AddSLABreach(b SLABreach) bool {
+	for _, r := range s.slaBreaches {
+		if r.PolicyID == b.PolicyID && r.IssueID == b.IssueID {
+			return false
+		}
+	}
+	s.slaBreaches = append(s.slaBreaches, b)
+
+	return true
+}
+
+func (s *fakeStore) CreateVersion(v Version) Version {
+	v.ID = len(s.versions) + 1
+	s.versions = append(s.versions, v)
+	return v
+}
+
+func (s *fakeStore) GetVersionByID(id int) (Version, bool) {
+	for _, v := range s.versions {
+		if v.ID == id {
+			return v, true
+		}
+	}
+
+	return Version{}, false
+}
+
+func (s *fakeStore) UpdateVersion(v Version) (Version, bool) {
+	for i := range s.versions {
+		if s.versions[i].ID == v.ID {
+			s.versions[i] = v
+			return v, true
+		}
+	}
+
+	return Version{}, false
+}
+
+func (s *fakeStore) ListVersionsByProjectKey(projectKey string) []Version {
+	var res []Version
+	for _, v := range s.versions {
+		if v.ProjectKey == projectKey {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateComment(c Comment) Comment {
+	c.ID = len(s.comments) + 1
+	s.comments = append(s.comments, c)
+	return c
+}
+
+func (s *fakeStore) ListCommentsByIssueID(issueID int) []Comment {
+	var res []Comment
+	for _, c := range s.comments {
+		if c.IssueID == issueID {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateWorklog(w Worklog) Worklog {
+	w.ID = len(s.worklogs) + 1
+	s.worklogs = append(s.worklogs, w)
+	return w
+}
+
+func (s *fakeStore) GetWorklogByID(id int) (Worklog, bool) {
+	for _, w := range s.worklogs {
+		if w.ID == id {
+			return w, true
+		}
+	}
+
+	return Worklog{}, false
+}
+
+func (s *fakeStore) UpdateWorklog(w Worklog) (Worklog, bool) {
+	for i := range s.worklogs {
+		if s.worklogs[i].ID == w.ID {
+			s.worklogs[i] = w
+			return w, true
+		}
+	}
+
+	return Worklog{}, false
+}
+
+func (s *fakeStore) DeleteWorklog(id int) bool {
+	for i := range s.worklogs {
+		if s.worklogs[i].ID == id {
+			s.worklogs = slices.Delete(s.worklogs, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *fakeStore) ListWorklogsByIssueID(issueID int) []Worklog {
+	var res []Worklog
+	for _, w := range s.worklogs {
+		if w.IssueID == issueID {
+			res = append(res, w)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) CreateLink(l IssueLink) IssueLink {
+	l.ID = len(s.links) + 1
+	s.links = append(s.links, l)
+	return l
+}
+
+func (s *fakeStore) ListLinksByIssueID(issueID int) []IssueLink {
+	var res []IssueLink
+	for _, l := range s.links {
+		if l.FromID == issueID || l.ToID == issueID {
+			res = append(res, l)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) AddStatusChange(c StatusChange) {
+	s.history = append(s.history, c)
+}
+
+func (s *fakeStore) ListStatusChanges(issueID int) []StatusChange {
+	var res []StatusChange
+	for _, c := range s.history {
+		if c.IssueID == issueID {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (s *fakeStore) GetWorkflow(projectKey string) (Workflow, bool) {
+	w, ok := s.workflows[projectKey]
+	return w, ok
+}
+
+func (s *fakeStore) SaveWorkflow(w Workflow) Workflow {
+	if s.workflows == nil {
+		s.workflows = make(map[string]Workflow)
+	}
+	s.workflows[w.ProjectKey] = w
+	return w
+}
+
 func TestCreateIssue_Success(t *testing.T) {
 	store := &fakeStore{
 		projects: map[string]Project{
@@ -213,7 +665,7 @@ func TestCreateIssue_Success(t *testing.T) {
 		nextIssueID:   1,
 	}
 
-	issue, err := CreateIssue(store, "PAY", "Fix checkout")
+	issue, err := CreateIssue(store, "PAY", "Fix checkout", testTime)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -278,7 +730,7 @@ func TestCreateIssue_InvalidInput(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := CreateIssue(store, tt.projectKey, tt.title)
+			_, err := CreateIssue(store, tt.projectKey, tt.title, testTime)
 
 			if !errors.Is(err, ErrInvalidIssue) {
 				t.Fatalf("expected ErrInvalidIssue, got %v", err)
@@ -295,7 +747,7 @@ func TestCreateIssue_ProjectNotFound(t *testing.T) {
 		nextIssueID:   1,
 	}
 
-	_, err := CreateIssue(store, "PAY", "Fix checkout")
+	_, err := CreateIssue(store, "PAY", "Fix checkout", testTime)
 	if !errors.Is(err, ErrProjectNotFound) {
 		t.Fatalf("expected ErrProjectNotFound, got %v", err)
 	}
@@ -306,6 +758,7 @@ func TestTransitionIssue_Success(t *testing.T) {
 		name       string
 		fromStatus string
 		toStatus   string
+		resolution string
 	}{
 		{
 			name:       "open to in progress",
@@ -316,6 +769,7 @@ func TestTransitionIssue_Success(t *testing.T) {
 			name:       "in progress to done",
 			fromStatus: StatusInProgress,
 			toStatus:   StatusDone,
+			resolution: ResolutionFixed,
 		},
 	}
 
@@ -336,7 +790,7 @@ func TestTransitionIssue_Success(t *testing.T) {
 				nextIssueID: 2,
 			}
 
-			_, err := TransitionIssue(store, 1, tt.toStatus)
+			_, err := TransitionIssueWith(store, 1, IssueTransition{ToStatus: tt.toStatus, Resolution: tt.resolution}, testTime)
 			if err != nil {
 				t.Fatalf("expected no error, got %v", err)
 			}
@@ -368,8 +822,8 @@ func TestTransitionIssue_InvalidTransition(t *testing.T) {
 			toStatus:   StatusInProgress,
 		},
 		{
-			name:       "done to open",
-			fromStatus: StatusDone,
+			name:       "in progress to open",
+			fromStatus: StatusInProgress,
 			toStatus:   StatusOpen,
 		},
 	}
@@ -392,7 +846,7 @@ func TestTransitionIssue_InvalidTransition(t *testing.T) {
 				nextProjectID: 1,
 			}
 
-			_, err := TransitionIssue(store, 1, tt.toStatus)
+			_, err := TransitionIssue(store, 1, tt.toStatus, testTime)
 			if !errors.Is(err, ErrInvalidTransition) {
 				t.Fatalf("expected ErrInvalidTransition, got %v", err)
 			}
@@ -410,7 +864,7 @@ func TestTransitionIssue_IssueNotFound(t *testing.T) {
 		nextProjectID: 2,
 	}
 
-	_, err := TransitionIssue(store, 999, StatusInProgress)
+	_, err := TransitionIssue(store, 999, StatusInProgress, testTime)
 	if !errors.Is(err, ErrIssueNotFound) {
 		t.Fatalf("expected ErrIssueNotFound, got %v", err)
 	}
@@ -462,10 +916,1434 @@ func TestTransitionIssue_InvalidInput(t *testing.T) {
 				nextIssueID:   2,
 			}
 
-			_, err := TransitionIssue(store, tt.issueID, tt.toStatus)
+			_, err := TransitionIssue(store, tt.issueID, tt.toStatus, testTime)
 			if !errors.Is(err, ErrInvalidIssue) {
 				t.Fatalf("expected ErrInvalidIssue, got %v", err)
 			}
 		})
 	}
 }
+
+func TestRankIssue_Success(t *testing.T) {
+	tests := []struct {
+		name     string
+		issueID  int
+		beforeID int
+		want     []int
+	}{
+		{
+			name:     "move to top",
+			issueID:  3,
+			beforeID: 1,
+			want:     []int{3, 1, 2},
+		},
+		{
+			name:     "move to end",
+			issueID:  1,
+			beforeID: 0,
+			want:     []int{2, 3, 1},
+		},
+		{
+			name:     "move to middle",
+			issueID:  1,
+			beforeID: 3,
+			want:     []int{2, 1, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeStore{
+				projects: map[string]Project{
+					"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+				},
+				issues: []Issue{
+					{ID: 1, ProjectKey: "PAY", Title: "One", Status: StatusOpen, Rank: 1},
+					{ID: 2, ProjectKey: "PAY", Title: "Two", Status: StatusOpen, Rank: 2},
+					{ID: 3, ProjectKey: "PAY", Title: "Three", Status: StatusOpen, Rank: 3},
+				},
+				nextIssueID: 4,
+			}
+
+			_, err := RankIssue(store, tt.issueID, tt.beforeID)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for rank, id := range tt.want {
+				issue, _ := store.GetIssueByID(id)
+				if issue.Rank != rank+1 {
+					t.Fatalf("expected issue %d to have rank %d, got %d", id, rank+1, issue.Rank)
+				}
+			}
+		})
+	}
+}
+
+func TestRankIssue_InvalidInput(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+			"OPS": {ID: 2, Key: "OPS", Name: "Operations"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "One", Status: StatusOpen, Rank: 1},
+			{ID: 2, ProjectKey: "OPS", Title: "Two", Status: StatusOpen, Rank: 1},
+		},
+		nextIssueID: 3,
+	}
+
+	tests := []struct {
+		name     string
+		issueID  int
+		beforeID int
+	}{
+		{
+			name:     "zero issue id",
+			issueID:  0,
+			beforeID: 1,
+		},
+		{
+			name:     "before itself",
+			issueID:  1,
+			beforeID: 1,
+		},
+		{
+			name:     "before issue of another project",
+			issueID:  1,
+			beforeID: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RankIssue(store, tt.issueID, tt.beforeID)
+			if !errors.Is(err, ErrInvalidRank) {
+				t.Fatalf("expected ErrInvalidRank, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateProject_ValidationFields(t *testing.T) {
+	store := &projectStore{
+		projects: make(map[string]Project),
+		nextID:   1,
+	}
+
+	_, err := CreateProject(store, " ", "")
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("expected ValidationError, got %v", err)
+	}
+
+	if len(verr.Fields) != 2 {
+		t.Fatalf("expected 2 field errors, got %d", len(verr.Fields))
+	}
+
+	if verr.Fields[0].Field != "key" || verr.Fields[1].Field != "name" {
+		t.Fatalf("expected errors on key and name, got %+v", verr.Fields)
+	}
+
+	for _, f := range verr.Fields {
+		if f.Code != FieldRequired {
+			t.Fatalf("expected code %s, got %s", FieldRequired, f.Code)
+		}
+	}
+}
+
+func TestUpdateIssue_Title(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fix checkout", Status: StatusInProgress, Rank: 1},
+		},
+		nextIssueID: 2,
+	}
+
+	title := "  Fix checkout totals "
+	updated, err := UpdateIssue(store, 1, IssuePatch{Title: &title})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if updated.Title != "Fix checkout totals" {
+		t.Fatalf("expected trimmed title, got %q", updated.Title)
+	}
+
+	if updated.Status != StatusInProgress || updated.Rank != 1 {
+		t.Fatalf("expected other fields unchanged, got %+v", updated)
+	}
+}
+
+func TestUpdateIssue_InvalidInput(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fix checkout", Status: StatusOpen, Rank: 1},
+		},
+		nextIssueID: 2,
+	}
+
+	blank := "   "
+
+	tests := []struct {
+		name  string
+		id    int
+		patch IssuePatch
+		want  error
+	}{
+		{
+			name: "zero id",
+			id:   0,
+			want: ErrInvalidID,
+		},
+		{
+			name:  "blank title",
+			id:    1,
+			patch: IssuePatch{Title: &blank},
+			want:  ErrInvalidIssue,
+		},
+		{
+			name: "missing issue",
+			id:   99,
+			want: ErrIssueNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := UpdateIssue(store, tt.id, tt.patch)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUpdateIssue_LabelsAndSprint(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+			"OPS": {ID: 2, Key: "OPS", Name: "Operations"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fix checkout", Status: StatusOpen, Rank: 1, Labels: []string{"triage", "backend"}},
+		},
+		sprints: []Sprint{
+			{ID: 1, ProjectKey: "PAY", Name: "Sprint 1"},
+			{ID: 2, ProjectKey: "OPS", Name: "Ops 1"},
+		},
+		nextIssueID: 2,
+	}
+
+	sprint := 1
+	updated, err := UpdateIssue(store, 1, IssuePatch{
+		AddLabels:    []string{"backend", "release"},
+		RemoveLabels: []string{"triage"},
+		SprintID:     &sprint,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !slices.Equal(updated.Labels, []string{"backend", "release"}) {
+		t.Fatalf("unexpected labels: %v", updated.Labels)
+	}
+	if updated.SprintID != 1 {
+		t.Fatalf("expected sprint 1, got %d", updated.SprintID)
+	}
+
+	other := 2
+	_, err = UpdateIssue(store, 1, IssuePatch{SprintID: &other})
+	if !errors.Is(err, ErrInvalidSprint) {
+		t.Fatalf("expected ErrInvalidSprint, got %v", err)
+	}
+
+	missing := 9
+	_, err = UpdateIssue(store, 1, IssuePatch{SprintID: &missing})
+	if !errors.Is(err, ErrSprintNotFound) {
+		t.Fatalf("expected ErrSprintNotFound, got %v", err)
+	}
+}
+
+func TestFindIssues_Filters(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Status: StatusOpen, Rank: 2, Assignee: "alice", Labels: []string{"release"}},
+			{ID: 2, ProjectKey: "PAY", Status: StatusDone, Rank: 1, Assignee: "alice"},
+			{ID: 3, ProjectKey: "PAY", Status: StatusOpen, Rank: 3, Labels: []string{"release"}, SprintID: 1},
+		},
+	}
+
+	backlog := 0
+
+	tests := []struct {
+		name  string
+		query IssueQuery
+		want  []int
+	}{
+		{name: "all in rank order", query: IssueQuery{ProjectKey: "PAY"}, want: []int{2, 1, 3}},
+		{name: "by assignee", query: IssueQuery{ProjectKey: "PAY", Assignee: "alice"}, want: []int{2, 1}},
+		{name: "by label and status", query: IssueQuery{ProjectKey: "PAY", Label: "release", Status: StatusOpen}, want: []int{1, 3}},
+		{name: "backlog", query: IssueQuery{ProjectKey: "PAY", SprintID: &backlog}, want: []int{2, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := FindIssues(store, tt.query)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var ids []int
+			for _, i := range issues {
+				ids = append(ids, i.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestValidateBulk(t *testing.T) {
+	tests := []struct {
+		name   string
+		action BulkAction
+		ids    []int
+		ok     bool
+	}{
+		{name: "transition", action: BulkAction{Action: BulkTransition, ToStatus: StatusDone}, ids: []int{1}, ok: true},
+		{name: "unassign", action: BulkAction{Action: BulkAssign}, ids: []int{1}, ok: true},
+		{name: "missing status", action: BulkAction{Action: BulkTransition}, ids: []int{1}},
+		{name: "no labels", action: BulkAction{Action: BulkLabel}, ids: []int{1}},
+		{name: "unknown action", action: BulkAction{Action: "delete"}, ids: []int{1}},
+		{name: "no issues", action: BulkAction{Action: BulkAssign}},
+		{name: "too many", action: BulkAction{Action: BulkAssign}, ids: make([]int, MaxBulkItems+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBulk(tt.action, tt.ids)
+			if tt.ok && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidBulk) {
+				t.Fatalf("expected ErrInvalidBulk, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTransitionIssue_ProjectWorkflow(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		workflows: map[string]Workflow{"PAY": {
+			ProjectKey: "PAY",
+			Statuses: []WorkflowStatus{
+				{Name: "BACKLOG", Category: CategoryTodo},
+				{Name: "IN_REVIEW", Category: CategoryInProgress},
+				{Name: "SHIPPED", Category: CategoryDone},
+			},
+			Transitions: []WorkflowTransition{{From: "BACKLOG", To: "IN_REVIEW"}},
+		}},
+		nextIssueID: 1,
+	}
+
+	issue, err := CreateIssue(store, "PAY", "Fix checkout", testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.Status != "BACKLOG" {
+		t.Fatalf("expected the first TODO status, got %s", issue.Status)
+	}
+
+	_, err = TransitionIssue(store, issue.ID, "SHIPPED", testTime)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("expected ErrInvalidTransition, got %v", err)
+	}
+
+	issue, err = TransitionIssue(store, issue.ID, "IN_REVIEW", testTime)
+	if err != nil || issue.Status != "IN_REVIEW" {
+		t.Fatalf("expected IN_REVIEW, got %v, %v", issue.Status, err)
+	}
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	tests := []struct {
+		name string
+		wf   Workflow
+		ok   bool
+	}{
+		{name: "default", wf: DefaultWorkflow("PAY"), ok: true},
+		{name: "no statuses", wf: Workflow{}},
+		{name: "no todo", wf: Workflow{Statuses: []WorkflowStatus{{Name: "DONE", Category: CategoryDone}}}},
+		{name: "unknown category", wf: Workflow{Statuses: []WorkflowStatus{{Name: "OPEN", Category: "LATER"}}}},
+		{name: "duplicate", wf: Workflow{Statuses: []WorkflowStatus{{Name: "OPEN", Category: CategoryTodo}, {Name: "OPEN", Category: CategoryDone}}}},
+		{
+			name: "unknown transition status",
+			wf: Workflow{
+				Statuses:    []WorkflowStatus{{Name: "OPEN", Category: CategoryTodo}},
+				Transitions: []WorkflowTransition{{From: "OPEN", To: "DONE"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWorkflow(tt.wf)
+			if tt.ok && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tt.ok && !errors.Is(err, ErrInvalidWorkflow) {
+				t.Fatalf("expected ErrInvalidWorkflow, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCreateIssueFrom_Parent(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+			"OPS": {ID: 2, Key: "OPS", Name: "Operations"},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Checkout", Type: TypeEpic, Status: StatusOpen, Rank: 1},
+			{ID: 2, ProjectKey: "OPS", Title: "Alerts", Type: TypeEpic, Status: StatusOpen, Rank: 1},
+		},
+		nextIssueID: 3,
+	}
+
+	child, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "Fix validation", Type: TypeBug, Priority: PriorityHigh, ParentID: 1}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if child.Type != TypeBug || child.Priority != PriorityHigh || child.ParentID != 1 {
+		t.Fatalf("unexpected issue: %+v", child)
+	}
+
+	_, err = CreateIssueFrom(store, "PAY", NewIssue{Title: "Cross project", ParentID: 2}, testTime)
+	if !errors.Is(err, ErrInvalidIssue) {
+		t.Fatalf("expected ErrInvalidIssue for a parent in another project, got %v", err)
+	}
+
+	_, err = CreateIssueFrom(store, "PAY", NewIssue{Title: "Bad type", Type: "IDEA"}, testTime)
+	if !errors.Is(err, ErrInvalidIssue) {
+		t.Fatalf("expected ErrInvalidIssue for an unknown type, got %v", err)
+	}
+
+	parent := child.ID
+	_, err = UpdateIssue(store, 1, IssuePatch{ParentID: &parent})
+	if !errors.Is(err, ErrInvalidIssue) {
+		t.Fatalf("expected ErrInvalidIssue for a parent cycle, got %v", err)
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	store := &fakeStore{
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fix checkout", Status: StatusOpen, Rank: 1},
+			{ID: 2, ProjectKey: "OPS", Title: "Alerts", Status: StatusOpen, Rank: 1},
+		},
+	}
+
+	link, err := LinkIssues(store, LinkBlocks, 1, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	again, err := LinkIssues(store, LinkBlocks, 1, 2)
+	if err != nil || again.ID != link.ID {
+		t.Fatalf("expected the existing link back, got %+v, %v", again, err)
+	}
+
+	links, _ := ListLinks(store, 2)
+	if len(links) != 1 {
+		t.Fatalf("expected the link on the target issue, got %v", links)
+	}
+
+	_, err = LinkIssues(store, "follows", 1, 2)
+	if !errors.Is(err, ErrInvalidLink) {
+		t.Fatalf("expected ErrInvalidLink, got %v", err)
+	}
+
+	_, err = LinkIssues(store, LinkRelates, 1, 9)
+	if !errors.Is(err, ErrIssueNotFound) {
+		t.Fatalf("expected ErrIssueNotFound, got %v", err)
+	}
+}
+
+func TestFlowMetrics(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, n) }
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fast", Status: StatusDone, Rank: 1, CreatedAt: day(0)},
+			{ID: 2, ProjectKey: "PAY", Title: "Slow", Status: StatusDone, Rank: 2, CreatedAt: day(0)},
+			{ID: 3, ProjectKey: "PAY", Title: "Open", Status: StatusOpen, Rank: 3, CreatedAt: day(5)},
+		},
+		history: []StatusChange{
+			{IssueID: 1, To: StatusOpen, At: day(0)},
+			{IssueID: 1, From: StatusOpen, To: StatusInProgress, At: day(1)},
+			{IssueID: 1, From: StatusInProgress, To: StatusDone, At: day(3)},
+			{IssueID: 2, To: StatusOpen, At: day(0)},
+			{IssueID: 2, From: StatusOpen, To: StatusInProgress, At: day(2)},
+			{IssueID: 2, From: StatusInProgress, To: StatusDone, At: day(6)},
+			{IssueID: 3, To: StatusOpen, At: day(5)},
+		},
+	}
+
+	rep, err := FlowMetrics(store, IssueQuery{ProjectKey: "PAY"}, day(0), day(30), day(10))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rep.Issues) != 3 {
+		t.Fatalf("expected 3 issues, got %d", len(rep.Issues))
+	}
+	fast := rep.Issues[0]
+	if fast.InStatus[StatusOpen] != 24*time.Hour || fast.InStatus[StatusInProgress] != 48*time.Hour || fast.CycleTime != 48*time.Hour || fast.LeadTime != 72*time.Hour {
+		t.Fatalf("unexpected times: %+v", fast)
+	}
+	if open := rep.Issues[2]; open.InStatus[StatusOpen] != 5*24*time.Hour || !open.DoneAt.IsZero() {
+		t.Fatalf("expected the open issue counted up to now, got %+v", open)
+	}
+	if rep.CycleTime != (Percentiles{Count: 2, P50: 48 * time.Hour, P85: 96 * time.Hour, P95: 96 * time.Hour}) {
+		t.Fatalf("unexpected cycle time: %+v", rep.CycleTime)
+	}
+
+	rep, _ = FlowMetrics(store, IssueQuery{ProjectKey: "PAY"}, day(4), day(30), day(10))
+	if len(rep.Issues) != 2 || rep.Issues[0].Issue.ID != 2 || rep.Issues[0].InStatus[StatusInProgress] != 48*time.Hour {
+		t.Fatalf("expected issues active after day 4 clipped to the range, got %+v", rep.Issues)
+	}
+	if rep.LeadTime.Count != 1 || rep.LeadTime.P50 != 6*24*time.Hour {
+		t.Fatalf("unexpected lead time: %+v", rep.LeadTime)
+	}
+
+	_, err = FlowMetrics(store, IssueQuery{ProjectKey: "PAY"}, day(4), day(1), day(10))
+	if !errors.Is(err, ErrInvalidReport) {
+		t.Fatalf("expected ErrInvalidReport, got %v", err)
+	}
+}
+
+func TestStartAndCloseSprint(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		sprints: []Sprint{
+			{ID: 1, ProjectKey: "PAY", Name: "Sprint 1", State: SprintFuture},
+			{ID: 2, ProjectKey: "PAY", Name: "Sprint 2", State: SprintFuture},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Done", Status: StatusDone, Rank: 1, SprintID: 1, StoryPoints: 3},
+			{ID: 2, ProjectKey: "PAY", Title: "Open", Status: StatusOpen, Rank: 2, SprintID: 1, StoryPoints: 5},
+		},
+	}
+
+	sp, err := StartSprint(store, 1, time.Time{}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sp.State != SprintActive || sp.CommittedIssues != 2 || sp.CommittedPoints != 8 || !sp.EndsAt.Equal(testTime.Add(DefaultSprintLength)) {
+		t.Fatalf("unexpected started sprint: %+v", sp)
+	}
+
+	_, err = StartSprint(store, 2, time.Time{}, testTime)
+	if !errors.Is(err, ErrInvalidSprintState) {
+		t.Fatalf("expected ErrInvalidSprintState for a second active sprint, got %v", err)
+	}
+
+	sp, moved, err := CloseSprint(store, 1, 2, testTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sp.State != SprintClosed || sp.CompletedIssues != 1 || sp.CompletedPoints != 3 || !slices.Equal(sp.Incomplete, []int{2}) {
+		t.Fatalf("unexpected closed sprint: %+v", sp)
+	}
+	if len(moved) != 1 || moved[0].SprintID != 2 {
+		t.Fatalf("expected the open issue moved to sprint 2, got %+v", moved)
+	}
+
+	_, _, err = CloseSprint(store, 1, 0, testTime)
+	if !errors.Is(err, ErrInvalidSprintState) {
+		t.Fatalf("expected ErrInvalidSprintState for a closed sprint, got %v", err)
+	}
+}
+
+func TestSprintBurndownAndVelocity(t *testing.T) {
+	day := func(n int) time.Time { return time.Date(2026, 10, 5, 9, 0, 0, 0, time.UTC).AddDate(0, 0, n) }
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		sprints: []Sprint{
+			{ID: 1, ProjectKey: "PAY", Name: "Sprint 1", State: SprintClosed, ClosedAt: day(-1), CompletedIssues: 4, CompletedPoints: 10},
+			{ID: 2, ProjectKey: "PAY", Name: "Sprint 2", State: SprintActive, StartedAt: day(0), EndsAt: day(4), CommittedIssues: 2, CommittedPoints: 8},
+		},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Fast", Status: StatusDone, Rank: 1, SprintID: 2, StoryPoints: 3},
+			{ID: 2, ProjectKey: "PAY", Title: "Slow", Status: StatusInProgress, Rank: 2, SprintID: 2, StoryPoints: 5},
+		},
+		history: []StatusChange{
+			{IssueID: 1, To: StatusOpen, At: day(-3)},
+			{IssueID: 1, From: StatusOpen, To: StatusDone, At: day(1)},
+			{IssueID: 2, To: StatusOpen, At: day(-3)},
+			{IssueID: 2, From: StatusOpen, To: StatusInProgress, At: day(2)},
+		},
+	}
+
+	rep, err := SprintBurndown(store, "PAY", 0, UnitPoints, day(2))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if rep.Sprint.ID != 2 || rep.Scope != 8 || len(rep.Days) != 5 {
+		t.Fatalf("unexpected burndown: %+v", rep)
+	}
+
+	var remaining []int
+	for _, d := range rep.Days {
+		if d.Remaining != nil {
+			remaining = append(remaining, *d.Remaining)
+		}
+	}
+	if !slices.Equal(remaining, []int{8, 5, 5}) {
+		t.Fatalf("expected remaining points up to today, got %v", remaining)
+	}
+	if rep.Days[0].Ideal <= rep.Days[1].Ideal || rep.Days[4].Ideal != 0 {
+		t.Fatalf("expected the ideal line to fall to zero, got %+v", rep.Days)
+	}
+
+	_, err = SprintBurndown(store, "PAY", 0, "hours", day(2))
+	if !errors.Is(err, ErrInvalidReport) {
+		t.Fatalf("expected ErrInvalidReport, got %v", err)
+	}
+
+	v, err := SprintVelocity(store, "PAY", 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(v.Sprints) != 1 || v.AveragePoints != 10 {
+		t.Fatalf("unexpected velocity: %+v", v)
+	}
+}
+
+func TestCumulativeFlowReport(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	at := func(day, hour int) time.Time { return time.Date(2026, 9, day, hour, 0, 0, 0, time.UTC) }
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Old", Status: StatusDone, Rank: 1, CreatedAt: at(1, 9)},
+			{ID: 2, ProjectKey: "PAY", Title: "Late night", Status: StatusOpen, Rank: 2, CreatedAt: at(10, 23)},
+			{ID: 3, ProjectKey: "PAY", Title: "Legacy", Status: StatusInProgress, Rank: 3},
+		},
+		history: []StatusChange{
+			{IssueID: 1, To: StatusOpen, At: at(1, 9)},
+			{IssueID: 1, From: StatusOpen, To: StatusInProgress, At: at(5, 9)},
+			{IssueID: 1, From: StatusInProgress, To: StatusDone, At: at(11, 9)},
+			{IssueID: 2, To: StatusOpen, At: at(10, 23)},
+		},
+	}
+
+	from := time.Date(2026, 9, 10, 0, 0, 0, 0, berlin)
+	rep, err := CumulativeFlowReport(store, IssueQuery{ProjectKey: "PAY"}, from, from.AddDate(0, 0, 5), at(12, 12))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(rep.Days) != 3 {
+		t.Fatalf("expected days up to now, got %+v", rep.Days)
+	}
+
+	want := []map[string]int{
+		{StatusOpen: 0, StatusInProgress: 2, StatusDone: 0},
+		{StatusOpen: 1, StatusInProgress: 1, StatusDone: 1},
+		{StatusOpen: 1, StatusInProgress: 1, StatusDone: 1},
+	}
+	for n, d := range rep.Days {
+		if !d.Date.Equal(from.AddDate(0, 0, n)) || !maps.Equal(d.Counts, want[n]) {
+			t.Fatalf("day %d: expected %v, got %s %v", n, want[n], d.Date, d.Counts)
+		}
+	}
+
+	_, err = CumulativeFlowReport(store, IssueQuery{ProjectKey: "PAY"}, from, from, at(12, 12))
+	if !errors.Is(err, ErrInvalidReport) {
+		t.Fatalf("expected ErrInvalidReport, got %v", err)
+	}
+}
+
+func TestWorklogsAdjustRemainingEstimate(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		sprints:  []Sprint{{ID: 1, ProjectKey: "PAY", Name: "Sprint 1"}},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Epic", Type: TypeEpic, Status: StatusOpen, Rank: 1},
+			{ID: 2, ProjectKey: "PAY", Title: "Story", Type: TypeStory, Status: StatusOpen, Rank: 2, ParentID: 1, SprintID: 1},
+			{ID: 3, ProjectKey: "PAY", Title: "Subtask", Type: TypeSubtask, Status: StatusOpen, Rank: 3, ParentID: 2, SprintID: 1},
+		},
+	}
+
+	estimate := 8 * time.Hour
+	issue, err := UpdateIssue(store, 2, IssuePatch{OriginalEstimate: &estimate})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.RemainingEstimate != estimate {
+		t.Fatalf("expected the remaining estimate to follow the original, got %v", issue.RemainingEstimate)
+	}
+
+	w, issue, err := LogWork(store, 2, "alice", 3*time.Hour, time.Time{}, "", testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.TimeSpent != 3*time.Hour || issue.RemainingEstimate != 5*time.Hour || !w.StartedAt.Equal(testTime) {
+		t.Fatalf("unexpected issue after logging work: %+v", issue)
+	}
+
+	spent := 10 * time.Hour
+	_, issue, err = UpdateWorklog(store, w.ID, WorklogPatch{Spent: &spent}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.TimeSpent != spent || issue.RemainingEstimate != 0 {
+		t.Fatalf("expected the remaining estimate to stop at zero, got %+v", issue)
+	}
+
+	if _, _, err := LogWork(store, 3, "bob", 2*time.Hour, time.Time{}, "", testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	epic, err := IssueTimeTracking(store, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if epic.Issues != 3 || epic.TimeSpent != 12*time.Hour || epic.OriginalEstimate != estimate {
+		t.Fatalf("unexpected epic time tracking: %+v", epic)
+	}
+
+	issue, err = DeleteWorklog(store, w.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if issue.TimeSpent != 0 || issue.RemainingEstimate != estimate {
+		t.Fatalf("expected the deleted time given back, got %+v", issue)
+	}
+
+	sprint, err := SprintTimeTracking(store, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sprint.Issues != 2 || sprint.TimeSpent != 2*time.Hour {
+		t.Fatalf("unexpected sprint time tracking: %+v", sprint)
+	}
+
+	_, _, err = LogWork(store, 2, "", 0, time.Time{}, "", testTime)
+	if !errors.Is(err, ErrInvalidWorklog) {
+		t.Fatalf("expected ErrInvalidWorklog, got %v", err)
+	}
+	if _, err := DeleteWorklog(store, w.ID); !errors.Is(err, ErrWorklogNotFound) {
+		t.Fatalf("expected ErrWorklogNotFound, got %v", err)
+	}
+}
+
+func TestWorklogsGiveBackOnlyWhatTheyTook(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		issues:   []Issue{{ID: 1, ProjectKey: "PAY", Title: "Checkout", OriginalEstimate: 4 * time.Hour, RemainingEstimate: time.Hour}},
+	}
+
+	w, issue, err := LogWork(store, 1, "alice", 3*time.Hour, time.Time{}, "", testTime)
+	if err != nil || issue.RemainingEstimate != 0 || w.Reduced != time.Hour {
+		t.Fatalf("expected the remaining hour used up, got %+v, %+v, %v", w, issue, err)
+	}
+
+	tests := []struct {
+		spent time.Duration
+		want  time.Duration
+	}{
+		{2 * time.Hour, 0},
+		{30 * time.Minute, 30 * time.Minute},
+		{90 * time.Minute, 0},
+	}
+	for _, tt := range tests {
+		spent := tt.spent
+		if _, issue, err = UpdateWorklog(store, w.ID, WorklogPatch{Spent: &spent}, testTime); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if issue.RemainingEstimate != tt.want {
+			t.Fatalf("%v logged: expected %v remaining, got %v", tt.spent, tt.want, issue.RemainingEstimate)
+		}
+	}
+
+	if issue, err = DeleteWorklog(store, w.ID); err != nil || issue.RemainingEstimate != time.Hour {
+		t.Fatalf("expected the hour given back, got %+v, %v", issue, err)
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		issues: []Issue{
+			{ID: 1, ProjectKey: "PAY", Title: "Crash on refund", Type: TypeBug, Status: StatusDone, Rank: 2},
+			{ID: 2, ProjectKey: "PAY", Title: "Saved cards", Type: TypeStory, Status: StatusDone, Rank: 1},
+			{ID: 3, ProjectKey: "PAY", Title: "Receipts", Type: TypeStory, Status: StatusInProgress, Rank: 3},
+		},
+	}
+
+	v, err := CreateVersion(store, "PAY", "1.4.0", "", time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	next, err := CreateVersion(store, "PAY", "1.5.0", "", time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := CreateVersion(store, "PAY", "1.4.0", "", time.Time{}); !errors.Is(err, ErrVersionExists) {
+		t.Fatalf("expected ErrVersionExists, got %v", err)
+	}
+
+	for _, id := range []int{1, 2, 3} {
+		if _, err := UpdateIssue(store, id, IssuePatch{FixVersionID: &v.ID}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	notes, err := GetReleaseNotes(store, v.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notes.Groups) != 2 || notes.Groups[0].Type != TypeBug || notes.Groups[1].Type != TypeStory || len(notes.Groups[1].Issues) != 1 {
+		t.Fatalf("unexpected release notes: %+v", notes.Groups)
+	}
+
+	_, _, err = ReleaseVersion(store, v.ID, 0, time.Time{}, testTime)
+	if !errors.Is(err, ErrUnfinishedIssues) {
+		t.Fatalf("expected ErrUnfinishedIssues, got %v", err)
+	}
+
+	released, moved, err := ReleaseVersion(store, v.ID, next.ID, time.Time{}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if released.State != VersionReleased || !released.ReleaseDate.Equal(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected released version: %+v", released)
+	}
+	if len(moved) != 1 || moved[0].ID != 3 || moved[0].FixVersionID != next.ID {
+		t.Fatalf("expected the open issue moved to the next version, got %+v", moved)
+	}
+
+	if _, _, err := ReleaseVersion(store, v.ID, 0, time.Time{}, testTime); !errors.Is(err, ErrInvalidVersionState) {
+		t.Fatalf("expected ErrInvalidVersionState, got %v", err)
+	}
+
+	if _, err := UpdateIssue(store, 3, IssuePatch{FixVersionID: &v.ID}); !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion for an open issue in a released version, got %v", err)
+	}
+	for _, id := range []int{next.ID, v.ID} {
+		if _, err := UpdateIssue(store, 1, IssuePatch{FixVersionID: &id}); err != nil {
+			t.Fatalf("expected a done issue to move freely, got %v", err)
+		}
+	}
+
+	if _, err := ArchiveVersion(store, v.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := UpdateIssue(store, 3, IssuePatch{FixVersionID: &v.ID}); !errors.Is(err, ErrInvalidVersion) {
+		t.Fatalf("expected ErrInvalidVersion for an archived version, got %v", err)
+	}
+}
+
+func TestComponentsDefaultAssignee(t *testing.T) {
+	store := &fakeStore{
+		projects: map[string]Project{
+			"PAY": {ID: 1, Key: "PAY", Name: "Payments"},
+			"OPS": {ID: 2, Key: "OPS", Name: "Operations"},
+		},
+		nextIssueID: 1,
+	}
+
+	api, err := CreateComponent(store, "PAY", "API", "", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	checkout, err := CreateComponent(store, "PAY", "Checkout", "", "alice")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	infra, err := CreateComponent(store, "OPS", "Infra", "", "carol")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := CreateComponent(store, "PAY", "checkout", "", ""); !errors.Is(err, ErrComponentExists) {
+		t.Fatalf("expected ErrComponentExists, got %v", err)
+	}
+
+	led, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "Fix totals", ComponentIDs: []int{checkout.ID, api.ID}}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if led.Assignee != "alice" || !slices.Equal(led.ComponentIDs, []int{api.ID, checkout.ID}) {
+		t.Fatalf("expected the issue assigned to the component lead, got %+v", led)
+	}
+
+	own, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "Fix receipts", Assignee: "bob", ComponentIDs: []int{checkout.ID}}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if own.Assignee != "bob" {
+		t.Fatalf("expected the given assignee kept, got %q", own.Assignee)
+	}
+
+	lead := "dave"
+	if _, err := UpdateComponent(store, checkout.ID, ComponentPatch{Lead: &lead}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if i, _ := store.GetIssueByID(led.ID); i.Assignee != "alice" {
+		t.Fatalf("expected existing issues to keep their assignee, got %q", i.Assignee)
+	}
+
+	if _, err := CreateIssue(store, "PAY", "No components", testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	none := 0
+	for _, tc := range []struct {
+		componentID *int
+		want        int
+	}{
+		{&api.ID, 1},
+		{&checkout.ID, 2},
+		{&none, 1},
+	} {
+		issues, err := FindIssues(store, IssueQuery{ProjectKey: "PAY", ComponentID: tc.componentID})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(issues) != tc.want {
+			t.Fatalf("component %d: expected %d issues, got %d", *tc.componentID, tc.want, len(issues))
+		}
+	}
+
+	if _, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "Wrong project", ComponentIDs: []int{infra.ID}}, testTime); !errors.Is(err, ErrInvalidComponent) {
+		t.Fatalf("expected ErrInvalidComponent, got %v", err)
+	}
+}
+
+func TestCustomFields(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+
+	for _, f := range []CustomField{
+		{Key: "severity", Name: "Severity", Type: CustomSelect, Required: true, Options: []string{"S1", "S2", "S3"}},
+		{Key: "envs", Name: "Environments", Type: CustomMultiSelect, Options: []string{"prod", "staging", "dev"}},
+		{Key: "amount", Name: "Amount", Type: CustomNumber},
+		{Key: "due", Name: "Due", Type: CustomDate},
+	} {
+		if _, err := CreateCustomField(store, "PAY", f); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if _, err := CreateCustomField(store, "PAY", CustomField{Key: "severity", Name: "Again", Type: CustomText}); !errors.Is(err, ErrCustomFieldExists) {
+		t.Fatalf("expected ErrCustomFieldExists, got %v", err)
+	}
+	for _, f := range []CustomField{
+		{Key: "Bad Key", Name: "Bad", Type: CustomText},
+		{Key: "kind", Name: "Kind", Type: CustomSelect},
+		{Key: "note", Name: "Note", Type: CustomText, Options: []string{"a"}},
+		{Key: "color", Name: "Color", Type: "colour"},
+	} {
+		if _, err := CreateCustomField(store, "PAY", f); !errors.Is(err, ErrInvalidCustomField) {
+			t.Fatalf("%s: expected ErrInvalidCustomField, got %v", f.Key, err)
+		}
+	}
+
+	_, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "No severity"}, testTime)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Fields[0].Field != "custom_fields.severity" || verr.Fields[0].Code != FieldRequired {
+		t.Fatalf("expected the required severity reported, got %v", err)
+	}
+
+	issue, err := CreateIssueFrom(store, "PAY", NewIssue{Title: "Refund fails", CustomFields: map[string][]string{
+		"severity": {"S1"},
+		"envs":     {"staging", "prod", "prod"},
+		"amount":   {"12.50"},
+		"due":      {"2026-11-02"},
+	}}, testTime)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := map[string][]string{"severity": {"S1"}, "envs": {"prod", "staging"}, "amount": {"12.5"}, "due": {"2026-11-02"}}
+	if !maps.EqualFunc(issue.CustomFields, want, slices.Equal) {
+		t.Fatalf("unexpected values: %v", issue.CustomFields)
+	}
+
+	for _, values := range []map[string][]string{
+		{"severity": {"S9"}},
+		{"severity": nil},
+		{"amount": {"lots"}},
+		{"due": {"02.11.2026"}},
+		{"envs": {"qa"}},
+		{"unknown": {"x"}},
+	} {
+		if _, err := UpdateIssue(store, issue.ID, IssuePatch{CustomFields: values}); !errors.Is(err, ErrInvalidIssue) {
+			t.Fatalf("%v: expected ErrInvalidIssue, got %v", values, err)
+		}
+	}
+
+	updated, err := UpdateIssue(store, issue.ID, IssuePatch{CustomFields: map[string][]string{"amount": nil, "severity": {"S2"}}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok := updated.CustomFields["amount"]; ok || updated.CustomFields["severity"][0] != "S2" {
+		t.Fatalf("unexpected values: %v", updated.CustomFields)
+	}
+	if issue.CustomFields["severity"][0] != "S1" {
+		t.Fatal("expected the earlier issue value left alone")
+	}
+
+	for filters, n := range map[string]int{"prod": 1, "dev": 0} {
+		issues, err := FindIssues(store, IssueQuery{ProjectKey: "PAY", CustomFields: map[string]string{"envs": filters}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(issues) != n {
+			t.Fatalf("envs=%s: expected %d issues, got %d", filters, n, len(issues))
+		}
+	}
+	if _, err := FindIssues(store, IssueQuery{ProjectKey: "PAY", CustomFields: map[string]string{"amount": "x"}}); !errors.Is(err, ErrInvalidCustomField) {
+		t.Fatalf("expected ErrInvalidCustomField, got %v", err)
+	}
+
+	cleared, err := DeleteCustomField(store, 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(cleared) != 1 || cleared[0].CustomFields["envs"] != nil {
+		t.Fatalf("expected the values removed from the issue, got %+v", cleared)
+	}
+}
+
+func TestRules(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+
+	if _, err := CreateRule(store, "PAY", Rule{Name: "Nothing", Trigger: "issue.deleted"}); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("expected ErrInvalidRule, got %v", err)
+	}
+	_, err := CreateRule(store, "PAY", Rule{
+		Name:       "Bad",
+		Trigger:    TriggerIssueCreated,
+		Conditions: []RuleCondition{{Field: ConditionPriority, Value: "URGENT"}},
+		Actions:    []RuleAction{{Type: ActionAddLabel, Value: "needs triage"}},
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Fields) != 2 {
+		t.Fatalf("expected the condition and the action rejected, got %v", err)
+	}
+	for _, a := range []RuleAction{
+		{Type: ActionTransition, Value: StatusDone, Resolution: "SOLVED"},
+		{Type: ActionComment, Value: "Closed", Resolution: ResolutionDone},
+	} {
+		if _, err := CreateRule(store, "PAY", Rule{Name: "Close", Trigger: TriggerIssueCreated, Actions: []RuleAction{a}}); !errors.Is(err, ErrInvalidRule) {
+			t.Fatalf("expected %+v rejected, got %v", a, err)
+		}
+	}
+
+	r, err := CreateRule(store, "PAY", Rule{
+		Name:       " Escalate ",
+		Enabled:    true,
+		Trigger:    TriggerIssueCreated,
+		Conditions: []RuleCondition{{Field: ConditionType, Value: TypeBug}, {Field: ConditionPriority, Value: PriorityHighest}},
+		Actions:    []RuleAction{{Type: ActionAssign, Value: "oncall"}, {Type: ActionAddLabel, Value: "urgent"}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if r.Name != "Escalate" || r.ProjectKey != "PAY" {
+		t.Fatalf("unexpected rule: %+v", r)
+	}
+
+	if !r.Matches(Issue{Type: TypeBug, Priority: PriorityHighest}, "", "") {
+		t.Fatal("expected a highest bug to match")
+	}
+	if r.Matches(Issue{Type: TypeBug, Priority: PriorityHigh}, "", "") {
+		t.Fatal("expected a high bug not to match")
+	}
+
+	disabled := false
+	if _, err := UpdateRule(store, r.ID, RulePatch{Enabled: &disabled, Actions: &[]RuleAction{}}); !errors.Is(err, ErrInvalidRule) {
+		t.Fatalf("expected the rule to need actions, got %v", err)
+	}
+	r, err = UpdateRule(store, r.ID, RulePatch{Enabled: &disabled})
+	if err != nil || r.Enabled {
+		t.Fatalf("expected the rule disabled, got %+v, %v", r, err)
+	}
+
+	for i := range 3 {
+		RecordRuleExecution(store, RuleExecution{RuleID: r.ID, ProjectKey: "PAY", IssueID: i + 1, Status: ExecutionSucceeded})
+	}
+	log, err := ListRuleExecutions(store, "PAY", 2)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(log) != 2 || log[0].IssueID != 3 || log[1].IssueID != 2 {
+		t.Fatalf("expected the two newest entries first, got %+v", log)
+	}
+
+	if err := DeleteRule(store, r.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := GetRule(store, r.ID); !errors.Is(err, ErrRuleNotFound) {
+		t.Fatalf("expected ErrRuleNotFound, got %v", err)
+	}
+	if log, _ := ListRuleExecutions(store, "PAY", 0); len(log) != 3 {
+		t.Fatalf("expected the audit log kept, got %d entries", len(log))
+	}
+}
+
+func TestDoneParent(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+
+	parent, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "Checkout"}, testTime)
+	first, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "API", Type: TypeSubtask, ParentID: parent.ID}, testTime)
+	second, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "UI", Type: TypeSubtask, ParentID: parent.ID}, testTime)
+
+	for _, id := range []int{first.ID, second.ID} {
+		if _, err := TransitionIssue(store, id, StatusInProgress, testTime); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if _, err := TransitionIssueWith(store, first.ID, IssueTransition{ToStatus: StatusDone, Resolution: ResolutionFixed}, testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, ok, err := DoneParent(store, first.ID); err != nil || ok {
+		t.Fatalf("expected no parent while a subtask is open, got %v, %v", ok, err)
+	}
+
+	if _, err := TransitionIssueWith(store, second.ID, IssueTransition{ToStatus: StatusDone, Resolution: ResolutionFixed}, testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	got, ok, err := DoneParent(store, second.ID)
+	if err != nil || !ok || got.ID != parent.ID {
+		t.Fatalf("expected the parent once all subtasks are done, got %+v, %v, %v", got, ok, err)
+	}
+	if _, ok, _ := DoneParent(store, parent.ID); ok {
+		t.Fatal("expected no parent for a top-level issue")
+	}
+}
+
+func TestTransitionIssueWith_Hooks(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+	RegisterCondition("not_bob", ConditionFunc(func(t Transition) error {
+		if t.Actor == "bob" {
+			return ErrTransitionDenied
+		}
+		return nil
+	}))
+	t.Cleanup(func() { delete(conditions, "not_bob") })
+	store.SaveWorkflow(Workflow{
+		ProjectKey: "PAY",
+		Statuses:   DefaultWorkflow("PAY").Statuses,
+		Transitions: []WorkflowTransition{
+			{From: StatusOpen, To: StatusInProgress, Validators: []string{HookAssigneeRequired}},
+			{From: StatusInProgress, To: StatusDone, Conditions: []string{"not_bob"}, PostFunctions: []string{HookClearAssignee}},
+			{From: StatusDone, To: StatusOpen},
+		},
+	})
+
+	issue, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "Checkout"}, testTime)
+	_, err := TransitionIssue(store, issue.ID, StatusInProgress, testTime)
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Fields[0].Field != "assignee" {
+		t.Fatalf("expected the assignee required, got %v", err)
+	}
+
+	assignee := "alice"
+	UpdateIssue(store, issue.ID, IssuePatch{Assignee: &assignee})
+	if _, err := TransitionIssue(store, issue.ID, StatusInProgress, testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	at := testTime.Add(time.Hour)
+	if _, err := TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, Actor: "bob"}, at); !errors.Is(err, ErrTransitionDenied) {
+		t.Fatalf("expected ErrTransitionDenied, got %v", err)
+	}
+	if got, _ := GetIssue(store, issue.ID); got.Status != StatusInProgress || len(store.history) != 2 {
+		t.Fatalf("expected a refused transition to change nothing, got %+v", got)
+	}
+
+	done, err := TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, Actor: "alice", Resolution: "FIXED"}, at)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if done.Assignee != "" || !done.ResolvedAt.Equal(at) || done.Resolution != "FIXED" {
+		t.Fatalf("expected the post functions applied, got %+v", done)
+	}
+
+	reopened, err := TransitionIssue(store, issue.ID, StatusOpen, at)
+	if err != nil || !reopened.ResolvedAt.IsZero() {
+		t.Fatalf("expected resolved_at cleared, got %+v, %v", reopened, err)
+	}
+
+	RegisterCondition("never", ConditionFunc(func(t Transition) error { return ErrTransitionDenied }))
+	t.Cleanup(func() { delete(conditions, "never") })
+	wf := GetWorkflow(store, "PAY")
+	wf.Transitions[0].Conditions = []string{"never"}
+	if err := ValidateWorkflow(wf); err != nil {
+		t.Fatalf("expected a registered hook accepted, got %v", err)
+	}
+	for _, name := range []string{"sometimes", "only_assignee"} {
+		wf.Transitions[0].Conditions = []string{name}
+		if err := ValidateWorkflow(wf); !errors.Is(err, ErrInvalidWorkflow) {
+			t.Fatalf("%s: expected ErrInvalidWorkflow, got %v", name, err)
+		}
+	}
+}
+
+func TestTransitionIssueWith_Resolution(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+	issue, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "Checkout"}, testTime)
+	if _, err := TransitionIssue(store, issue.ID, StatusInProgress, testTime); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var verr *ValidationError
+	_, err := TransitionIssue(store, issue.ID, StatusDone, testTime)
+	if !errors.As(err, &verr) || verr.Fields[0].Field != "resolution" || verr.Fields[0].Code != FieldRequired {
+		t.Fatalf("expected the resolution required, got %v", err)
+	}
+	_, err = TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, Resolution: "SOLVED"}, testTime)
+	if !errors.As(err, &verr) || verr.Fields[0].Code != FieldInvalid {
+		t.Fatalf("expected an unknown resolution rejected, got %v", err)
+	}
+
+	at := testTime.Add(time.Hour)
+	done, err := TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, Resolution: ResolutionWontDo}, at)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if done.Resolution != ResolutionWontDo || !done.ResolvedAt.Equal(at) {
+		t.Fatalf("expected the issue resolved, got %+v", done)
+	}
+
+	reopened, err := TransitionIssue(store, issue.ID, StatusOpen, at.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected the issue reopened, got %v", err)
+	}
+	if reopened.Resolution != "" || !reopened.ResolvedAt.IsZero() {
+		t.Fatalf("expected the resolution cleared, got %+v", reopened)
+	}
+}
+
+func TestTransitionIssueWith_DefaultResolution(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"PAY": {ID: 1, Key: "PAY", Name: "Payments"}},
+		nextIssueID: 1,
+	}
+	store.SaveWorkflow(Workflow{
+		ProjectKey: "PAY",
+		Statuses: []WorkflowStatus{
+			{Name: StatusOpen, Category: CategoryTodo},
+			{Name: StatusDone, Category: CategoryDone},
+			{Name: "RELEASED", Category: CategoryDone},
+		},
+	})
+	issue, _ := CreateIssueFrom(store, "PAY", NewIssue{Title: "Checkout"}, testTime)
+
+	done, err := TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, Resolution: ResolutionWontDo, DefaultResolution: ResolutionDone}, testTime)
+	if err != nil || done.Resolution != ResolutionWontDo {
+		t.Fatalf("expected the given resolution to win, got %+v, %v", done, err)
+	}
+	released, err := TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: "RELEASED", DefaultResolution: ResolutionDone}, testTime.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if released.Resolution != ResolutionWontDo || !released.ResolvedAt.Equal(testTime) {
+		t.Fatalf("expected the resolution kept within DONE, got %+v", released)
+	}
+
+	if _, err := TransitionIssue(store, issue.ID, StatusOpen, testTime); err != nil {
+		t.Fatalf("expected the issue reopened, got %v", err)
+	}
+	done, err = TransitionIssueWith(store, issue.ID, IssueTransition{ToStatus: StatusDone, DefaultResolution: ResolutionDone}, testTime)
+	if err != nil || done.Resolution != ResolutionDone {
+		t.Fatalf("expected the default on entering DONE, got %+v, %v", done, err)
+	}
+}
+
+func TestSLAPolicy_Timer(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, berlin)
+	}
+	office := Calendar{
+		TimeZone: "Europe/Berlin",
+		Workdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		Opens:    9 * time.Hour,
+		Closes:   17 * time.Hour,
+	}
+	// Friday 16:00, then Monday 10:00 and 12:00.
+	opened := []StatusChange{{To: StatusOpen, At: at(16, 16)}}
+	worked := append(slices.Clone(opened),
+		StatusChange{From: StatusOpen, To: StatusInProgress, At: at(19, 10)},
+		StatusChange{From: StatusInProgress, To: StatusDone, At: at(19, 12)})
+	now := at(19, 11)
+
+	tests := []struct {
+		name    string
+		policy  SLAPolicy
+		history []StatusChange
+		now     time.Time
+		want    SLATimer
+	}{
+		{
+			name:    "running over a weekend",
+			policy:  SLAPolicy{Target: 4 * time.Hour, StartStatuses: []string{StatusOpen}, StopStatuses: []string{StatusInProgress}, Calendar: office},
+			history: opened,
+			now:     now,
+			want:    SLATimer{State: SLARunning, Target: 4 * time.Hour, Elapsed: 3 * time.Hour, Remaining: time.Hour, StartedAt: at(16, 16), DueAt: at(19, 12)},
+		},
+		{
+			name: "holiday",
+			policy: SLAPolicy{Target: 4 * time.Hour, StartStatuses: []string{StatusOpen}, StopStatuses: []string{StatusInProgress}, Calendar: Calendar{
+				TimeZone: office.TimeZone, Workdays: office.Workdays, Opens: office.Opens, Closes: office.Closes,
+				Holidays: []time.Time{time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+			}},
+			history: opened,
+			now:     now,
+			want:    SLATimer{State: SLARunning, Target: 4 * time.Hour, Elapsed: time.Hour, Remaining: 3 * time.Hour, StartedAt: at(16, 16), DueAt: at(20, 12)},
+		},
+		{
+			name:    "breached",
+			policy:  SLAPolicy{Target: time.Hour, StartStatuses: []string{StatusOpen}, StopStatuses: []string{StatusInProgress}, Calendar: office},
+			history: opened,
+			now:     now,
+			want:    SLATimer{State: SLARunning, Target: time.Hour, Elapsed: 3 * time.Hour, Remaining: -2 * time.Hour, Breached: true, StartedAt: at(16, 16)},
+		},
+		{
+			name:    "paused then stopped",
+			policy:  SLAPolicy{Target: 8 * time.Hour, StartStatuses: []string{StatusOpen}, PauseStatuses: []string{StatusInProgress}, StopStatuses: []string{StatusDone}, Calendar: office},
+			history: worked,
+			now:     at(19, 15),
+			want:    SLATimer{State: SLAStopped, Target: 8 * time.Hour, Elapsed: 2 * time.Hour, Remaining: 6 * time.Hour, StartedAt: at(16, 16), StoppedAt: at(19, 12)},
+		},
+		{
+			name:    "paused",
+			policy:  SLAPolicy{Target: 8 * time.Hour, StartStatuses: []string{StatusOpen}, PauseStatuses: []string{StatusInProgress}, StopStatuses: []string{StatusDone}, Calendar: office},
+			history: worked[:2],
+			now:     at(19, 15),
+			want:    SLATimer{State: SLAPaused, Target: 8 * time.Hour, Elapsed: 2 * time.Hour, Remaining: 6 * time.Hour, StartedAt: at(16, 16)},
+		},
+		{
+			name:    "not started",
+			policy:  SLAPolicy{Target: time.Hour, StartStatuses: []string{StatusInProgress}, StopStatuses: []string{StatusDone}},
+			history: opened,
+			now:     now,
+			want:    SLATimer{State: SLANotStarted, Target: time.Hour, Remaining: time.Hour},
+		},
+		{
+			name:    "around the clock",
+			policy:  SLAPolicy{Target: 72 * time.Hour, StartStatuses: []string{StatusOpen}, StopStatuses: []string{StatusDone}},
+			history: opened,
+			now:     now,
+			want:    SLATimer{State: SLARunning, Target: 72 * time.Hour, Elapsed: 67 * time.Hour, Remaining: 5 * time.Hour, StartedAt: at(16, 16), DueAt: at(19, 16)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.Timer(tt.history, tt.now)
+			if got.State != tt.want.State || got.Elapsed != tt.want.Elapsed || got.Remaining != tt.want.Remaining ||
+				got.Breached != tt.want.Breached || !got.StartedAt.Equal(tt.want.StartedAt) ||
+				!got.StoppedAt.Equal(tt.want.StoppedAt) || !got.DueAt.Equal(tt.want.DueAt) {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSLAPolicies(t *testing.T) {
+	store := &fakeStore{
+		projects:    map[string]Project{"SUP": {ID: 1, Key: "SUP", Name: "Support"}},
+		nextIssueID: 1,
+	}
+
+	_, err := CreateSLAPolicy(store, "SUP", SLAPolicy{
+		Name:          "Bad",
+		Target:        time.Hour,
+		StartStatuses: []string{StatusOpen, "WAITING"},
+		StopStatuses:  []string{StatusOpen},
+		Calendar:      Calendar{TimeZone: "Mars/Olympus", Opens: 17 * time.Hour, Closes: 9 * time.Hour},
+	})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || !errors.Is(err, ErrInvalidSLA) || len(verr.Fields) != 4 {
+		t.Fatalf("expected the statuses and the calendar rejected, got %v", err)
+	}
+
+	p, err := CreateSLAPolicy(store, "SUP", SLAPolicy{
+		Name:          " First response ",
+		Target:        time.Hour,
+		StartStatuses: []string{StatusOpen},
+		StopStatuses:  []string{StatusInProgress},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if p.Name != "First response" || p.ProjectKey != "SUP" {
+		t.Fatalf("unexpected policy: %+v", p)
+	}
+
+	issue, _ := CreateIssue(store, "SUP", "Cannot log in", testTime)
+	timers := SLATimers(store, []Issue{issue}, testTime.Add(30*time.Minute))
+	if len(timers[issue.ID]) != 1 || timers[issue.ID][0].Breached {
+		t.Fatalf("expected one running timer, got %+v", timers)
+	}
+
+	if got := RecordSLABreaches(store, testTime.Add(30*time.Minute)); len(got) != 0 {
+		t.Fatalf("expected no breaches yet, got %+v", got)
+	}
+	got := RecordSLABreaches(store, testTime.Add(2*time.Hour))
+	if len(got) != 1 || got[0].Issue.ID != issue.ID || got[0].Timer.PolicyID != p.ID {
+		t.Fatalf("expected the breach reported, got %+v", got)
+	}
+	if got := RecordSLABreaches(store, testTime.Add(3*time.Hour)); len(got) != 0 {
+		t.Fatalf("expected the breach reported once, got %+v", got)
+	}
+
+	target := 4 * time.Hour
+	updated, err := UpdateSLAPolicy(store, p.ID, SLAPatch{Target: &target})
+	if err != nil || updated.Target != target {
+		t.Fatalf("expected the target updated, got %+v, %v", updated, err)
+	}
+	stop := []string{StatusOpen}
+	if _, err := UpdateSLAPolicy(store, p.ID, SLAPatch{StopStatuses: &stop}); !errors.Is(err, ErrInvalidSLA) {
+		t.Fatalf("expected ErrInvalidSLA, got %v", err)
+	}
+
+	if err := DeleteSLAPolicy(store, p.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, err := GetSLAPolicy(store, p.ID); !errors.Is(err, ErrSLANotFound) {
+		t.Fatalf("expected ErrSLANotFound, got %v", err)
+	}
+}
@@ -1,5 +1,7 @@
 package logic
 
+import "time"
+
 type Project struct {
 	ID   int
 	Key  string
@@ -10,7 +12,359 @@ type Issue struct {
 	ID         int
 	ProjectKey string
 	Title      string
+	Type       string
+	Priority   string
+	Status     string
+	Rank       int
+	Assignee   string
+	Labels     []string
+	// SprintID is 0 while the issue is in the project backlog.
+	SprintID int
+	// ParentID is the epic or parent task, 0 if none.
+	ParentID  int
+	CreatedAt time.Time
+	// StoryPoints is the estimate used by sprint reports; 0 means
+	// unestimated.
+	StoryPoints int
+	// OriginalEstimate and RemainingEstimate are time estimates; both 0
+	// means no time estimate. TimeSpent is the sum of the worklogs.
+	OriginalEstimate  time.Duration
+	RemainingEstimate time.Duration
+	TimeSpent         time.Duration
+	// FixVersionID is the version the issue ships in, 0 if none.
+	FixVersionID int
+	ComponentIDs []int
+	// CustomFields holds custom field values by field key, normalized as
+	// text: numbers in their shortest form and dates as YYYY-MM-DD. Only
+	// multi_select fields have more than one value.
+	CustomFields map[string][]string
+	// Resolution says how an issue in a DONE status was settled, one of
+	// Resolutions; ResolvedAt is when it entered the DONE category. Both
+	// are empty while the issue is not done.
+	Resolution string
+	ResolvedAt time.Time
+}
+
+// IssueTransition asks to move an issue. Resolution is required to enter
+// a DONE status and ignored otherwise; DefaultResolution stands in for it
+// only when the issue enters DONE from another category, so it never
+// overwrites a resolution already given. Actor is input for the hooks of
+// the workflow transition.
+type IssueTransition struct {
+	ToStatus          string
+	Actor             string
+	Resolution        string
+	DefaultResolution string
+}
+
+// NewIssue carries the optional fields an issue can be created with.
+// Empty Type and Priority mean TASK and MEDIUM.
+type NewIssue struct {
+	Title        string
+	Type         string
+	Priority     string
+	Assignee     string
+	Labels       []string
+	ParentID     int
+	StoryPoints  int
+	FixVersionID int
+	// Without an Assignee the issue goes to the lead of the first of
+	// ComponentIDs that has one.
+	ComponentIDs []int
+	// CustomFields must include every required field of the project.
+	CustomFields map[string][]string
+	// RemainingEstimate defaults to OriginalEstimate.
+	OriginalEstimate  time.Duration
+	RemainingEstimate time.Duration
+}
+
+// Sprint moves from FUTURE to ACTIVE to CLOSED. Committed* is the scope
+// when it started, Completed* what was done when it closed; Incomplete
+// lists the issues that were moved out at close.
+type Sprint struct {
+	ID              int
+	ProjectKey      string
+	Name            string
+	State           string
+	StartedAt       time.Time
+	EndsAt          time.Time
+	ClosedAt        time.Time
+	CommittedIssues int
+	CommittedPoints int
+	CompletedIssues int
+	CompletedPoints int
+	Incomplete      []int
+}
+
+// Component is a part of a project, such as "checkout", owned by Lead.
+type Component struct {
+	ID          int
+	ProjectKey  string
+	Name        string
+	Description string
+	Lead        string
+}
+
+// ComponentPatch lists the fields of a component to change; nil means
+// unchanged.
+type ComponentPatch struct {
+	Name        *string
+	Description *string
+	Lead        *string
+}
+
+// CustomField is an issue field a project defines for itself. Key names
+// the field in issue values and filters and never changes; Options lists
+// the choices of select and multi_select fields.
+type CustomField struct {
+	ID         int
+	ProjectKey string
+	Key        string
+	Name       string
+	Type       string
+	Required   bool
+	Options    []string
+}
+
+// CustomFieldPatch lists the fields of a custom field to change; nil means
+// unchanged. The key and type are fixed.
+type CustomFieldPatch struct {
+	Name     *string
+	Required *bool
+	Options  *[]string
+}
+
+// Rule is an automation rule of a project: when Trigger fires for an
+// issue that meets every condition, the actions run in order.
+type Rule struct {
+	ID         int
+	ProjectKey string
+	Name       string
+	Enabled    bool
+	Trigger    string
+	Conditions []RuleCondition
+	Actions    []RuleAction
+}
+
+// RuleCondition holds when Field of the issue is Value. An issue meets a
+// label condition when it has the label; an empty assignee means
+// unassigned.
+type RuleCondition struct {
+	Field string
+	Value string
+}
+
+// RuleAction is one step of a rule. Resolution is for a transition action
+// and is required when it moves an issue into a DONE status.
+type RuleAction struct {
+	Type       string
+	Value      string
+	Resolution string
+}
+
+// RulePatch lists the fields of a rule to change; nil means unchanged.
+// Conditions and Actions replace the whole list.
+type RulePatch struct {
+	Name       *string
+	Enabled    *bool
+	Trigger    *string
+	Conditions *[]RuleCondition
+	Actions    *[]RuleAction
+}
+
+// RuleExecution is an entry of the automation audit log: one rule run
+// for one issue, or the reason it did not run. Chain lists the rules
+// whose actions led to the triggering event.
+type RuleExecution struct {
+	ID         int
+	RuleID     int
+	RuleName   string
+	ProjectKey string
+	IssueID    int
+	Trigger    string
+	Chain      []int
+	Status     string
+	// Actions counts the actions that completed.
+	Actions int
+	Error   string
+	At      time.Time
+}
+
+// SLAPolicy is a time goal for the issues of a project. Its timer starts
+// when an issue enters one of StartStatuses, stands still while the issue
+// is in one of PauseStatuses and stops for good when it enters one of
+// StopStatuses. Only the business hours of Calendar count.
+type SLAPolicy struct {
+	ID            int
+	ProjectKey    string
+	Name          string
+	Target        time.Duration
+	StartStatuses []string
+	PauseStatuses []string
+	StopStatuses  []string
+	Calendar      Calendar
+}
+
+// Calendar describes business hours: Opens to Closes, measured from
+// midnight in TimeZone, on Workdays that are not Holidays. Empty Workdays
+// means every day, Opens and Closes both zero mean the whole day, and an
+// empty TimeZone means UTC, so the zero Calendar counts around the clock.
+type Calendar struct {
+	TimeZone string
+	Workdays []time.Weekday
+	Opens    time.Duration
+	Closes   time.Duration
+	// Holidays are dates; only their year, month and day count.
+	Holidays []time.Time
+}
+
+// SLAPatch lists the fields of an SLA policy to change; nil means
+// unchanged. Status lists and the calendar are replaced as a whole.
+type SLAPatch struct {
+	Name          *string
+	Target        *time.Duration
+	StartStatuses *[]string
+	PauseStatuses *[]string
+	StopStatuses  *[]string
+	Calendar      *Calendar
+}
+
+// SLATimer is where an issue stands against an SLA policy at a moment.
+// Remaining goes negative once the target is breached.
+type SLATimer struct {
+	PolicyID  int
+	Name      string
+	State     string
+	Target    time.Duration
+	Elapsed   time.Duration
+	Remaining time.Duration
+	Breached  bool
+	StartedAt time.Time
+	StoppedAt time.Time
+	// DueAt is when a running timer reaches its target; zero otherwise.
+	DueAt time.Time
+}
+
+// SLABreach records that the breach of a policy by an issue was reported.
+type SLABreach struct {
+	PolicyID int
+	IssueID  int
+	At       time.Time
+}
+
+// BreachedSLA is an issue whose SLA timer went over its target.
+type BreachedSLA struct {
+	Issue Issue
+	Timer SLATimer
+}
+
+// Version is a release of a project. It is UNRELEASED until released and
+// may be ARCHIVED afterwards. ReleaseDate is the planned or actual day of
+// the release; ReleasedAt is when it was marked released.
+type Version struct {
+	ID          int
+	ProjectKey  string
+	Name        string
+	Description string
+	State       string
+	ReleaseDate time.Time
+	ReleasedAt  time.Time
+}
+
+// VersionPatch lists the fields of a version to change; nil means
+// unchanged.
+type VersionPatch struct {
+	Name        *string
+	Description *string
+	ReleaseDate *time.Time
+}
+
+// Worklog is time spent on an issue, starting at StartedAt. Reduced is how
+// much it lowered the remaining estimate, which stops at zero, so removing
+// the work gives back exactly that.
+type Worklog struct {
+	ID        int
+	IssueID   int
+	Author    string
+	Spent     time.Duration
+	Reduced   time.Duration
+	StartedAt time.Time
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WorklogPatch lists the fields of a worklog to change; nil means
+// unchanged.
+type WorklogPatch struct {
+	Spent     *time.Duration
+	StartedAt *time.Time
+	Comment   *string
+}
+
+type Comment struct {
+	ID        int
+	IssueID   int
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// StatusChange records an issue entering a status. The first change of an
+// issue has an empty From and is recorded when the issue is created.
+type StatusChange struct {
+	IssueID int
+	From    string
+	To      string
+	At      time.Time
+}
+
+// IssueLink is a directed relation: FromID blocks, duplicates or clones
+// ToID. "relates" has no direction.
+type IssueLink struct {
+	ID     int
+	Type   string
+	FromID int
+	ToID   int
+}
+
+// IssuePatch lists the fields of an issue to change; nil means unchanged.
+// AddLabels and RemoveLabels apply on top of Labels.
+type IssuePatch struct {
+	Title        *string
+	Type         *string
+	Priority     *string
+	Assignee     *string
+	Labels       *[]string
+	AddLabels    []string
+	RemoveLabels []string
+	SprintID     *int
+	ParentID     *int
+	StoryPoints  *int
+	// Setting OriginalEstimate alone also resets RemainingEstimate while
+	// no work is logged.
+	OriginalEstimate  *time.Duration
+	RemainingEstimate *time.Duration
+	FixVersionID      *int
+	// ComponentIDs replaces the whole set.
+	ComponentIDs *[]int
+	// CustomFields sets the fields present; an empty value clears one.
+	CustomFields map[string][]string
+}
+
+// IssueQuery selects issues of one project; empty fields match anything.
+type IssueQuery struct {
+	ProjectKey string
 	Status     string
+	Assignee   string
+	Label      string
+	SprintID   *int
+	// FixVersionID 0 selects issues without a fix version, ComponentID 0
+	// issues without components.
+	FixVersionID *int
+	ComponentID  *int
+	// CustomFields selects issues that have each value, by field key.
+	CustomFields map[string]string
 }
 
 const (
@@ -18,3 +372,116 @@ const (
 	StatusInProgress = "IN_PROGRESS"
 	StatusDone       = "DONE"
 )
+
+const (
+	SprintFuture = "FUTURE"
+	SprintActive = "ACTIVE"
+	SprintClosed = "CLOSED"
+)
+
+const (
+	VersionUnreleased = "UNRELEASED"
+	VersionReleased   = "RELEASED"
+	VersionArchived   = "ARCHIVED"
+)
+
+const (
+	TypeTask    = "TASK"
+	TypeBug     = "BUG"
+	TypeStory   = "STORY"
+	TypeEpic    = "EPIC"
+	TypeSubtask = "SUBTASK"
+)
+
+var IssueTypes = []string{TypeTask, TypeBug, TypeStory, TypeEpic, TypeSubtask}
+
+const (
+	PriorityHighest = "HIGHEST"
+	PriorityHigh    = "HIGH"
+	PriorityMedium  = "MEDIUM"
+	PriorityLow     = "LOW"
+	PriorityLowest  = "LOWEST"
+)
+
+const (
+	ResolutionFixed           = "FIXED"
+	ResolutionWontDo          = "WONT_DO"
+	ResolutionDuplicate       = "DUPLICATE"
+	ResolutionCannotReproduce = "CANNOT_REPRODUCE"
+	// ResolutionDone is for work that is simply complete.
+	ResolutionDone = "DONE"
+)
+
+var Resolutions = []string{ResolutionFixed, ResolutionWontDo, ResolutionDuplicate, ResolutionCannotReproduce, ResolutionDone}
+
+var Priorities = []string{PriorityHighest, PriorityHigh, PriorityMedium, PriorityLow, PriorityLowest}
+
+const (
+	LinkBlocks     = "blocks"
+	LinkRelates    = "relates"
+	LinkDuplicates = "duplicates"
+	LinkClones     = "clones"
+)
+
+const (
+	CustomText        = "text"
+	CustomNumber      = "number"
+	CustomSelect      = "select"
+	CustomMultiSelect = "multi_select"
+	CustomDate        = "date"
+	CustomUser        = "user"
+)
+
+var CustomFieldTypes = []string{CustomText, CustomNumber, CustomSelect, CustomMultiSelect, CustomDate, CustomUser}
+
+const (
+	TriggerIssueCreated      = "issue.created"
+	TriggerIssueUpdated      = "issue.updated"
+	TriggerIssueTransitioned = "issue.transitioned"
+	// TriggerSubtasksDone fires for the parent when the last of its
+	// subtasks moves to a DONE status.
+	TriggerSubtasksDone = "subtasks.done"
+)
+
+var RuleTriggers = []string{TriggerIssueCreated, TriggerIssueUpdated, TriggerIssueTransitioned, TriggerSubtasksDone}
+
+// Rule condition fields. FromStatus and ToStatus describe the transition
+// that fired an issue.transitioned rule.
+const (
+	ConditionType       = "type"
+	ConditionPriority   = "priority"
+	ConditionStatus     = "status"
+	ConditionAssignee   = "assignee"
+	ConditionLabel      = "label"
+	ConditionFromStatus = "from_status"
+	ConditionToStatus   = "to_status"
+)
+
+var RuleConditionFields = []string{ConditionType, ConditionPriority, ConditionStatus, ConditionAssignee, ConditionLabel, ConditionFromStatus, ConditionToStatus}
+
+const (
+	ActionAssign      = "assign"
+	ActionAddLabel    = "add_label"
+	ActionRemoveLabel = "remove_label"
+	ActionSetPriority = "set_priority"
+	ActionTransition  = "transition"
+	ActionComment     = "comment"
+)
+
+var RuleActionTypes = []string{ActionAssign, ActionAddLabel, ActionRemoveLabel, ActionSetPriority, ActionTransition, ActionComment}
+
+const (
+	ExecutionSucceeded = "SUCCEEDED"
+	ExecutionFailed    = "FAILED"
+	// ExecutionSkipped marks a rule held back by loop protection.
+	ExecutionSkipped = "SKIPPED"
+)
+
+const (
+	SLANotStarted = "NOT_STARTED"
+	SLARunning    = "RUNNING"
+	SLAPaused     = "PAUSED"
+	SLAStopped    = "STOPPED"
+)
+
+var LinkTypes = []string{LinkBlocks, LinkRelates, LinkDuplicates, LinkClones}
@@ -10,5 +10,120 @@ type IssueStore interface {
 	CreateIssue(i Issue) Issue
 	GetIssueByID(id int) (Issue, bool)
 	UpdateIssueStatus(id int, newStatus string) (Issue, bool)
+	UpdateIssueRank(id int, rank int) (Issue, bool)
+	UpdateIssue(i Issue) (Issue, bool)
 	ListIssuesByProjectKey(projectKey string) []Issue
+	// MaxIssueRank returns the highest rank in a project, 0 if it has no
+	// issues.
+	MaxIssueRank(projectKey string) int
+}
+
+type SprintStore interface {
+	CreateSprint(s Sprint) Sprint
+	GetSprintByID(id int) (Sprint, bool)
+	UpdateSprint(s Sprint) (Sprint, bool)
+	ListSprintsByProjectKey(projectKey string) []Sprint
+}
+
+type ComponentStore interface {
+	CreateComponent(c Component) Component
+	GetComponentByID(id int) (Component, bool)
+	UpdateComponent(c Component) (Component, bool)
+	ListComponentsByProjectKey(projectKey string) []Component
+}
+
+type CustomFieldStore interface {
+	CreateCustomField(f CustomField) CustomField
+	GetCustomFieldByID(id int) (CustomField, bool)
+	UpdateCustomField(f CustomField) (CustomField, bool)
+	DeleteCustomField(id int) bool
+	ListCustomFieldsByProjectKey(projectKey string) []CustomField
+}
+
+type RuleStore interface {
+	CreateRule(r Rule) Rule
+	GetRuleByID(id int) (Rule, bool)
+	UpdateRule(r Rule) (Rule, bool)
+	DeleteRule(id int) bool
+	ListRulesByProjectKey(projectKey string) []Rule
+	// AddRuleExecution appends to the audit log, dropping the oldest
+	// entries beyond MaxRuleExecutions.
+	AddRuleExecution(x RuleExecution) RuleExecution
+	// ListRuleExecutions returns the audit log of a project, oldest first.
+	ListRuleExecutions(projectKey string) []RuleExecution
+}
+
+type SLAStore interface {
+	CreateSLAPolicy(p SLAPolicy) SLAPolicy
+	GetSLAPolicyByID(id int) (SLAPolicy, bool)
+	UpdateSLAPolicy(p SLAPolicy) (SLAPolicy, bool)
+	DeleteSLAPolicy(id int) bool
+	ListSLAPoliciesByProjectKey(projectKey string) []SLAPolicy
+	// AddSLABreach records a breach once; it returns false if the breach
+	// of that policy by that issue was already recorded.
+	AddSLABreach(b SLABreach) bool
+}
+
+type VersionStore interface {
+	CreateVersion(v Version) Version
+	GetVersionByID(id int) (Version, bool)
+	UpdateVersion(v Version) (Version, bool)
+	ListVersionsByProjectKey(projectKey string) []Version
+}
+
+type CommentStore interface {
+	CreateComment(c Comment) Comment
+	ListCommentsByIssueID(issueID int) []Comment
+}
+
+type WorklogStore interface {
+	CreateWorklog(w Worklog) Worklog
+	GetWorklogByID(id int) (Worklog, bool)
+	UpdateWorklog(w Worklog) (Worklog, bool)
+	DeleteWorklog(id int) bool
+	// ListWorklogsByIssueID returns the worklogs of an issue in the order
+	// they were logged.
+	ListWorklogsByIssueID(issueID int) []Worklog
+}
+
+type LinkStore interface {
+	CreateLink(l IssueLink) IssueLink
+	// ListLinksByIssueID returns links with the issue on either end.
+	ListLinksByIssueID(issueID int) []IssueLink
+}
+
+type WorkflowStore interface {
+	GetWorkflow(projectKey string) (Workflow, bool)
+	SaveWorkflow(w Workflow) Workflow
+}
+
+type HistoryStore interface {
+	AddStatusChange(c StatusChange)
+	// ListStatusChanges returns the changes of an issue, oldest first.
+	ListStatusChanges(issueID int) []StatusChange
+}
+
+type Store interface {
+	ProjectStore
+	IssueStore
+	SprintStore
+	ComponentStore
+	CustomFieldStore
+	RuleStore
+	SLAStore
+	VersionStore
+	CommentStore
+	WorklogStore
+	LinkStore
+	WorkflowStore
+	HistoryStore
+}
+
+// TxStore runs fn against a transactional view of the store: its writes
+// become visible to others only if fn returns nil, and are discarded
+// otherwise. The view is a TxStore too; a transaction started on it is
+// discarded alone if it fails and otherwise commits with the outer one.
+type TxStore interface {
+	Store
+	Tx(fn func(tx Store) error) error
 }
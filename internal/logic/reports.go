@@ -0,0 +1,497 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// IssueTimes is how one issue moved through its statuses. Statuses no
+// longer in the workflow count as in progress.
+type IssueTimes struct {
+	Issue Issue
+	// InStatus is the time spent in each status within the report range,
+	// excluding DONE statuses.
+	InStatus map[string]time.Duration
+	// StartedAt is the first move out of the TODO category. DoneAt is the
+	// last move into DONE and is zero unless the issue is done now.
+	StartedAt time.Time
+	DoneAt    time.Time
+	// CycleTime runs from StartedAt and LeadTime from creation to DoneAt;
+	// both are zero for issues that are not done.
+	CycleTime time.Duration
+	LeadTime  time.Duration
+}
+
+// Percentiles use the nearest-rank method over Count values.
+type Percentiles struct {
+	Count int
+	P50   time.Duration
+	P85   time.Duration
+	P95   time.Duration
+}
+
+// FlowReport covers the issues of a project that were open or finished
+// between From and To. Cycle and lead time percentiles are over the issues
+// finished in the range.
+type FlowReport struct {
+	ProjectKey string
+	From       time.Time
+	To         time.Time
+	// Statuses are the workflow statuses followed by any historical ones.
+	Statuses  []string
+	Issues    []IssueTimes
+	CycleTime Percentiles
+	LeadTime  Percentiles
+}
+
+// ListHistory returns the status changes of an issue, oldest first.
+func ListHistory(store Store, issueID int) ([]StatusChange, error) {
+	if _, err := GetIssue(store, issueID); err != nil {
+		return nil, err
+	}
+
+	return store.ListStatusChanges(issueID), nil
+}
+
+// FlowMetrics reports time in status, cycle time and lead time for the
+// issues matching q over [from, to). Time after now is not counted.
+func FlowMetrics(store Store, q IssueQuery, from, to, now time.Time) (FlowReport, error) {
+	var fields []FieldError
+	if from.IsZero() {
+		fields = append(fields, required("from"))
+	}
+	if to.IsZero() {
+		fields = append(fields, required("to"))
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		fields = append(fields, FieldError{Field: "to", Code: FieldInvalid, Message: "must be after from"})
+	}
+	if err := collect(ErrInvalidReport, fields); err != nil {
+		return FlowReport{}, err
+	}
+
+	issues, err := FindIssues(store, q)
+	if err != nil {
+		return FlowReport{}, err
+	}
+
+	wf := GetWorkflow(store, q.ProjectKey)
+	rep := FlowReport{ProjectKey: wf.ProjectKey, From: from, To: to}
+	for _, s := range wf.Statuses {
+		rep.Statuses = append(rep.Statuses, s.Name)
+	}
+
+	end := to
+	if now.Before(end) {
+		end = now
+	}
+
+	var cycle, lead []time.Duration
+	for _, i := range issues {
+		t := issueTimes(wf, i, store.ListStatusChanges(i.ID), from, end, now)
+		finished := !t.DoneAt.IsZero() && !t.DoneAt.Before(from) && t.DoneAt.Before(to)
+		if len(t.InStatus) == 0 && !finished {
+			continue
+		}
+		rep.Issues = append(rep.Issues, t)
+
+		for s := range t.InStatus {
+			if !slices.Contains(rep.Statuses, s) {
+				rep.Statuses = append(rep.Statuses, s)
+			}
+		}
+		if finished {
+			cycle = append(cycle, t.CycleTime)
+			lead = append(lead, t.LeadTime)
+		}
+	}
+	slices.Sort(rep.Statuses[len(wf.Statuses):])
+	rep.CycleTime = percentiles(cycle)
+	rep.LeadTime = percentiles(lead)
+
+	return rep, nil
+}
+
+// issueTimes walks the history of an issue and clips its intervals to
+// [from, end).
+func issueTimes(wf Workflow, i Issue, history []StatusChange, from, end, now time.Time) IssueTimes {
+	created := i.CreatedAt
+	if len(history) == 0 {
+		// Issues stored before history was recorded.
+		history = []StatusChange{{IssueID: i.ID, To: i.Status, At: created}}
+	}
+	if created.IsZero() {
+		created = history[0].At
+	}
+
+	t := IssueTimes{Issue: i, InStatus: map[string]time.Duration{}}
+	for n, c := range history {
+		category := categoryOf(wf, c.To)
+		if category != CategoryTodo && t.StartedAt.IsZero() {
+			t.StartedAt = c.At
+		}
+		switch {
+		case category == CategoryDone && (c.From == "" || categoryOf(wf, c.From) != CategoryDone):
+			t.DoneAt = c.At
+		case category != CategoryDone:
+			t.DoneAt = time.Time{}
+		}
+		if category == CategoryDone {
+			continue
+		}
+
+		stop := now
+		if n+1 < len(history) {
+			stop = history[n+1].At
+		}
+		start := c.At
+		if start.Before(from) {
+			start = from
+		}
+		if stop.After(end) {
+			stop = end
+		}
+		if stop.After(start) {
+			t.InStatus[c.To] += stop.Sub(start)
+		}
+	}
+
+	if !t.DoneAt.IsZero() {
+		t.CycleTime = t.DoneAt.Sub(t.StartedAt)
+		t.LeadTime = t.DoneAt.Sub(created)
+	}
+
+	return t
+}
+
+func categoryOf(wf Workflow, status string) string {
+	if c, ok := wf.Category(status); ok {
+		return c
+	}
+
+	return CategoryInProgress
+}
+
+func percentiles(ds []time.Duration) Percentiles {
+	if len(ds) == 0 {
+		return Percentiles{}
+	}
+
+	slices.Sort(ds)
+	rank := func(p int) time.Duration {
+		// Nearest rank: the smallest value with at least p% of values at or
+		// below it.
+		return ds[(p*len(ds)+99)/100-1]
+	}
+
+	return Percentiles{Count: len(ds), P50: rank(50), P85: rank(85), P95: rank(95)}
+}
+
+// MaxFlowDays bounds the days in a cumulative flow report.
+const MaxFlowDays = 366
+
+// FlowDay counts the issues in each status at the end of a day, or now for
+// the current day.
+type FlowDay struct {
+	Date   time.Time
+	Counts map[string]int
+}
+
+// CumulativeFlow has one entry per calendar day, in the location of From,
+// up to the current day. Every day counts every status in Statuses.
+type CumulativeFlow struct {
+	ProjectKey string
+	From       time.Time
+	To         time.Time
+	// Statuses are the workflow statuses followed by any historical ones.
+	Statuses []string
+	Days     []FlowDay
+}
+
+// CumulativeFlowReport counts the issues matching q per status for each
+// day in [from, to). from and to are truncated to midnight in the location
+// of from. Issues created before from are replayed from their history so
+// the first day is complete.
+func CumulativeFlowReport(store Store, q IssueQuery, from, to, now time.Time) (CumulativeFlow, error) {
+	loc := from.Location()
+	midnight := func(t time.Time) time.Time {
+		y, m, d := t.In(loc).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	}
+	from, to = midnight(from), midnight(to)
+
+	var fields []FieldError
+	if !to.After(from) {
+		fields = append(fields, FieldError{Field: "to", Code: FieldInvalid, Message: "must be after from"})
+	} else if to.After(from.AddDate(0, 0, MaxFlowDays)) {
+		fields = append(fields, FieldError{Field: "to", Code: FieldInvalid, Message: fmt.Sprintf("must be at most %d days after from", MaxFlowDays)})
+	}
+	if err := collect(ErrInvalidReport, fields); err != nil {
+		return CumulativeFlow{}, err
+	}
+
+	issues, err := FindIssues(store, q)
+	if err != nil {
+		return CumulativeFlow{}, err
+	}
+
+	wf := GetWorkflow(store, q.ProjectKey)
+	rep := CumulativeFlow{ProjectKey: wf.ProjectKey, From: from, To: to, Days: []FlowDay{}}
+	for _, s := range wf.Statuses {
+		rep.Statuses = append(rep.Statuses, s.Name)
+	}
+
+	histories := make(map[int][]StatusChange, len(issues))
+	for _, i := range issues {
+		histories[i.ID] = store.ListStatusChanges(i.ID)
+		statuses := []string{i.Status}
+		for _, c := range histories[i.ID] {
+			statuses = append(statuses, c.To)
+		}
+		for _, st := range statuses {
+			if !slices.Contains(rep.Statuses, st) {
+				rep.Statuses = append(rep.Statuses, st)
+			}
+		}
+	}
+	slices.Sort(rep.Statuses[len(wf.Statuses):])
+
+	for day := from; day.Before(to) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		at := day.AddDate(0, 0, 1)
+		if at.After(now) {
+			at = now
+		}
+
+		d := FlowDay{Date: day, Counts: make(map[string]int, len(rep.Statuses))}
+		for _, s := range rep.Statuses {
+			d.Counts[s] = 0
+		}
+		for _, i := range issues {
+			if status := statusAt(i, histories[i.ID], at); status != "" {
+				d.Counts[status]++
+			}
+		}
+		rep.Days = append(rep.Days, d)
+	}
+
+	return rep, nil
+}
+
+// Burndown units: remaining work is counted in issues or story points.
+const (
+	UnitIssues = "issues"
+	UnitPoints = "points"
+)
+
+// MaxVelocitySprints bounds the sprints in a velocity report.
+const MaxVelocitySprints = 50
+
+// BurndownDay is the work remaining at the end of one sprint day, or now
+// for the current day. Remaining is nil for days still ahead. Ideal falls
+// linearly from the commitment at the start to zero at the planned end.
+type BurndownDay struct {
+	Date      time.Time
+	Remaining *int
+	Ideal     float64
+}
+
+// Burndown covers the issues in the sprint now plus those moved out when
+// it closed. Scope is the work of those issues in Unit.
+type Burndown struct {
+	Sprint Sprint
+	Unit   string
+	Scope  int
+	Days   []BurndownDay
+}
+
+// Velocity lists the last closed sprints of a project, oldest first, with
+// the average completed work.
+type Velocity struct {
+	ProjectKey    string
+	Sprints       []Sprint
+	AverageIssues float64
+	AveragePoints float64
+}
+
+// SprintBurndown reports the daily remaining work of a started sprint. A
+// zero sprintID selects the active sprint of the project.
+func SprintBurndown(store Store, projectKey string, sprintID int, unit string, now time.Time) (Burndown, error) {
+	if unit == "" {
+		unit = UnitIssues
+	}
+	var fields []FieldError
+	if sprintID < 0 {
+		fields = append(fields, FieldError{Field: "sprint", Code: FieldInvalid, Message: "must be a sprint id or 0"})
+	}
+	if unit != UnitIssues && unit != UnitPoints {
+		fields = append(fields, FieldError{Field: "unit", Code: FieldInvalid, Message: "must be issues or points"})
+	}
+	if err := collect(ErrInvalidReport, fields); err != nil {
+		return Burndown{}, err
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Burndown{}, err
+	}
+
+	var sp Sprint
+	if sprintID == 0 {
+		sp, err = activeSprint(store, p.Key)
+	} else {
+		sp, err = projectSprint(store, p.Key, sprintID)
+	}
+	if err != nil {
+		return Burndown{}, err
+	}
+	if sprintState(sp) == SprintFuture {
+		return Burndown{}, fmt.Errorf("%w: sprint %d has not started", ErrInvalidSprintState, sp.ID)
+	}
+
+	issues := sprintIssues(store, sp)
+	for _, id := range sp.Incomplete {
+		if i, ok := store.GetIssueByID(id); ok && i.SprintID != sp.ID {
+			issues = append(issues, i)
+		}
+	}
+
+	wf := GetWorkflow(store, p.Key)
+	work := func(i Issue) int {
+		if unit == UnitPoints {
+			return i.StoryPoints
+		}
+		return 1
+	}
+	committed := sp.CommittedIssues
+	if unit == UnitPoints {
+		committed = sp.CommittedPoints
+	}
+
+	rep := Burndown{Sprint: sp, Unit: unit}
+	histories := make(map[int][]StatusChange, len(issues))
+	for _, i := range issues {
+		rep.Scope += work(i)
+		histories[i.ID] = store.ListStatusChanges(i.ID)
+	}
+
+	end := sp.EndsAt
+	if sp.State == SprintClosed {
+		end = sp.ClosedAt
+	} else if now.After(end) {
+		end = now
+	}
+	length := sp.EndsAt.Sub(sp.StartedAt)
+	for day := sp.StartedAt.UTC().Truncate(24 * time.Hour); day.Before(end); day = day.AddDate(0, 0, 1) {
+		at := day.AddDate(0, 0, 1)
+		if at.After(end) {
+			at = end
+		}
+
+		d := BurndownDay{Date: day}
+		if left := sp.EndsAt.Sub(at); left > 0 && length > 0 {
+			d.Ideal = float64(committed) * float64(left) / float64(length)
+		}
+		if !day.After(now) {
+			if at.After(now) {
+				at = now
+			}
+			remaining := 0
+			for _, i := range issues {
+				if !i.CreatedAt.After(at) && !doneAt(wf, i, histories[i.ID], at) {
+					remaining += work(i)
+				}
+			}
+			d.Remaining = &remaining
+		}
+		rep.Days = append(rep.Days, d)
+	}
+
+	return rep, nil
+}
+
+// SprintVelocity reports the last n closed sprints of a project.
+func SprintVelocity(store Store, projectKey string, n int) (Velocity, error) {
+	if n <= 0 || n > MaxVelocitySprints {
+		return Velocity{}, NewValidationError(ErrInvalidReport, FieldError{
+			Field:   "sprints",
+			Code:    FieldInvalid,
+			Message: fmt.Sprintf("must be between 1 and %d", MaxVelocitySprints),
+		})
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Velocity{}, err
+	}
+
+	rep := Velocity{ProjectKey: p.Key, Sprints: []Sprint{}}
+	for _, sp := range store.ListSprintsByProjectKey(p.Key) {
+		if sp.State == SprintClosed {
+			rep.Sprints = append(rep.Sprints, sp)
+		}
+	}
+	slices.SortStableFunc(rep.Sprints, func(a, b Sprint) int {
+		return a.ClosedAt.Compare(b.ClosedAt)
+	})
+	rep.Sprints = rep.Sprints[max(0, len(rep.Sprints)-n):]
+
+	for _, sp := range rep.Sprints {
+		rep.AverageIssues += float64(sp.CompletedIssues)
+		rep.AveragePoints += float64(sp.CompletedPoints)
+	}
+	if len(rep.Sprints) > 0 {
+		rep.AverageIssues /= float64(len(rep.Sprints))
+		rep.AveragePoints /= float64(len(rep.Sprints))
+	}
+
+	return rep, nil
+}
+
+func activeSprint(store Store, projectKey string) (Sprint, error) {
+	for _, sp := range store.ListSprintsByProjectKey(projectKey) {
+		if sp.State == SprintActive {
+			return sp, nil
+		}
+	}
+
+	return Sprint{}, ErrNoActiveSprint
+}
+
+func projectSprint(store Store, projectKey string, id int) (Sprint, error) {
+	sp, ok := store.GetSprintByID(id)
+	if !ok || sp.ProjectKey != projectKey {
+		return Sprint{}, ErrSprintNotFound
+	}
+
+	return sp, nil
+}
+
+// doneAt reports whether the issue was in a DONE status at t. Without
+// history only the current status is known.
+func doneAt(wf Workflow, i Issue, history []StatusChange, t time.Time) bool {
+	status := statusAt(i, history, t)
+
+	return status != "" && categoryOf(wf, status) == CategoryDone
+}
+
+// statusAt returns the status of the issue at t, or "" if it did not exist
+// yet. Without history only the current status is known.
+func statusAt(i Issue, history []StatusChange, t time.Time) string {
+	if len(history) == 0 {
+		if i.CreatedAt.After(t) {
+			return ""
+		}
+		return i.Status
+	}
+
+	status := ""
+	for _, c := range history {
+		if c.At.After(t) {
+			break
+		}
+		status = c.To
+	}
+
+	return status
+}
@@ -0,0 +1,270 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxRuleChain bounds how many rules may fire one after another from
+	// a single change, whatever the rules are.
+	MaxRuleChain = 5
+	// MaxRuleExecutions is how many audit log entries a store keeps.
+	MaxRuleExecutions = 1000
+)
+
+// CreateRule adds an automation rule to a project. The ID and ProjectKey
+// of r are ignored.
+func CreateRule(store Store, projectKey string, r Rule) (Rule, error) {
+	r.Name = strings.TrimSpace(r.Name)
+	if err := collect(ErrInvalidRule, checkRule(r)); err != nil {
+		return Rule{}, err
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	r.ID = 0
+	r.ProjectKey = p.Key
+
+	return store.CreateRule(r), nil
+}
+
+func GetRule(store Store, id int) (Rule, error) {
+	if id <= 0 {
+		return Rule{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	r, ok := store.GetRuleByID(id)
+	if !ok {
+		return Rule{}, ErrRuleNotFound
+	}
+
+	return r, nil
+}
+
+func ListRules(store Store, projectKey string) ([]Rule, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListRulesByProjectKey(p.Key), nil
+}
+
+func UpdateRule(store Store, id int, patch RulePatch) (Rule, error) {
+	r, err := GetRule(store, id)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	if patch.Name != nil {
+		r.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Enabled != nil {
+		r.Enabled = *patch.Enabled
+	}
+	if patch.Trigger != nil {
+		r.Trigger = *patch.Trigger
+	}
+	if patch.Conditions != nil {
+		r.Conditions = *patch.Conditions
+	}
+	if patch.Actions != nil {
+		r.Actions = *patch.Actions
+	}
+	if err := collect(ErrInvalidRule, checkRule(r)); err != nil {
+		return Rule{}, err
+	}
+
+	updated, ok := store.UpdateRule(r)
+	if !ok {
+		return Rule{}, ErrRuleNotFound
+	}
+
+	return updated, nil
+}
+
+// DeleteRule removes a rule; its audit log entries stay.
+func DeleteRule(store Store, id int) error {
+	r, err := GetRule(store, id)
+	if err != nil {
+		return err
+	}
+	if !store.DeleteRule(r.ID) {
+		return ErrRuleNotFound
+	}
+
+	return nil
+}
+
+// Matches reports whether an issue meets every condition of the rule.
+// from and to are the statuses of the transition that fired it, if any.
+func (r Rule) Matches(i Issue, from, to string) bool {
+	for _, c := range r.Conditions {
+		var ok bool
+		switch c.Field {
+		case ConditionType:
+			ok = i.Type == c.Value
+		case ConditionPriority:
+			ok = i.Priority == c.Value
+		case ConditionStatus:
+			ok = i.Status == c.Value
+		case ConditionAssignee:
+			ok = i.Assignee == c.Value
+		case ConditionLabel:
+			ok = slices.Contains(i.Labels, c.Value)
+		case ConditionFromStatus:
+			ok = from == c.Value
+		case ConditionToStatus:
+			ok = to == c.Value
+		}
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RecordRuleExecution adds an entry to the automation audit log.
+func RecordRuleExecution(store Store, x RuleExecution) RuleExecution {
+	return store.AddRuleExecution(x)
+}
+
+// ListRuleExecutions returns up to limit audit log entries of a project,
+// newest first. A limit of 0 means all that are kept.
+func ListRuleExecutions(store Store, projectKey string, limit int) ([]RuleExecution, error) {
+	if limit < 0 {
+		return nil, NewValidationError(ErrInvalidRule, notNegative("limit"))
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	log := store.ListRuleExecutions(p.Key)
+	slices.Reverse(log)
+	if limit > 0 && len(log) > limit {
+		log = log[:limit]
+	}
+
+	return log, nil
+}
+
+// DoneParent returns the parent of a subtask when the parent is not done
+// yet and every one of its subtasks is in a DONE status. Issues under an
+// epic do not count as subtasks.
+func DoneParent(store Store, issueID int) (Issue, bool, error) {
+	issue, err := GetIssue(store, issueID)
+	if err != nil {
+		return Issue{}, false, err
+	}
+	if issue.Type != TypeSubtask || issue.ParentID == 0 {
+		return Issue{}, false, nil
+	}
+
+	parent, ok := store.GetIssueByID(issue.ParentID)
+	if !ok {
+		return Issue{}, false, nil
+	}
+
+	wf := GetWorkflow(store, parent.ProjectKey)
+	if categoryOf(wf, parent.Status) == CategoryDone {
+		return Issue{}, false, nil
+	}
+	for _, i := range store.ListIssuesByProjectKey(parent.ProjectKey) {
+		if i.ParentID == parent.ID && i.Type == TypeSubtask && categoryOf(wf, i.Status) != CategoryDone {
+			return Issue{}, false, nil
+		}
+	}
+
+	return parent, true, nil
+}
+
+func checkRule(r Rule) []FieldError {
+	var fields []FieldError
+	if r.Name == "" {
+		fields = append(fields, required("name"))
+	}
+	if !slices.Contains(RuleTriggers, r.Trigger) {
+		fields = append(fields, FieldError{Field: "trigger", Code: FieldInvalid, Message: "must be one of " + strings.Join(RuleTriggers, ", ")})
+	}
+
+	for _, c := range r.Conditions {
+		if msg := checkCondition(c); msg != "" {
+			fields = append(fields, FieldError{Field: "conditions", Code: FieldInvalid, Message: msg})
+			break
+		}
+	}
+
+	if len(r.Actions) == 0 {
+		fields = append(fields, required("actions"))
+	}
+	for _, a := range r.Actions {
+		if msg := checkAction(a); msg != "" {
+			fields = append(fields, FieldError{Field: "actions", Code: FieldInvalid, Message: msg})
+			break
+		}
+	}
+
+	return fields
+}
+
+func checkCondition(c RuleCondition) string {
+	switch c.Field {
+	case ConditionType:
+		if !slices.Contains(IssueTypes, c.Value) {
+			return "type must be one of " + strings.Join(IssueTypes, ", ")
+		}
+	case ConditionPriority:
+		if !slices.Contains(Priorities, c.Value) {
+			return "priority must be one of " + strings.Join(Priorities, ", ")
+		}
+	case ConditionLabel:
+		if !ValidLabel(c.Value) {
+			return "label must be non-empty and contain no spaces"
+		}
+	case ConditionStatus, ConditionFromStatus, ConditionToStatus:
+		if strings.TrimSpace(c.Value) == "" {
+			return c.Field + " must not be empty"
+		}
+	case ConditionAssignee:
+	default:
+		return "field must be one of " + strings.Join(RuleConditionFields, ", ")
+	}
+
+	return ""
+}
+
+func checkAction(a RuleAction) string {
+	switch a.Type {
+	case ActionAddLabel, ActionRemoveLabel:
+		if !ValidLabel(a.Value) {
+			return a.Type + " needs a label without spaces"
+		}
+	case ActionSetPriority:
+		if !slices.Contains(Priorities, a.Value) {
+			return "set_priority needs one of " + strings.Join(Priorities, ", ")
+		}
+	case ActionTransition, ActionComment:
+		if strings.TrimSpace(a.Value) == "" {
+			return a.Type + " needs a value"
+		}
+	case ActionAssign:
+	default:
+		return "type must be one of " + strings.Join(RuleActionTypes, ", ")
+	}
+	switch {
+	case a.Resolution == "":
+	case a.Type != ActionTransition:
+		return "only transition takes a resolution"
+	case !slices.Contains(Resolutions, a.Resolution):
+		return "resolution must be one of " + strings.Join(Resolutions, ", ")
+	}
+
+	return ""
+}
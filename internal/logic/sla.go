@@ -0,0 +1,368 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// maxCalendarDays bounds the search for the end of a target, for
+// calendars whose holidays leave hardly any business days.
+const maxCalendarDays = 3660
+
+// CreateSLAPolicy adds an SLA policy to a project. Its statuses must be
+// statuses of the project's workflow. The ID and ProjectKey of p are
+// ignored.
+func CreateSLAPolicy(store Store, projectKey string, p SLAPolicy) (SLAPolicy, error) {
+	project, err := GetProject(store, projectKey)
+	if err != nil {
+		return SLAPolicy{}, err
+	}
+
+	p.ID = 0
+	p.ProjectKey = project.Key
+	p.Name = strings.TrimSpace(p.Name)
+	if err := collect(ErrInvalidSLA, checkSLAPolicy(GetWorkflow(store, p.ProjectKey), p)); err != nil {
+		return SLAPolicy{}, err
+	}
+
+	return store.CreateSLAPolicy(p), nil
+}
+
+func GetSLAPolicy(store Store, id int) (SLAPolicy, error) {
+	if id <= 0 {
+		return SLAPolicy{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	p, ok := store.GetSLAPolicyByID(id)
+	if !ok {
+		return SLAPolicy{}, ErrSLANotFound
+	}
+
+	return p, nil
+}
+
+func ListSLAPolicies(store Store, projectKey string) ([]SLAPolicy, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListSLAPoliciesByProjectKey(p.Key), nil
+}
+
+func UpdateSLAPolicy(store Store, id int, patch SLAPatch) (SLAPolicy, error) {
+	p, err := GetSLAPolicy(store, id)
+	if err != nil {
+		return SLAPolicy{}, err
+	}
+
+	if patch.Name != nil {
+		p.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Target != nil {
+		p.Target = *patch.Target
+	}
+	if patch.StartStatuses != nil {
+		p.StartStatuses = *patch.StartStatuses
+	}
+	if patch.PauseStatuses != nil {
+		p.PauseStatuses = *patch.PauseStatuses
+	}
+	if patch.StopStatuses != nil {
+		p.StopStatuses = *patch.StopStatuses
+	}
+	if patch.Calendar != nil {
+		p.Calendar = *patch.Calendar
+	}
+	if err := collect(ErrInvalidSLA, checkSLAPolicy(GetWorkflow(store, p.ProjectKey), p)); err != nil {
+		return SLAPolicy{}, err
+	}
+
+	updated, ok := store.UpdateSLAPolicy(p)
+	if !ok {
+		return SLAPolicy{}, ErrSLANotFound
+	}
+
+	return updated, nil
+}
+
+func DeleteSLAPolicy(store Store, id int) error {
+	p, err := GetSLAPolicy(store, id)
+	if err != nil {
+		return err
+	}
+	if !store.DeleteSLAPolicy(p.ID) {
+		return ErrSLANotFound
+	}
+
+	return nil
+}
+
+// SLATimers returns the timers of the issues against the SLA policies of
+// their projects, by issue ID, in the order the policies were created.
+func SLATimers(store Store, issues []Issue, now time.Time) map[int][]SLATimer {
+	policies := make(map[string][]SLAPolicy)
+	res := make(map[int][]SLATimer, len(issues))
+	for _, i := range issues {
+		ps, ok := policies[i.ProjectKey]
+		if !ok {
+			ps = store.ListSLAPoliciesByProjectKey(i.ProjectKey)
+			policies[i.ProjectKey] = ps
+		}
+		if len(ps) == 0 {
+			continue
+		}
+
+		history := issueHistory(store, i)
+		timers := make([]SLATimer, len(ps))
+		for n, p := range ps {
+			timers[n] = p.Timer(history, now)
+		}
+		res[i.ID] = timers
+	}
+
+	return res
+}
+
+// RecordSLABreaches finds the SLA timers of every project that are over
+// their target and records their breaches. It returns only the breaches
+// not recorded before, so each is reported once. Run it in a transaction.
+func RecordSLABreaches(store Store, now time.Time) []BreachedSLA {
+	var res []BreachedSLA
+	for _, p := range store.List() {
+		if len(store.ListSLAPoliciesByProjectKey(p.Key)) == 0 {
+			continue
+		}
+
+		issues := store.ListIssuesByProjectKey(p.Key)
+		timers := SLATimers(store, issues, now)
+		for _, i := range issues {
+			for _, t := range timers[i.ID] {
+				if t.Breached && store.AddSLABreach(SLABreach{PolicyID: t.PolicyID, IssueID: i.ID, At: now}) {
+					res = append(res, BreachedSLA{Issue: i, Timer: t})
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// Timer replays the status history of an issue, oldest first, against the
+// policy. A timer runs once: after it stops, it stays stopped even if the
+// issue enters a start status again.
+func (p SLAPolicy) Timer(history []StatusChange, now time.Time) SLATimer {
+	t := SLATimer{PolicyID: p.ID, Name: p.Name, State: SLANotStarted, Target: p.Target}
+
+	for n, c := range history {
+		switch {
+		case t.State == SLANotStarted && slices.Contains(p.StartStatuses, c.To):
+			t.StartedAt = c.At
+		case t.State != SLANotStarted && slices.Contains(p.StopStatuses, c.To):
+			t.State = SLAStopped
+			t.StoppedAt = c.At
+		}
+		if t.State == SLAStopped {
+			break
+		}
+		if t.StartedAt.IsZero() {
+			continue
+		}
+
+		t.State = SLARunning
+		if slices.Contains(p.PauseStatuses, c.To) {
+			t.State = SLAPaused
+			continue
+		}
+		end := now
+		if n+1 < len(history) {
+			end = history[n+1].At
+		}
+		t.Elapsed += p.Calendar.Between(c.At, end)
+	}
+
+	t.Remaining = t.Target - t.Elapsed
+	t.Breached = t.Remaining < 0
+	if t.State == SLARunning && !t.Breached {
+		t.DueAt = p.Calendar.Add(now, t.Remaining)
+	}
+
+	return t
+}
+
+// Between returns the business time from one moment to another.
+func (c Calendar) Between(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if c.aroundTheClock() {
+		return to.Sub(from)
+	}
+
+	var d time.Duration
+	for day := c.midnight(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		opens, closes, ok := c.hours(day)
+		if !ok {
+			continue
+		}
+		start, end := later(opens, from), earlier(closes, to)
+		if end.After(start) {
+			d += end.Sub(start)
+		}
+	}
+
+	return d
+}
+
+// Add returns the moment d of business time after from. It returns the
+// zero time if the calendar has no business hours in the next ten years.
+func (c Calendar) Add(from time.Time, d time.Duration) time.Time {
+	if c.aroundTheClock() {
+		return from.Add(d)
+	}
+
+	day := c.midnight(from)
+	for range maxCalendarDays {
+		opens, closes, ok := c.hours(day)
+		day = day.AddDate(0, 0, 1)
+		if !ok {
+			continue
+		}
+		start := later(opens, from)
+		if !closes.After(start) {
+			continue
+		}
+		left := closes.Sub(start)
+		if d <= left {
+			return start.Add(d)
+		}
+		d -= left
+	}
+
+	return time.Time{}
+}
+
+func (c Calendar) aroundTheClock() bool {
+	return len(c.Workdays) == 0 && c.Opens == 0 && c.Closes == 0 && len(c.Holidays) == 0
+}
+
+func (c Calendar) midnight(t time.Time) time.Time {
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// hours returns the business hours of the day starting at midnight day,
+// or false if the day has none.
+func (c Calendar) hours(day time.Time) (time.Time, time.Time, bool) {
+	if len(c.Workdays) > 0 && !slices.Contains(c.Workdays, day.Weekday()) {
+		return time.Time{}, time.Time{}, false
+	}
+	y, m, d := day.Date()
+	for _, h := range c.Holidays {
+		if hy, hm, hd := h.Date(); hy == y && hm == m && hd == d {
+			return time.Time{}, time.Time{}, false
+		}
+	}
+
+	opens, closes := c.Opens, c.Closes
+	if opens == 0 && closes == 0 {
+		closes = 24 * time.Hour
+	}
+	loc := day.Location()
+
+	return time.Date(y, m, d, 0, int(opens/time.Minute), 0, 0, loc),
+		time.Date(y, m, d, 0, int(closes/time.Minute), 0, 0, loc), true
+}
+
+// issueHistory returns the status changes of an issue. Issues without any,
+// such as imported ones, are taken to have been in their status since
+// they were created.
+func issueHistory(store Store, i Issue) []StatusChange {
+	if h := store.ListStatusChanges(i.ID); len(h) > 0 {
+		return h
+	}
+
+	return []StatusChange{{IssueID: i.ID, To: i.Status, At: i.CreatedAt}}
+}
+
+func checkSLAPolicy(wf Workflow, p SLAPolicy) []FieldError {
+	var fields []FieldError
+	if p.Name == "" {
+		fields = append(fields, required("name"))
+	}
+	if p.Target <= 0 {
+		fields = append(fields, FieldError{Field: "target", Code: FieldInvalid, Message: "must be a positive duration"})
+	}
+
+	lists := []struct {
+		field    string
+		statuses []string
+	}{
+		{"start_statuses", p.StartStatuses},
+		{"pause_statuses", p.PauseStatuses},
+		{"stop_statuses", p.StopStatuses},
+	}
+	for _, l := range lists {
+		if l.field != "pause_statuses" && len(l.statuses) == 0 {
+			fields = append(fields, required(l.field))
+		}
+		for _, s := range l.statuses {
+			if !wf.HasStatus(s) {
+				fields = append(fields, FieldError{Field: l.field, Code: FieldInvalid, Message: "unknown status " + s})
+				break
+			}
+		}
+	}
+	for _, s := range p.StopStatuses {
+		if slices.Contains(p.StartStatuses, s) || slices.Contains(p.PauseStatuses, s) {
+			fields = append(fields, FieldError{Field: "stop_statuses", Code: FieldInvalid, Message: "must not be a start or pause status: " + s})
+			break
+		}
+	}
+
+	return append(fields, checkCalendar(p.Calendar)...)
+}
+
+func checkCalendar(c Calendar) []FieldError {
+	var fields []FieldError
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		fields = append(fields, FieldError{Field: "calendar.time_zone", Code: FieldInvalid, Message: "unknown time zone " + c.TimeZone})
+	}
+	for _, d := range c.Workdays {
+		if d < time.Sunday || d > time.Saturday {
+			fields = append(fields, FieldError{Field: "calendar.workdays", Code: FieldInvalid, Message: "must be days of the week"})
+			break
+		}
+	}
+
+	hours := c.Opens%time.Minute == 0 && c.Closes%time.Minute == 0 &&
+		c.Opens >= 0 && c.Closes <= 24*time.Hour
+	if !hours || (c.Opens >= c.Closes && (c.Opens != 0 || c.Closes != 0)) {
+		fields = append(fields, FieldError{Field: "calendar.hours", Code: FieldInvalid, Message: "opens must come before closes, in whole minutes within the day"})
+	}
+
+	return fields
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
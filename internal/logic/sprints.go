@@ -0,0 +1,137 @@
+package logic
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultSprintLength is the length of a sprint started without an end.
+const DefaultSprintLength = 14 * 24 * time.Hour
+
+// StartSprint makes a future sprint the active one of its project and
+// records its scope as the commitment. A zero endsAt means
+// DefaultSprintLength from at.
+func StartSprint(store Store, id int, endsAt, at time.Time) (Sprint, error) {
+	if id <= 0 {
+		return Sprint{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+	if endsAt.IsZero() {
+		endsAt = at.Add(DefaultSprintLength)
+	}
+	if !endsAt.After(at) {
+		return Sprint{}, NewValidationError(ErrInvalidSprint, FieldError{Field: "ends_at", Code: FieldInvalid, Message: "must be in the future"})
+	}
+
+	sp, ok := store.GetSprintByID(id)
+	if !ok {
+		return Sprint{}, ErrSprintNotFound
+	}
+	if state := sprintState(sp); state != SprintFuture {
+		return Sprint{}, fmt.Errorf("%w: sprint %d is %s", ErrInvalidSprintState, sp.ID, state)
+	}
+	for _, other := range store.ListSprintsByProjectKey(sp.ProjectKey) {
+		if other.State == SprintActive {
+			return Sprint{}, fmt.Errorf("%w: sprint %d of %s is already active", ErrInvalidSprintState, other.ID, sp.ProjectKey)
+		}
+	}
+
+	sp.State = SprintActive
+	sp.StartedAt = at
+	sp.EndsAt = endsAt
+	for _, i := range sprintIssues(store, sp) {
+		sp.CommittedIssues++
+		sp.CommittedPoints += i.StoryPoints
+	}
+
+	updated, ok := store.UpdateSprint(sp)
+	if !ok {
+		return Sprint{}, ErrSprintNotFound
+	}
+
+	return updated, nil
+}
+
+// CloseSprint closes the active sprint and moves its unfinished issues to
+// the future sprint moveTo, or to the backlog when moveTo is 0. Run it in
+// a transaction to keep the moves atomic. The moved issues are returned
+// with the sprint.
+func CloseSprint(store Store, id, moveTo int, at time.Time) (Sprint, []Issue, error) {
+	var fields []FieldError
+	if id <= 0 {
+		fields = append(fields, positive("id"))
+	}
+	if moveTo < 0 || (moveTo != 0 && moveTo == id) {
+		fields = append(fields, FieldError{Field: "move_to_sprint_id", Code: FieldInvalid, Message: "must be another sprint id or 0"})
+	}
+	if err := collect(ErrInvalidSprint, fields); err != nil {
+		return Sprint{}, nil, err
+	}
+
+	sp, ok := store.GetSprintByID(id)
+	if !ok {
+		return Sprint{}, nil, ErrSprintNotFound
+	}
+	if state := sprintState(sp); state != SprintActive {
+		return Sprint{}, nil, fmt.Errorf("%w: sprint %d is %s", ErrInvalidSprintState, sp.ID, state)
+	}
+	if moveTo != 0 {
+		next, ok := store.GetSprintByID(moveTo)
+		if !ok {
+			return Sprint{}, nil, ErrSprintNotFound
+		}
+		if next.ProjectKey != sp.ProjectKey || sprintState(next) != SprintFuture {
+			return Sprint{}, nil, NewValidationError(ErrInvalidSprint, FieldError{
+				Field:   "move_to_sprint_id",
+				Code:    FieldInvalid,
+				Message: "must be a future sprint of the same project",
+			})
+		}
+	}
+
+	wf := GetWorkflow(store, sp.ProjectKey)
+	var moved []Issue
+	for _, i := range sprintIssues(store, sp) {
+		if categoryOf(wf, i.Status) == CategoryDone {
+			sp.CompletedIssues++
+			sp.CompletedPoints += i.StoryPoints
+			continue
+		}
+
+		i.SprintID = moveTo
+		updated, ok := store.UpdateIssue(i)
+		if !ok {
+			return Sprint{}, nil, ErrIssueNotFound
+		}
+		sp.Incomplete = append(sp.Incomplete, i.ID)
+		moved = append(moved, updated)
+	}
+
+	sp.State = SprintClosed
+	sp.ClosedAt = at
+	updated, ok := store.UpdateSprint(sp)
+	if !ok {
+		return Sprint{}, nil, ErrSprintNotFound
+	}
+
+	return updated, moved, nil
+}
+
+// sprintState treats sprints stored before sprint states as future ones.
+func sprintState(sp Sprint) string {
+	if sp.State == "" {
+		return SprintFuture
+	}
+
+	return sp.State
+}
+
+func sprintIssues(store Store, sp Sprint) []Issue {
+	var res []Issue
+	for _, i := range store.ListIssuesByProjectKey(sp.ProjectKey) {
+		if i.SprintID == sp.ID {
+			res = append(res, i)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,270 @@
+package logic
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReleaseNotesGroup lists the done issues of one type in board order.
+type ReleaseNotesGroup struct {
+	Type   string
+	Issues []Issue
+}
+
+// ReleaseNotes covers the issues of a version that are in a DONE status,
+// grouped by type in the order of IssueTypes. Empty groups are left out.
+type ReleaseNotes struct {
+	Version Version
+	Groups  []ReleaseNotesGroup
+}
+
+// CreateVersion adds an unreleased version to a project. Names are unique
+// within a project; releaseDate may be zero.
+func CreateVersion(store Store, projectKey, name, description string, releaseDate time.Time) (Version, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Version{}, NewValidationError(ErrInvalidVersion, required("name"))
+	}
+
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Version{}, err
+	}
+	if err := checkVersionName(store, p.Key, 0, name); err != nil {
+		return Version{}, err
+	}
+
+	return store.CreateVersion(Version{
+		ProjectKey:  p.Key,
+		Name:        name,
+		Description: strings.TrimSpace(description),
+		State:       VersionUnreleased,
+		ReleaseDate: dateOf(releaseDate),
+	}), nil
+}
+
+func GetVersion(store Store, id int) (Version, error) {
+	if id <= 0 {
+		return Version{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	v, ok := store.GetVersionByID(id)
+	if !ok {
+		return Version{}, ErrVersionNotFound
+	}
+
+	return v, nil
+}
+
+func ListVersions(store Store, projectKey string) ([]Version, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.ListVersionsByProjectKey(p.Key), nil
+}
+
+func UpdateVersion(store Store, id int, patch VersionPatch) (Version, error) {
+	if patch.Name != nil && strings.TrimSpace(*patch.Name) == "" {
+		return Version{}, NewValidationError(ErrInvalidVersion, required("name"))
+	}
+
+	v, err := GetVersion(store, id)
+	if err != nil {
+		return Version{}, err
+	}
+
+	if patch.Name != nil {
+		name := strings.TrimSpace(*patch.Name)
+		if err := checkVersionName(store, v.ProjectKey, v.ID, name); err != nil {
+			return Version{}, err
+		}
+		v.Name = name
+	}
+	if patch.Description != nil {
+		v.Description = strings.TrimSpace(*patch.Description)
+	}
+	if patch.ReleaseDate != nil {
+		v.ReleaseDate = dateOf(*patch.ReleaseDate)
+	}
+
+	updated, ok := store.UpdateVersion(v)
+	if !ok {
+		return Version{}, ErrVersionNotFound
+	}
+
+	return updated, nil
+}
+
+// ReleaseVersion marks an unreleased version released. Its issues that are
+// not done must go somewhere else: moveTo names an unreleased version of
+// the same project, and without one the release fails with
+// ErrUnfinishedIssues. A zero releaseDate keeps the planned date, or uses
+// the day of at if none was planned. Run it in a transaction to keep the
+// moves atomic; the moved issues are returned with the version.
+func ReleaseVersion(store Store, id, moveTo int, releaseDate, at time.Time) (Version, []Issue, error) {
+	if moveTo < 0 || (moveTo != 0 && moveTo == id) {
+		return Version{}, nil, NewValidationError(ErrInvalidVersion, FieldError{
+			Field:   "move_to_version_id",
+			Code:    FieldInvalid,
+			Message: "must be another version id or 0",
+		})
+	}
+
+	v, err := GetVersion(store, id)
+	if err != nil {
+		return Version{}, nil, err
+	}
+	if v.State != VersionUnreleased {
+		return Version{}, nil, fmt.Errorf("%w: version %s is %s", ErrInvalidVersionState, v.Name, v.State)
+	}
+
+	wf := GetWorkflow(store, v.ProjectKey)
+	var unfinished []Issue
+	for _, i := range store.ListIssuesByProjectKey(v.ProjectKey) {
+		if i.FixVersionID == v.ID && categoryOf(wf, i.Status) != CategoryDone {
+			unfinished = append(unfinished, i)
+		}
+	}
+	if len(unfinished) > 0 && moveTo == 0 {
+		return Version{}, nil, fmt.Errorf("%w: %d issues of %s are not done; move them to another version", ErrUnfinishedIssues, len(unfinished), v.Name)
+	}
+	if moveTo != 0 {
+		next, err := GetVersion(store, moveTo)
+		if err != nil {
+			return Version{}, nil, err
+		}
+		if next.ProjectKey != v.ProjectKey || next.State != VersionUnreleased {
+			return Version{}, nil, NewValidationError(ErrInvalidVersion, FieldError{
+				Field:   "move_to_version_id",
+				Code:    FieldInvalid,
+				Message: "must be an unreleased version of the same project",
+			})
+		}
+	}
+
+	var moved []Issue
+	for _, i := range unfinished {
+		i.FixVersionID = moveTo
+		updated, ok := store.UpdateIssue(i)
+		if !ok {
+			return Version{}, nil, ErrIssueNotFound
+		}
+		moved = append(moved, updated)
+	}
+
+	switch {
+	case !releaseDate.IsZero():
+		v.ReleaseDate = dateOf(releaseDate)
+	case v.ReleaseDate.IsZero():
+		v.ReleaseDate = dateOf(at)
+	}
+	v.State = VersionReleased
+	v.ReleasedAt = at
+
+	updated, ok := store.UpdateVersion(v)
+	if !ok {
+		return Version{}, nil, ErrVersionNotFound
+	}
+
+	return updated, moved, nil
+}
+
+// ArchiveVersion hides a version from planning. Archived versions keep
+// their issues but take no new ones.
+func ArchiveVersion(store Store, id int) (Version, error) {
+	v, err := GetVersion(store, id)
+	if err != nil {
+		return Version{}, err
+	}
+	if v.State == VersionArchived {
+		return Version{}, fmt.Errorf("%w: version %s is already archived", ErrInvalidVersionState, v.Name)
+	}
+
+	v.State = VersionArchived
+	updated, ok := store.UpdateVersion(v)
+	if !ok {
+		return Version{}, ErrVersionNotFound
+	}
+
+	return updated, nil
+}
+
+// GetReleaseNotes collects the done issues of a version.
+func GetReleaseNotes(store Store, id int) (ReleaseNotes, error) {
+	v, err := GetVersion(store, id)
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	issues, err := FindIssues(store, IssueQuery{ProjectKey: v.ProjectKey, FixVersionID: &v.ID})
+	if err != nil {
+		return ReleaseNotes{}, err
+	}
+
+	wf := GetWorkflow(store, v.ProjectKey)
+	notes := ReleaseNotes{Version: v, Groups: []ReleaseNotesGroup{}}
+	for _, typ := range IssueTypes {
+		g := ReleaseNotesGroup{Type: typ}
+		for _, i := range issues {
+			if i.Type == typ && categoryOf(wf, i.Status) == CategoryDone {
+				g.Issues = append(g.Issues, i)
+			}
+		}
+		if len(g.Issues) > 0 {
+			notes.Groups = append(notes.Groups, g)
+		}
+	}
+
+	return notes, nil
+}
+
+// checkFixVersion rejects versions of other projects and archived ones,
+// and released ones unless the issue is done: a release ships finished
+// work only.
+func checkFixVersion(store Store, issue Issue, id int) error {
+	v, ok := store.GetVersionByID(id)
+	if !ok {
+		return ErrVersionNotFound
+	}
+	if v.ProjectKey != issue.ProjectKey || v.State == VersionArchived {
+		return NewValidationError(ErrInvalidVersion, FieldError{
+			Field:   "fix_version_id",
+			Code:    FieldInvalid,
+			Message: "must be a version of the same project that is not archived",
+		})
+	}
+	if v.State == VersionReleased && categoryOf(GetWorkflow(store, issue.ProjectKey), issue.Status) != CategoryDone {
+		return NewValidationError(ErrInvalidVersion, FieldError{
+			Field:   "fix_version_id",
+			Code:    FieldInvalid,
+			Message: "must not be a released version while the issue is not done",
+		})
+	}
+
+	return nil
+}
+
+func checkVersionName(store Store, projectKey string, id int, name string) error {
+	if slices.ContainsFunc(store.ListVersionsByProjectKey(projectKey), func(v Version) bool {
+		return v.ID != id && strings.EqualFold(v.Name, name)
+	}) {
+		return fmt.Errorf("%w: %s in %s", ErrVersionExists, name, projectKey)
+	}
+
+	return nil
+}
+
+// dateOf truncates t to its day in UTC.
+func dateOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+
+	y, m, d := t.UTC().Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,185 @@
+package logic
+
+import "slices"
+
+// Status categories group workflow statuses for boards and reports,
+// whatever the statuses are called.
+const (
+	CategoryTodo       = "TODO"
+	CategoryInProgress = "IN_PROGRESS"
+	CategoryDone       = "DONE"
+)
+
+type WorkflowStatus struct {
+	Name     string
+	Category string
+}
+
+// WorkflowTransition allows a move and names the hooks that guard and
+// complete it; see TransitionHooks for the names.
+type WorkflowTransition struct {
+	From          string
+	To            string
+	Conditions    []string
+	Validators    []string
+	PostFunctions []string
+}
+
+// Workflow lists the statuses of a project and the allowed moves between
+// them. With no transitions, any status may move to any other, and no
+// hooks run.
+type Workflow struct {
+	ProjectKey  string
+	Statuses    []WorkflowStatus
+	Transitions []WorkflowTransition
+}
+
+// DefaultWorkflow is used by projects that have not stored their own. DONE
+// issues may be reopened, which clears their resolution.
+func DefaultWorkflow(projectKey string) Workflow {
+	return Workflow{
+		ProjectKey: projectKey,
+		Statuses: []WorkflowStatus{
+			{Name: StatusOpen, Category: CategoryTodo},
+			{Name: StatusInProgress, Category: CategoryInProgress},
+			{Name: StatusDone, Category: CategoryDone},
+		},
+		Transitions: []WorkflowTransition{
+			{From: StatusOpen, To: StatusInProgress},
+			{From: StatusInProgress, To: StatusDone},
+			{From: StatusDone, To: StatusOpen},
+		},
+	}
+}
+
+func (w Workflow) HasStatus(name string) bool {
+	_, ok := w.Category(name)
+	return ok
+}
+
+func (w Workflow) Category(name string) (string, bool) {
+	for _, s := range w.Statuses {
+		if s.Name == name {
+			return s.Category, true
+		}
+	}
+
+	return "", false
+}
+
+// Initial is the status new issues start in: the first TODO status.
+func (w Workflow) Initial() string {
+	for _, s := range w.Statuses {
+		if s.Category == CategoryTodo {
+			return s.Name
+		}
+	}
+
+	return w.Statuses[0].Name
+}
+
+func (w Workflow) Allows(from, to string) bool {
+	_, ok := w.Transition(from, to)
+	return ok
+}
+
+// Transition returns the transition that allows moving from one status to
+// another, with its hooks.
+func (w Workflow) Transition(from, to string) (WorkflowTransition, bool) {
+	if from == to || !w.HasStatus(from) || !w.HasStatus(to) {
+		return WorkflowTransition{}, false
+	}
+	if len(w.Transitions) == 0 {
+		return WorkflowTransition{From: from, To: to}, true
+	}
+
+	i := slices.IndexFunc(w.Transitions, func(t WorkflowTransition) bool {
+		return t.From == from && t.To == to
+	})
+	if i < 0 {
+		return WorkflowTransition{}, false
+	}
+
+	return w.Transitions[i], true
+}
+
+// ValidateWorkflow checks that every status has a known category, names
+// are unique, at least one status is TODO and transitions refer to
+// listed statuses and registered hooks.
+func ValidateWorkflow(w Workflow) error {
+	var fields []FieldError
+	if len(w.Statuses) == 0 {
+		fields = append(fields, required("statuses"))
+	}
+
+	seen := make(map[string]bool, len(w.Statuses))
+	hasTodo := false
+	for _, s := range w.Statuses {
+		switch {
+		case s.Name == "":
+			fields = append(fields, required("statuses.name"))
+		case seen[s.Name]:
+			fields = append(fields, FieldError{Field: "statuses.name", Code: FieldInvalid, Message: "duplicate status " + s.Name})
+		}
+		seen[s.Name] = true
+
+		switch s.Category {
+		case CategoryTodo:
+			hasTodo = true
+		case CategoryInProgress, CategoryDone:
+		default:
+			fields = append(fields, FieldError{Field: "statuses.category", Code: FieldInvalid, Message: "must be one of TODO, IN_PROGRESS, DONE"})
+		}
+	}
+	if len(w.Statuses) > 0 && !hasTodo {
+		fields = append(fields, FieldError{Field: "statuses", Code: FieldInvalid, Message: "at least one status must be in category TODO"})
+	}
+
+	for _, t := range w.Transitions {
+		if !seen[t.From] || !seen[t.To] {
+			fields = append(fields, FieldError{Field: "transitions", Code: FieldInvalid, Message: "must refer to listed statuses"})
+			break
+		}
+		fields = append(fields, checkHooks(t)...)
+	}
+
+	return collect(ErrInvalidWorkflow, fields)
+}
+
+// SaveWorkflow replaces the workflow of a project. Every issue must stay
+// in a status the new workflow lists. Run it in a transaction so that no
+// issue moves in between.
+func SaveWorkflow(store Store, projectKey string, w Workflow) (Workflow, error) {
+	p, err := GetProject(store, projectKey)
+	if err != nil {
+		return Workflow{}, err
+	}
+
+	w.ProjectKey = p.Key
+	if err := ValidateWorkflow(w); err != nil {
+		return Workflow{}, err
+	}
+
+	var fields []FieldError
+	seen := make(map[string]bool)
+	for _, i := range store.ListIssuesByProjectKey(p.Key) {
+		if !w.HasStatus(i.Status) && !seen[i.Status] {
+			seen[i.Status] = true
+			fields = append(fields, FieldError{Field: "statuses", Code: FieldInvalid, Message: "issues are still in status " + i.Status})
+		}
+	}
+	if err := collect(ErrInvalidWorkflow, fields); err != nil {
+		return Workflow{}, err
+	}
+
+	return store.SaveWorkflow(w), nil
+}
+
+// GetWorkflow returns the stored workflow of a project or the default one.
+func GetWorkflow(store WorkflowStore, projectKey string) Workflow {
+	if w, ok := store.GetWorkflow(projectKey); ok {
+		return w
+	}
+
+	return DefaultWorkflow(projectKey)
+}
@@ -0,0 +1,223 @@
+package logic
+
+import (
+	"strings"
+	"time"
+)
+
+// TimeTracking sums the time estimates and time spent of Issues issues.
+type TimeTracking struct {
+	Issues            int
+	OriginalEstimate  time.Duration
+	RemainingEstimate time.Duration
+	TimeSpent         time.Duration
+}
+
+// LogWork records time spent on an issue and lowers its remaining
+// estimate by the same amount, down to zero. A zero startedAt means at.
+// Run it in a transaction to keep the worklog and the issue consistent;
+// the updated issue is returned with the worklog.
+func LogWork(store Store, issueID int, author string, spent time.Duration, startedAt time.Time, comment string, at time.Time) (Worklog, Issue, error) {
+	author = strings.TrimSpace(author)
+
+	var fields []FieldError
+	if issueID <= 0 {
+		fields = append(fields, positive("issue_id"))
+	}
+	if author == "" {
+		fields = append(fields, required("author"))
+	}
+	if spent <= 0 {
+		fields = append(fields, positive("time_spent_seconds"))
+	}
+	if err := collect(ErrInvalidWorklog, fields); err != nil {
+		return Worklog{}, Issue{}, err
+	}
+	if startedAt.IsZero() {
+		startedAt = at
+	}
+
+	issue, ok := store.GetIssueByID(issueID)
+	if !ok {
+		return Worklog{}, Issue{}, ErrIssueNotFound
+	}
+	reduced := reduction(issue, spent)
+	updated, err := logTime(store, issue, spent, reduced)
+	if err != nil {
+		return Worklog{}, Issue{}, err
+	}
+
+	w := store.CreateWorklog(Worklog{
+		IssueID:   issueID,
+		Author:    author,
+		Spent:     spent,
+		Reduced:   reduced,
+		StartedAt: startedAt,
+		Comment:   strings.TrimSpace(comment),
+		CreatedAt: at,
+		UpdatedAt: at,
+	})
+
+	return w, updated, nil
+}
+
+// UpdateWorklog changes a worklog and moves the remaining estimate of its
+// issue by the change in time spent. Less time gives back no more than the
+// worklog took off the estimate. Run it in a transaction.
+func UpdateWorklog(store Store, id int, patch WorklogPatch, at time.Time) (Worklog, Issue, error) {
+	if id <= 0 {
+		return Worklog{}, Issue{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+	if patch.Spent != nil && *patch.Spent <= 0 {
+		return Worklog{}, Issue{}, NewValidationError(ErrInvalidWorklog, positive("time_spent_seconds"))
+	}
+
+	w, ok := store.GetWorklogByID(id)
+	if !ok {
+		return Worklog{}, Issue{}, ErrWorklogNotFound
+	}
+	issue, ok := store.GetIssueByID(w.IssueID)
+	if !ok {
+		return Worklog{}, Issue{}, ErrIssueNotFound
+	}
+
+	if patch.Spent != nil && *patch.Spent != w.Spent {
+		reduced := min(w.Reduced, *patch.Spent)
+		if *patch.Spent > w.Spent {
+			reduced = w.Reduced + reduction(issue, *patch.Spent-w.Spent)
+		}
+
+		var err error
+		if issue, err = logTime(store, issue, *patch.Spent-w.Spent, reduced-w.Reduced); err != nil {
+			return Worklog{}, Issue{}, err
+		}
+		w.Spent = *patch.Spent
+		w.Reduced = reduced
+	}
+	if patch.StartedAt != nil && !patch.StartedAt.IsZero() {
+		w.StartedAt = *patch.StartedAt
+	}
+	if patch.Comment != nil {
+		w.Comment = strings.TrimSpace(*patch.Comment)
+	}
+	w.UpdatedAt = at
+
+	updated, ok := store.UpdateWorklog(w)
+	if !ok {
+		return Worklog{}, Issue{}, ErrWorklogNotFound
+	}
+
+	return updated, issue, nil
+}
+
+// DeleteWorklog removes a worklog and gives back to the remaining estimate
+// of the issue what the worklog took off it. Run it in a transaction.
+func DeleteWorklog(store Store, id int) (Issue, error) {
+	if id <= 0 {
+		return Issue{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	w, ok := store.GetWorklogByID(id)
+	if !ok {
+		return Issue{}, ErrWorklogNotFound
+	}
+	issue, ok := store.GetIssueByID(w.IssueID)
+	if !ok {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	updated, err := logTime(store, issue, -w.Spent, -w.Reduced)
+	if err != nil {
+		return Issue{}, err
+	}
+	if !store.DeleteWorklog(id) {
+		return Issue{}, ErrWorklogNotFound
+	}
+
+	return updated, nil
+}
+
+func ListWorklogs(store Store, issueID int) ([]Worklog, error) {
+	if _, err := GetIssue(store, issueID); err != nil {
+		return nil, err
+	}
+
+	return store.ListWorklogsByIssueID(issueID), nil
+}
+
+// IssueTimeTracking sums an issue with all issues below it, so an epic
+// includes its stories and their subtasks.
+func IssueTimeTracking(store Store, issueID int) (TimeTracking, error) {
+	issue, err := GetIssue(store, issueID)
+	if err != nil {
+		return TimeTracking{}, err
+	}
+
+	children := make(map[int][]Issue)
+	for _, i := range store.ListIssuesByProjectKey(issue.ProjectKey) {
+		if i.ParentID != 0 {
+			children[i.ParentID] = append(children[i.ParentID], i)
+		}
+	}
+
+	var t TimeTracking
+	queue := []Issue{issue}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		t.add(i)
+		queue = append(queue, children[i.ID]...)
+	}
+
+	return t, nil
+}
+
+// SprintTimeTracking sums the issues in a sprint.
+func SprintTimeTracking(store Store, sprintID int) (TimeTracking, error) {
+	if sprintID <= 0 {
+		return TimeTracking{}, NewValidationError(ErrInvalidID, positive("id"))
+	}
+
+	sp, ok := store.GetSprintByID(sprintID)
+	if !ok {
+		return TimeTracking{}, ErrSprintNotFound
+	}
+
+	var t TimeTracking
+	for _, i := range sprintIssues(store, sp) {
+		t.add(i)
+	}
+
+	return t, nil
+}
+
+func (t *TimeTracking) add(i Issue) {
+	t.Issues++
+	t.OriginalEstimate += i.OriginalEstimate
+	t.RemainingEstimate += i.RemainingEstimate
+	t.TimeSpent += i.TimeSpent
+}
+
+// logTime adds spent, which is negative for removed work, to the time spent
+// on the issue and takes reduced off its remaining estimate.
+func logTime(store Store, issue Issue, spent, reduced time.Duration) (Issue, error) {
+	issue.TimeSpent = max(0, issue.TimeSpent+spent)
+	issue.RemainingEstimate = max(0, issue.RemainingEstimate-reduced)
+
+	updated, ok := store.UpdateIssue(issue)
+	if !ok {
+		return Issue{}, ErrIssueNotFound
+	}
+
+	return updated, nil
+}
+
+// reduction is how much spent lowers the remaining estimate of issue: all
+// of it down to zero, and nothing for issues without a time estimate.
+func reduction(issue Issue, spent time.Duration) time.Duration {
+	if issue.OriginalEstimate == 0 && issue.RemainingEstimate == 0 {
+		return 0
+	}
+
+	return min(spent, issue.RemainingEstimate)
+}
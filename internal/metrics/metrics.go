@@ -0,0 +1,343 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Sample is one labelled value reported by a GaugeFunc at scrape time.
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+type collector interface {
+	write(w *bufio.Writer)
+}
+
+// Registry keeps metrics in registration order and renders them in the
+// Prometheus text exposition format.
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collectors = append(r.collectors, c)
+}
+
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.Lock()
+	collectors := make([]collector, len(r.collectors))
+	copy(collectors, r.collectors)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, c := range collectors {
+		c.write(bw)
+	}
+
+	return bw.Flush()
+}
+
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.WriteText(w)
+	})
+}
+
+type desc struct {
+	name   string
+	help   string
+	kind   string
+	labels []string
+}
+
+func (d desc) header(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", d.name, escapeHelp(d.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", d.name, d.kind)
+}
+
+// series holds per-label-set values of a vector, keyed by the joined label values.
+type series[T any] struct {
+	mu     sync.Mutex
+	values map[string]*T
+	labels map[string][]string
+}
+
+func newSeries[T any]() series[T] {
+	return series[T]{values: make(map[string]*T), labels: make(map[string][]string)}
+}
+
+func (s *series[T]) get(d desc, values []string, init func() *T) *T {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", d.name, len(d.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	v, ok := s.values[key]
+	if !ok {
+		v = init()
+		s.values[key] = v
+		s.labels[key] = append([]string(nil), values...)
+	}
+
+	return v
+}
+
+func (s *series[T]) sortedKeys() []string {
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+type CounterVec struct {
+	desc
+	series series[float64]
+}
+
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{name: name, help: help, kind: "counter", labels: labels},
+		series: newSeries[float64](),
+	}
+	r.register(c)
+
+	return c
+}
+
+func (c *CounterVec) Add(v float64, labels ...string) {
+	if v < 0 {
+		panic("metrics: counter cannot decrease")
+	}
+	c.series.mu.Lock()
+	defer c.series.mu.Unlock()
+
+	*c.series.get(c.desc, labels, newFloat) += v
+}
+
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.series.mu.Lock()
+	defer c.series.mu.Unlock()
+
+	c.header(w)
+	for _, k := range c.series.sortedKeys() {
+		writeSample(w, c.name, c.labels, c.series.labels[k], "", "", *c.series.values[k])
+	}
+}
+
+type GaugeVec struct {
+	desc
+	series series[float64]
+}
+
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{
+		desc:   desc{name: name, help: help, kind: "gauge", labels: labels},
+		series: newSeries[float64](),
+	}
+	r.register(g)
+
+	return g
+}
+
+func (g *GaugeVec) Add(v float64, labels ...string) {
+	g.series.mu.Lock()
+	defer g.series.mu.Unlock()
+
+	*g.series.get(g.desc, labels, newFloat) += v
+}
+
+func (g *GaugeVec) Set(v float64, labels ...string) {
+	g.series.mu.Lock()
+	defer g.series.mu.Unlock()
+
+	*g.series.get(g.desc, labels, newFloat) = v
+}
+
+func (g *GaugeVec) Inc(labels ...string) {
+	g.Add(1, labels...)
+}
+
+func (g *GaugeVec) Dec(labels ...string) {
+	g.Add(-1, labels...)
+}
+
+func (g *GaugeVec) write(w *bufio.Writer) {
+	g.series.mu.Lock()
+	defer g.series.mu.Unlock()
+
+	g.header(w)
+	for _, k := range g.series.sortedKeys() {
+		writeSample(w, g.name, g.labels, g.series.labels[k], "", "", *g.series.values[k])
+	}
+}
+
+// GaugeFunc computes its samples on every scrape, for values that are
+// cheaper to derive from the source of truth than to keep in sync.
+type GaugeFunc struct {
+	desc
+	collect func() []Sample
+}
+
+func (r *Registry) NewGaugeFunc(name, help string, labels []string, collect func() []Sample) *GaugeFunc {
+	g := &GaugeFunc{
+		desc:    desc{name: name, help: help, kind: "gauge", labels: labels},
+		collect: collect,
+	}
+	r.register(g)
+
+	return g
+}
+
+func (g *GaugeFunc) write(w *bufio.Writer) {
+	samples := g.collect()
+	sort.Slice(samples, func(a, b int) bool {
+		return strings.Join(samples[a].Labels, "\xff") < strings.Join(samples[b].Labels, "\xff")
+	})
+
+	g.header(w)
+	for _, s := range samples {
+		writeSample(w, g.name, g.labels, s.Labels, "", "", s.Value)
+	}
+}
+
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+type HistogramVec struct {
+	desc
+	buckets []float64
+	series  series[histogram]
+}
+
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	sorted := append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+
+	h := &HistogramVec{
+		desc:    desc{name: name, help: help, kind: "histogram", labels: labels},
+		buckets: sorted,
+		series:  newSeries[histogram](),
+	}
+	r.register(h)
+
+	return h
+}
+
+func (h *HistogramVec) Observe(v float64, labels ...string) {
+	h.series.mu.Lock()
+	defer h.series.mu.Unlock()
+
+	s := h.series.get(h.desc, labels, func() *histogram {
+		return &histogram{counts: make([]uint64, len(h.buckets))}
+	})
+	for i, upper := range h.buckets {
+		if v <= upper {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.series.mu.Lock()
+	defer h.series.mu.Unlock()
+
+	h.header(w)
+	for _, k := range h.series.sortedKeys() {
+		s := h.series.values[k]
+		values := h.series.labels[k]
+		for i, upper := range h.buckets {
+			writeSample(w, h.name+"_bucket", h.labels, values, "le", formatFloat(upper), float64(s.counts[i]))
+		}
+		writeSample(w, h.name+"_bucket", h.labels, values, "le", "+Inf", float64(s.count))
+		writeSample(w, h.name+"_sum", h.labels, values, "", "", s.sum)
+		writeSample(w, h.name+"_count", h.labels, values, "", "", float64(s.count))
+	}
+}
+
+func newFloat() *float64 {
+	return new(float64)
+}
+
+func writeSample(w *bufio.Writer, name string, labels, values []string, extraName, extraValue string, v float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || extraName != "" {
+		w.WriteByte('{')
+		for i, l := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, l, escapeLabel(values[i]))
+		}
+		if extraName != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, `%s="%s"`, extraName, extraValue)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
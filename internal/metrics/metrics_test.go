@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	reg := NewRegistry()
+
+	counter := reg.NewCounterVec("jobs_total", "Jobs processed.", "queue")
+	counter.Inc("default")
+	counter.Add(2, `with "quotes"`)
+
+	hist := reg.NewHistogramVec("latency_seconds", "Latency.", []float64{0.1, 1}, "route")
+	hist.Observe(0.05, "/a")
+	hist.Observe(0.5, "/a")
+
+	reg.NewGaugeFunc("items", "Items.", []string{"kind"}, func() []Sample {
+		return []Sample{{Labels: []string{"b"}, Value: 2}, {Labels: []string{"a"}, Value: 1}}
+	})
+
+	var sb strings.Builder
+	err := reg.WriteText(&sb)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	want := `# HELP jobs_total Jobs processed.
+# TYPE jobs_total counter
+jobs_total{queue="default"} 1
+jobs_total{queue="with \"quotes\""} 2
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/a",le="0.1"} 1
+latency_seconds_bucket{route="/a",le="1"} 2
+latency_seconds_bucket{route="/a",le="+Inf"} 2
+latency_seconds_sum{route="/a"} 0.55
+latency_seconds_count{route="/a"} 2
+# HELP items Items.
+# TYPE items gauge
+items{kind="a"} 1
+items{kind="b"} 2
+`
+	if sb.String() != want {
+		t.Fatalf("unexpected exposition:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
@@ -0,0 +1,287 @@
+// Package scheduler runs periodic background jobs, such as SLA checks and
+// backups, on cron-like schedules. A job never runs twice at the same
+// time: a run that comes due while the previous one is still going is
+// skipped. Shutdown stops the schedules and waits for running jobs, so it
+// fits in the server's graceful shutdown.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrJobNotFound = errors.New("job not found")
+	ErrJobRunning  = errors.New("job already running")
+	ErrStopped     = errors.New("scheduler stopped")
+)
+
+// Func is the work of a job. Its context is canceled when a shutdown
+// runs out of time.
+type Func func(ctx context.Context) error
+
+// Status describes a job and its latest run.
+type Status struct {
+	Name     string
+	Schedule string
+	Running  bool
+	// NextRun is zero while the scheduler is not running or the schedule
+	// has no further run.
+	NextRun      time.Time
+	LastStarted  time.Time
+	LastFinished time.Time
+	// LastError is the error of the latest finished run, empty if it
+	// succeeded.
+	LastError string
+	Runs      int
+	Failures  int
+	// Skipped counts runs that came due while the job was still running.
+	Skipped int
+}
+
+type job struct {
+	schedule Schedule
+	fn       Func
+	// status is guarded by the scheduler's mutex.
+	status Status
+}
+
+type Scheduler struct {
+	logger logrus.FieldLogger
+	now    func() time.Time
+
+	mu      sync.Mutex
+	jobs    []*job
+	started bool
+	stopped bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   chan struct{}
+	loops  sync.WaitGroup
+	runs   sync.WaitGroup
+}
+
+func New(logger logrus.FieldLogger) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		logger: logger,
+		now:    time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+	}
+}
+
+// Add registers a job. Jobs are added before Start; names are unique.
+func (s *Scheduler) Add(name string, schedule Schedule, fn Func) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started || s.stopped {
+		return fmt.Errorf("add job %q: scheduler already started", name)
+	}
+	if s.find(name) != nil {
+		return fmt.Errorf("add job %q: name already taken", name)
+	}
+	s.jobs = append(s.jobs, &job{
+		schedule: schedule,
+		fn:       fn,
+		status:   Status{Name: name, Schedule: schedule.String()},
+	})
+
+	return nil
+}
+
+// Start runs every job on its schedule until Shutdown.
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started || s.stopped {
+		return
+	}
+	s.started = true
+	for _, j := range s.jobs {
+		s.loops.Add(1)
+		go s.loop(j)
+	}
+}
+
+func (s *Scheduler) loop(j *job) {
+	defer s.loops.Done()
+
+	for {
+		now := s.now()
+		next := j.schedule.Next(now)
+		s.mu.Lock()
+		j.status.NextRun = next
+		s.mu.Unlock()
+		if next.IsZero() {
+			return
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := s.start(j); errors.Is(err, ErrJobRunning) {
+			s.mu.Lock()
+			j.status.Skipped++
+			s.mu.Unlock()
+			s.logger.WithField("job", j.status.Name).Warn("scheduler: previous run still going, skipped")
+		}
+	}
+}
+
+// Run starts a job now, outside its schedule, without waiting for it.
+func (s *Scheduler) Run(name string) (Status, error) {
+	s.mu.Lock()
+	j := s.find(name)
+	s.mu.Unlock()
+	if j == nil {
+		return Status{}, ErrJobNotFound
+	}
+
+	if err := s.start(j); err != nil {
+		return Status{}, err
+	}
+
+	return s.Job(name)
+}
+
+// start begins a run of j unless one is going or the scheduler stopped.
+func (s *Scheduler) start(j *job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case s.stopped:
+		return ErrStopped
+	case j.status.Running:
+		return ErrJobRunning
+	}
+	j.status.Running = true
+	j.status.LastStarted = s.now()
+	s.runs.Add(1)
+	go s.run(j)
+
+	return nil
+}
+
+func (s *Scheduler) run(j *job) {
+	defer s.runs.Done()
+
+	start := s.now()
+	err := call(s.ctx, j.fn)
+	finished := s.now()
+
+	s.mu.Lock()
+	j.status.Running = false
+	j.status.LastFinished = finished
+	j.status.LastError = ""
+	j.status.Runs++
+	if err != nil {
+		j.status.LastError = err.Error()
+		j.status.Failures++
+	}
+	name := j.status.Name
+	s.mu.Unlock()
+
+	log := s.logger.WithField("job", name).WithField("duration", finished.Sub(start))
+	if err != nil {
+		log.WithError(err).Error("scheduler: job failed")
+		return
+	}
+	log.Debug("scheduler: job done")
+}
+
+// call runs fn and turns a panic into an error, so that one broken job
+// does not bring the server down.
+func call(ctx context.Context, fn Func) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+
+	return fn(ctx)
+}
+
+// Jobs returns the status of every job, in the order they were added.
+func (s *Scheduler) Jobs() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]Status, len(s.jobs))
+	for i, j := range s.jobs {
+		res[i] = j.status
+	}
+
+	return res
+}
+
+func (s *Scheduler) Job(name string) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	j := s.find(name)
+	if j == nil {
+		return Status{}, ErrJobNotFound
+	}
+
+	return j.status, nil
+}
+
+func (s *Scheduler) find(name string) *job {
+	i := slices.IndexFunc(s.jobs, func(j *job) bool { return j.status.Name == name })
+	if i < 0 {
+		return nil
+	}
+
+	return s.jobs[i]
+}
+
+// Shutdown stops the schedules and waits for running jobs to finish. If
+// ctx ends first, it cancels the jobs' context and returns ctx.Err()
+// without waiting any longer.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+	s.mu.Unlock()
+	s.loops.Wait()
+
+	s.mu.Lock()
+	for _, j := range s.jobs {
+		j.status.NextRun = time.Time{}
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+	defer s.cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
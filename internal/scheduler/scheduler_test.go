@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestScheduler() *Scheduler {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return New(logger)
+}
+
+func TestParse_Next(t *testing.T) {
+	from := time.Date(2026, 10, 18, 12, 34, 56, 0, time.UTC) // a Sunday
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 18, 12, 35, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 18, 12, 45, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 10, 19, 2, 30, 0, 0, time.UTC)},
+		{"0 9-17 * * 1-5", time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * 3", time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"@every 90s", from.Add(90 * time.Second)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		if err != nil {
+			t.Fatalf("%q: expected no error, got %v", tt.spec, err)
+		}
+		if got := s.Next(from); !got.Equal(tt.want) {
+			t.Errorf("%q: expected %v, got %v", tt.spec, tt.want, got)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "5-1 * * * *", "*/0 * * * *", "@every", "@every -1m", "@often"} {
+		if _, err := Parse(spec); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("%q: expected ErrInvalidSchedule, got %v", spec, err)
+		}
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s := newTestScheduler()
+	started := make(chan struct{}, 16)
+	release := make(chan struct{})
+	err := s.Add("slow", Every(time.Millisecond), func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return errors.New("boom")
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s.Start()
+
+	<-started
+	time.Sleep(20 * time.Millisecond)
+	if _, err := s.Run("slow"); !errors.Is(err, ErrJobRunning) {
+		t.Fatalf("expected ErrJobRunning, got %v", err)
+	}
+	if _, err := s.Run("other"); !errors.Is(err, ErrJobNotFound) {
+		t.Fatalf("expected ErrJobNotFound, got %v", err)
+	}
+	if len(started) != 0 {
+		t.Fatalf("expected a single run, got %d more", len(started))
+	}
+
+	st, _ := s.Job("slow")
+	if !st.Running || st.Skipped == 0 || st.NextRun.IsZero() {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	close(release)
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	st, _ = s.Job("slow")
+	if st.Running || st.Runs == 0 || st.Failures != st.Runs || st.LastError != "boom" || !st.NextRun.IsZero() {
+		t.Fatalf("unexpected status %+v", st)
+	}
+	if _, err := s.Run("slow"); !errors.Is(err, ErrStopped) {
+		t.Fatalf("expected ErrStopped, got %v", err)
+	}
+}
+
+func TestScheduler_RunRecoversPanic(t *testing.T) {
+	s := newTestScheduler()
+	if err := s.Add("broken", Every(time.Hour), func(ctx context.Context) error { panic("nil map") }); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := s.Add("broken", Every(time.Hour), nil); err == nil {
+		t.Fatal("expected an error for a duplicate name")
+	}
+	s.Start()
+
+	if _, err := s.Run("broken"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	st, _ := s.Job("broken")
+	if st.Runs != 1 || st.Failures != 1 || st.LastError != "panic: nil map" {
+		t.Fatalf("unexpected status %+v", st)
+	}
+}
+
+func TestScheduler_ShutdownCancelsAfterTimeout(t *testing.T) {
+	s := newTestScheduler()
+	canceled := make(chan struct{})
+	err := s.Add("stuck", Every(time.Hour), func(ctx context.Context) error {
+		<-ctx.Done()
+		close(canceled)
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s.Start()
+	if _, err := s.Run("stuck"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded, got %v", err)
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("expected the job's context canceled")
+	}
+}
@@ -0,0 +1,34 @@
+// Package sla reports SLA breaches: each check goes over the SLA timers of
+// every issue and has the use-case layer publish an event for each new
+// breach. The scheduler runs the checks.
+package sla
+
+import (
+	"MiniJira/internal/usecase"
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Checker struct {
+	service *usecase.Service
+	logger  logrus.FieldLogger
+}
+
+func NewChecker(service *usecase.Service, logger logrus.FieldLogger) *Checker {
+	return &Checker{service: service, logger: logger}
+}
+
+// Check reports the breaches since the last check. After a failed check,
+// the next one reports what this one missed.
+func (c *Checker) Check(ctx context.Context) error {
+	n, err := c.service.CheckSLAs(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		c.logger.WithField("breaches", n).Info("sla: breaches reported")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package sla
+
+import (
+	"MiniJira/internal/events"
+	"MiniJira/internal/logic"
+	"MiniJira/internal/store/memory"
+	"MiniJira/internal/usecase"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestChecker_ReportsBreachOnce(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	service := usecase.NewService(memory.NewStore(), events.NewBus(), nil)
+	sub := service.Subscribe(16, nil)
+	defer sub.Close()
+
+	if _, err := service.CreateProject(ctx, "SUP", "Support"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	policy, err := service.CreateSLAPolicy(ctx, "SUP", logic.SLAPolicy{
+		Name:          "First response",
+		Target:        time.Millisecond,
+		StartStatuses: []string{logic.StatusOpen},
+		StopStatuses:  []string{logic.StatusInProgress},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	issue, err := service.CreateIssue(ctx, "SUP", "Cannot log in")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	<-sub.Events()
+	time.Sleep(5 * time.Millisecond)
+
+	checker := NewChecker(service, logger)
+	for range 2 {
+		if err := checker.Check(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	var breaches []events.Event
+	for len(sub.Events()) > 0 {
+		breaches = append(breaches, <-sub.Events())
+	}
+	if len(breaches) != 1 {
+		t.Fatalf("expected 1 breach event, got %d", len(breaches))
+	}
+	ev := breaches[0]
+	if ev.Type != events.SLABreached || ev.Issue.ID != issue.ID || ev.SLA == nil || ev.SLA.PolicyID != policy.ID {
+		t.Fatalf("unexpected event %+v", ev)
+	}
+	if !ev.SLA.Breached || ev.SLA.State != logic.SLARunning {
+		t.Fatalf("expected a running breached timer, got %+v", ev.SLA)
+	}
+}
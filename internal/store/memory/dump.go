@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"MiniJira/internal/logic"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// dump is the serialized form of state used by backups. Models are written
+// with their Go field names, so renaming a model field needs a migration
+// here.
+type dump struct {
+	Projects        []logic.Project       `json:"projects"`
+	Issues          []logic.Issue         `json:"issues"`
+	Sprints         []logic.Sprint        `json:"sprints"`
+	Components      []logic.Component     `json:"components"`
+	CustomFields    []logic.CustomField   `json:"custom_fields"`
+	Rules           []logic.Rule          `json:"rules"`
+	Executions      []logic.RuleExecution `json:"rule_executions"`
+	SLAPolicies     []logic.SLAPolicy     `json:"sla_policies"`
+	SLABreaches     []logic.SLABreach     `json:"sla_breaches"`
+	Versions        []logic.Version       `json:"versions"`
+	Comments        []logic.Comment       `json:"comments"`
+	Worklogs        []logic.Worklog       `json:"worklogs"`
+	Links           []logic.IssueLink     `json:"links"`
+	History         []logic.StatusChange  `json:"history"`
+	Workflows       []logic.Workflow      `json:"workflows"`
+	NextID          int                   `json:"next_project_id"`
+	NextIssueID     int                   `json:"next_issue_id"`
+	NextSprintID    int                   `json:"next_sprint_id"`
+	NextComponentID int                   `json:"next_component_id"`
+	NextFieldID     int                   `json:"next_custom_field_id"`
+	NextRuleID      int                   `json:"next_rule_id"`
+	NextExecutionID int                   `json:"next_rule_execution_id"`
+	NextSLAID       int                   `json:"next_sla_policy_id"`
+	NextVersionID   int                   `json:"next_version_id"`
+	NextCommentID   int                   `json:"next_comment_id"`
+	NextWorklogID   int                   `json:"next_worklog_id"`
+	NextLinkID      int                   `json:"next_link_id"`
+}
+
+// Dump returns the whole store as JSON. It copies the state under the read
+// lock and encodes the copy afterwards, so writers wait only for the copy.
+func (s *Store) Dump() ([]byte, error) {
+	s.mu.RLock()
+	st := s.state.clone()
+	s.mu.RUnlock()
+
+	d := dump{
+		Projects:        st.projects,
+		Issues:          st.issues,
+		Sprints:         st.sprints,
+		Components:      st.components,
+		CustomFields:    st.customFields,
+		Rules:           st.rules,
+		Executions:      st.executions,
+		SLAPolicies:     st.slaPolicies,
+		SLABreaches:     st.slaBreaches,
+		Versions:        st.versions,
+		Comments:        st.comments,
+		Worklogs:        st.worklogs,
+		Links:           st.links,
+		History:         st.history,
+		NextID:          st.nextID,
+		NextIssueID:     st.nextIssueID,
+		NextSprintID:    st.nextSprintID,
+		NextComponentID: st.nextComponentID,
+		NextFieldID:     st.nextFieldID,
+		NextRuleID:      st.nextRuleID,
+		NextExecutionID: st.nextExecutionID,
+		NextSLAID:       st.nextSLAID,
+		NextVersionID:   st.nextVersionID,
+		NextCommentID:   st.nextCommentID,
+		NextWorklogID:   st.nextWorklogID,
+		NextLinkID:      st.nextLinkID,
+	}
+	for _, k := range slices.Sorted(maps.Keys(st.workflows)) {
+		d.Workflows = append(d.Workflows, st.workflows[k])
+	}
+
+	return json.Marshal(d)
+}
+
+// Load replaces the whole store with a dump. The dump is decoded in full
+// before the live state is touched, so a bad dump leaves the store as it
+// was.
+func (s *Store) Load(data []byte) error {
+	var d dump
+	if err := json.Unmarshal(data, &d); err != nil {
+		return fmt.Errorf("decode store dump: %w", err)
+	}
+
+	st := state{
+		projects:     d.Projects,
+		issues:       d.Issues,
+		sprints:      d.Sprints,
+		components:   d.Components,
+		customFields: d.CustomFields,
+		rules:        d.Rules,
+		executions:   d.Executions,
+		slaPolicies:  d.SLAPolicies,
+		slaBreaches:  d.SLABreaches,
+		versions:     d.Versions,
+		comments:     d.Comments,
+		worklogs:     d.Worklogs,
+		links:        d.Links,
+		history:      d.History,
+		workflows:    make(map[string]logic.Workflow, len(d.Workflows)),
+		maxRanks:     make(map[string]int),
+	}
+	for _, w := range d.Workflows {
+		st.workflows[w.ProjectKey] = w
+	}
+	// Lookups by issue ID rely on this order.
+	slices.SortStableFunc(st.issues, func(a, b logic.Issue) int { return a.ID - b.ID })
+
+	// Counters are never behind the stored IDs, even in a hand-edited dump.
+	st.nextID = max(d.NextID, nextAfter(st.projects, func(p logic.Project) int { return p.ID }))
+	st.nextIssueID = max(d.NextIssueID, nextAfter(st.issues, func(i logic.Issue) int { return i.ID }))
+	st.nextSprintID = max(d.NextSprintID, nextAfter(st.sprints, func(sp logic.Sprint) int { return sp.ID }))
+	st.nextComponentID = max(d.NextComponentID, nextAfter(st.components, func(c logic.Component) int { return c.ID }))
+	st.nextFieldID = max(d.NextFieldID, nextAfter(st.customFields, func(f logic.CustomField) int { return f.ID }))
+	st.nextRuleID = max(d.NextRuleID, nextAfter(st.rules, func(r logic.Rule) int { return r.ID }))
+	st.nextExecutionID = max(d.NextExecutionID, nextAfter(st.executions, func(x logic.RuleExecution) int { return x.ID }))
+	st.nextSLAID = max(d.NextSLAID, nextAfter(st.slaPolicies, func(p logic.SLAPolicy) int { return p.ID }))
+	st.nextVersionID = max(d.NextVersionID, nextAfter(st.versions, func(v logic.Version) int { return v.ID }))
+	st.nextCommentID = max(d.NextCommentID, nextAfter(st.comments, func(c logic.Comment) int { return c.ID }))
+	st.nextWorklogID = max(d.NextWorklogID, nextAfter(st.worklogs, func(w logic.Worklog) int { return w.ID }))
+	st.nextLinkID = max(d.NextLinkID, nextAfter(st.links, func(l logic.IssueLink) int { return l.ID }))
+
+	s.mu.Lock()
+	*s.state = st
+	s.mu.Unlock()
+
+	return nil
+}
+
+func nextAfter[T any](items []T, id func(T) int) int {
+	next := 1
+	for _, item := range items {
+		next = max(next, id(item)+1)
+	}
+
+	return next
+}
@@ -2,28 +2,185 @@ package memory
 
 import (
 	"MiniJira/internal/logic"
+	"context"
+	"maps"
+	"slices"
 	"sync"
 )
 
 type Store struct {
-	issues      []logic.Issue
-	projects    []logic.Project
-	mu          sync.RWMutex
-	nextID      int
-	nextIssueID int
+	mu sync.RWMutex
+	*state
+
+	// inTx marks the view a transaction works through; its writes append
+	// to undo the steps that revert them.
+	inTx bool
+	undo []func()
+}
+
+// state is everything a transaction may change.
+type state struct {
+	issues       []logic.Issue
+	projects     []logic.Project
+	sprints      []logic.Sprint
+	components   []logic.Component
+	customFields []logic.CustomField
+	rules        []logic.Rule
+	executions   []logic.RuleExecution
+	slaPolicies  []logic.SLAPolicy
+	slaBreaches  []logic.SLABreach
+	versions     []logic.Version
+	comments     []logic.Comment
+	worklogs     []logic.Worklog
+	links        []logic.IssueLink
+	history      []logic.StatusChange
+	workflows    map[string]logic.Workflow
+	// maxRanks caches MaxIssueRank by project. Entries are dropped when
+	// the highest rank may have gone down and rebuilt on the next call.
+	maxRanks        map[string]int
+	nextID          int
+	nextIssueID     int
+	nextSprintID    int
+	nextComponentID int
+	nextFieldID     int
+	nextRuleID      int
+	nextExecutionID int
+	nextSLAID       int
+	nextVersionID   int
+	nextCommentID   int
+	nextWorklogID   int
+	nextLinkID      int
 }
 
 func NewStore() *Store {
-	return &Store{nextID: 1, nextIssueID: 1}
+	return &Store{state: &state{
+		workflows:       make(map[string]logic.Workflow),
+		maxRanks:        make(map[string]int),
+		nextID:          1,
+		nextIssueID:     1,
+		nextSprintID:    1,
+		nextComponentID: 1,
+		nextFieldID:     1,
+		nextRuleID:      1,
+		nextExecutionID: 1,
+		nextSLAID:       1,
+		nextVersionID:   1,
+		nextCommentID:   1,
+		nextWorklogID:   1,
+		nextLinkID:      1,
+	}}
+}
+
+// clone copies the slices and maps for Dump; their elements are replaced
+// rather than modified in place, so sharing them is safe.
+func (st state) clone() state {
+	st.issues = slices.Clone(st.issues)
+	st.projects = slices.Clone(st.projects)
+	st.sprints = slices.Clone(st.sprints)
+	st.components = slices.Clone(st.components)
+	st.customFields = slices.Clone(st.customFields)
+	st.rules = slices.Clone(st.rules)
+	st.executions = slices.Clone(st.executions)
+	st.slaPolicies = slices.Clone(st.slaPolicies)
+	st.slaBreaches = slices.Clone(st.slaBreaches)
+	st.versions = slices.Clone(st.versions)
+	st.comments = slices.Clone(st.comments)
+	st.worklogs = slices.Clone(st.worklogs)
+	st.links = slices.Clone(st.links)
+	st.history = slices.Clone(st.history)
+	st.workflows = maps.Clone(st.workflows)
+
+	return st
+}
+
+// Tx runs fn against the store while holding the write lock, so
+// transactions are serialized with every other write. fn writes to the live
+// state and each write records how to undo it; if fn fails or panics, the
+// undo log is replayed backwards, so a write costs the same inside a
+// transaction as outside it.
+func (s *Store) Tx(fn func(tx logic.Store) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx := &Store{state: s.state, inTx: true}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.rollback()
+		}
+	}()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	// A transaction nested in another one is undone with it.
+	if s.inTx {
+		s.undo = append(s.undo, tx.undo...)
+	}
+
+	return nil
+}
+
+func (s *Store) rollback() {
+	for i := len(s.undo) - 1; i >= 0; i-- {
+		s.undo[i]()
+	}
+	s.undo = nil
+}
+
+// record keeps an undo step when s is a transaction view.
+func (s *Store) record(undo func()) {
+	if s.inTx {
+		s.undo = append(s.undo, undo)
+	}
+}
+
+// take returns the next ID of counter and advances it.
+func (s *Store) take(counter *int) int {
+	id := *counter
+	*counter++
+	s.record(func() { *counter = id })
+
+	return id
+}
+
+func appendItem[T any](s *Store, list *[]T, v T) {
+	old := *list
+	*list = append(*list, v)
+	s.record(func() {
+		clear((*list)[len(old):])
+		*list = old
+	})
+}
+
+func setItem[T any](s *Store, list *[]T, i int, v T) {
+	old := (*list)[i]
+	(*list)[i] = v
+	s.record(func() { (*list)[i] = old })
+}
+
+func deleteItem[T any](s *Store, list *[]T, i int) {
+	old := (*list)[i]
+	*list = slices.Delete(*list, i, i+1)
+	s.record(func() { *list = slices.Insert(*list, i, old) })
+}
+
+// Ping reports whether the store can serve reads; a store stuck behind
+// a held lock will not return before the caller's deadline.
+func (s *Store) Ping(ctx context.Context) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return ctx.Err()
 }
 
 func (s *Store) Create(p logic.Project) logic.Project {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	p.ID = s.nextID
-	s.nextID++
-	s.projects = append(s.projects, p)
+	p.ID = s.take(&s.nextID)
+	appendItem(s, &s.projects, p)
 
 	return p
 }
@@ -59,9 +216,14 @@ func (s *Store) CreateIssue(i logic.Issue) logic.Issue {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	i.ID = s.nextIssueID
-	s.nextIssueID++
-	s.issues = append(s.issues, i)
+	i.ID = s.take(&s.nextIssueID)
+	i.Labels = slices.Clone(i.Labels)
+	i.ComponentIDs = slices.Clone(i.ComponentIDs)
+	i.CustomFields = cloneValues(i.CustomFields)
+	appendItem(s, &s.issues, i)
+	if rank, ok := s.maxRanks[i.ProjectKey]; ok && i.Rank > rank {
+		s.cacheMaxRank(i.ProjectKey, i.Rank)
+	}
 
 	return i
 }
@@ -70,10 +232,8 @@ func (s *Store) GetIssueByID(id int) (logic.Issue, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	for _, i := range s.issues {
-		if i.ID == id {
-			return i, true
-		}
+	if i, ok := s.issueIndex(id); ok {
+		return s.issues[i], true
 	}
 
 	return logic.Issue{}, false
@@ -83,16 +243,61 @@ func (s *Store) UpdateIssueStatus(id int, newStatus string) (logic.Issue, bool)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for i := range s.issues {
-		if s.issues[i].ID == id {
-			s.issues[i].Status = newStatus
-			return s.issues[i], true
-		}
+	if i, ok := s.issueIndex(id); ok {
+		issue := s.issues[i]
+		issue.Status = newStatus
+		setItem(s, &s.issues, i, issue)
+		return issue, true
 	}
 
 	return logic.Issue{}, false
 }
 
+func (s *Store) UpdateIssueRank(id int, rank int) (logic.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, ok := s.issueIndex(id); ok {
+		issue := s.issues[i]
+		issue.Rank = rank
+		s.setIssue(i, issue)
+		return issue, true
+	}
+
+	return logic.Issue{}, false
+}
+
+func (s *Store) UpdateIssue(issue logic.Issue) (logic.Issue, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i, ok := s.issueIndex(issue.ID); ok {
+		issue.Labels = slices.Clone(issue.Labels)
+		issue.ComponentIDs = slices.Clone(issue.ComponentIDs)
+		issue.CustomFields = cloneValues(issue.CustomFields)
+		s.setIssue(i, issue)
+		return issue, true
+	}
+
+	return logic.Issue{}, false
+}
+
+// setIssue replaces the issue at index i and drops the cached highest
+// ranks it may change.
+func (s *Store) setIssue(i int, issue logic.Issue) {
+	if old := s.issues[i]; old.Rank != issue.Rank || old.ProjectKey != issue.ProjectKey {
+		delete(s.maxRanks, old.ProjectKey)
+		delete(s.maxRanks, issue.ProjectKey)
+	}
+	setItem(s, &s.issues, i, issue)
+}
+
+// issueIndex finds an issue by ID. Issues are kept in ID order: new ones
+// get the highest ID and none are deleted.
+func (s *Store) issueIndex(id int) (int, bool) {
+	return slices.BinarySearchFunc(s.issues, id, func(i logic.Issue, id int) int { return i.ID - id })
+}
+
 func (s *Store) ListIssuesByProjectKey(projectKey string) []logic.Issue {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -106,3 +311,617 @@ func (s *Store) ListIssuesByProjectKey(projectKey string) []logic.Issue {
 
 	return res
 }
+
+// MaxIssueRank takes the write lock, as it may fill the cache.
+func (s *Store) MaxIssueRank(projectKey string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rank, ok := s.maxRanks[projectKey]; ok {
+		return rank
+	}
+
+	rank := 0
+	for _, i := range s.issues {
+		if i.ProjectKey == projectKey {
+			rank = max(rank, i.Rank)
+		}
+	}
+	s.cacheMaxRank(projectKey, rank)
+
+	return rank
+}
+
+// cacheMaxRank sets a cached highest rank. A rolled back transaction drops
+// the entry, since the issues it was worked out from may be gone.
+func (s *Store) cacheMaxRank(projectKey string, rank int) {
+	s.maxRanks[projectKey] = rank
+	s.record(func() { delete(s.maxRanks, projectKey) })
+}
+
+func (s *Store) CreateSprint(sp logic.Sprint) logic.Sprint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sp.ID = s.take(&s.nextSprintID)
+	appendItem(s, &s.sprints, sp)
+
+	return sp
+}
+
+func (s *Store) GetSprintByID(id int) (logic.Sprint, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sp := range s.sprints {
+		if sp.ID == id {
+			return sp, true
+		}
+	}
+
+	return logic.Sprint{}, false
+}
+
+func (s *Store) UpdateSprint(sp logic.Sprint) (logic.Sprint, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.sprints {
+		if s.sprints[i].ID == sp.ID {
+			sp.Incomplete = slices.Clone(sp.Incomplete)
+			setItem(s, &s.sprints, i, sp)
+			return sp, true
+		}
+	}
+
+	return logic.Sprint{}, false
+}
+
+func (s *Store) ListSprintsByProjectKey(projectKey string) []logic.Sprint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Sprint, 0, len(s.sprints))
+	for _, sp := range s.sprints {
+		if sp.ProjectKey == projectKey {
+			res = append(res, sp)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateComponent(c logic.Component) logic.Component {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.ID = s.take(&s.nextComponentID)
+	appendItem(s, &s.components, c)
+
+	return c
+}
+
+func (s *Store) GetComponentByID(id int) (logic.Component, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, c := range s.components {
+		if c.ID == id {
+			return c, true
+		}
+	}
+
+	return logic.Component{}, false
+}
+
+func (s *Store) UpdateComponent(c logic.Component) (logic.Component, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.components {
+		if s.components[i].ID == c.ID {
+			setItem(s, &s.components, i, c)
+			return c, true
+		}
+	}
+
+	return logic.Component{}, false
+}
+
+func (s *Store) ListComponentsByProjectKey(projectKey string) []logic.Component {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Component, 0)
+	for _, c := range s.components {
+		if c.ProjectKey == projectKey {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateCustomField(f logic.CustomField) logic.CustomField {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f.ID = s.take(&s.nextFieldID)
+	f.Options = slices.Clone(f.Options)
+	appendItem(s, &s.customFields, f)
+
+	return f
+}
+
+func (s *Store) GetCustomFieldByID(id int) (logic.CustomField, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, f := range s.customFields {
+		if f.ID == id {
+			return f, true
+		}
+	}
+
+	return logic.CustomField{}, false
+}
+
+func (s *Store) UpdateCustomField(f logic.CustomField) (logic.CustomField, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.customFields {
+		if s.customFields[i].ID == f.ID {
+			f.Options = slices.Clone(f.Options)
+			setItem(s, &s.customFields, i, f)
+			return f, true
+		}
+	}
+
+	return logic.CustomField{}, false
+}
+
+func (s *Store) DeleteCustomField(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.customFields {
+		if s.customFields[i].ID == id {
+			deleteItem(s, &s.customFields, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) ListCustomFieldsByProjectKey(projectKey string) []logic.CustomField {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.CustomField, 0)
+	for _, f := range s.customFields {
+		if f.ProjectKey == projectKey {
+			res = append(res, f)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateRule(r logic.Rule) logic.Rule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r.ID = s.take(&s.nextRuleID)
+	r.Conditions = slices.Clone(r.Conditions)
+	r.Actions = slices.Clone(r.Actions)
+	appendItem(s, &s.rules, r)
+
+	return r
+}
+
+func (s *Store) GetRuleByID(id int) (logic.Rule, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, r := range s.rules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+
+	return logic.Rule{}, false
+}
+
+func (s *Store) UpdateRule(r logic.Rule) (logic.Rule, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rules {
+		if s.rules[i].ID == r.ID {
+			r.Conditions = slices.Clone(r.Conditions)
+			r.Actions = slices.Clone(r.Actions)
+			setItem(s, &s.rules, i, r)
+			return r, true
+		}
+	}
+
+	return logic.Rule{}, false
+}
+
+func (s *Store) DeleteRule(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.rules {
+		if s.rules[i].ID == id {
+			deleteItem(s, &s.rules, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) ListRulesByProjectKey(projectKey string) []logic.Rule {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Rule, 0)
+	for _, r := range s.rules {
+		if r.ProjectKey == projectKey {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) AddRuleExecution(x logic.RuleExecution) logic.RuleExecution {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	x.ID = s.take(&s.nextExecutionID)
+	x.Chain = slices.Clone(x.Chain)
+	appendItem(s, &s.executions, x)
+	if n := len(s.executions) - logic.MaxRuleExecutions; n > 0 {
+		dropped := slices.Clone(s.executions[:n])
+		s.executions = slices.Delete(s.executions, 0, n)
+		s.record(func() { s.executions = slices.Insert(s.executions, 0, dropped...) })
+	}
+
+	return x
+}
+
+func (s *Store) ListRuleExecutions(projectKey string) []logic.RuleExecution {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.RuleExecution, 0)
+	for _, x := range s.executions {
+		if x.ProjectKey == projectKey {
+			res = append(res, x)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateSLAPolicy(p logic.SLAPolicy) logic.SLAPolicy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p.ID = s.take(&s.nextSLAID)
+	p = cloneSLAPolicy(p)
+	appendItem(s, &s.slaPolicies, p)
+
+	return p
+}
+
+func (s *Store) GetSLAPolicyByID(id int) (logic.SLAPolicy, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.slaPolicies {
+		if p.ID == id {
+			return p, true
+		}
+	}
+
+	return logic.SLAPolicy{}, false
+}
+
+func (s *Store) UpdateSLAPolicy(p logic.SLAPolicy) (logic.SLAPolicy, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.slaPolicies {
+		if s.slaPolicies[i].ID == p.ID {
+			p = cloneSLAPolicy(p)
+			setItem(s, &s.slaPolicies, i, p)
+			return p, true
+		}
+	}
+
+	return logic.SLAPolicy{}, false
+}
+
+func (s *Store) DeleteSLAPolicy(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.slaPolicies {
+		if s.slaPolicies[i].ID == id {
+			deleteItem(s, &s.slaPolicies, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) ListSLAPoliciesByProjectKey(projectKey string) []logic.SLAPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.SLAPolicy, 0)
+	for _, p := range s.slaPolicies {
+		if p.ProjectKey == projectKey {
+			res = append(res, p)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) AddSLABreach(b logic.SLABreach) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.slaBreaches {
+		if r.PolicyID == b.PolicyID && r.IssueID == b.IssueID {
+			return false
+		}
+	}
+	appendItem(s, &s.slaBreaches, b)
+
+	return true
+}
+
+func cloneSLAPolicy(p logic.SLAPolicy) logic.SLAPolicy {
+	p.StartStatuses = slices.Clone(p.StartStatuses)
+	p.PauseStatuses = slices.Clone(p.PauseStatuses)
+	p.StopStatuses = slices.Clone(p.StopStatuses)
+	p.Calendar.Workdays = slices.Clone(p.Calendar.Workdays)
+	p.Calendar.Holidays = slices.Clone(p.Calendar.Holidays)
+
+	return p
+}
+
+func (s *Store) CreateVersion(v logic.Version) logic.Version {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v.ID = s.take(&s.nextVersionID)
+	appendItem(s, &s.versions, v)
+
+	return v
+}
+
+func (s *Store) GetVersionByID(id int) (logic.Version, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, v := range s.versions {
+		if v.ID == id {
+			return v, true
+		}
+	}
+
+	return logic.Version{}, false
+}
+
+func (s *Store) UpdateVersion(v logic.Version) (logic.Version, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.versions {
+		if s.versions[i].ID == v.ID {
+			setItem(s, &s.versions, i, v)
+			return v, true
+		}
+	}
+
+	return logic.Version{}, false
+}
+
+func (s *Store) ListVersionsByProjectKey(projectKey string) []logic.Version {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Version, 0)
+	for _, v := range s.versions {
+		if v.ProjectKey == projectKey {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateComment(c logic.Comment) logic.Comment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.ID = s.take(&s.nextCommentID)
+	appendItem(s, &s.comments, c)
+
+	return c
+}
+
+func (s *Store) ListCommentsByIssueID(issueID int) []logic.Comment {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Comment, 0)
+	for _, c := range s.comments {
+		if c.IssueID == issueID {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateWorklog(w logic.Worklog) logic.Worklog {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.ID = s.take(&s.nextWorklogID)
+	appendItem(s, &s.worklogs, w)
+
+	return w
+}
+
+func (s *Store) GetWorklogByID(id int) (logic.Worklog, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, w := range s.worklogs {
+		if w.ID == id {
+			return w, true
+		}
+	}
+
+	return logic.Worklog{}, false
+}
+
+func (s *Store) UpdateWorklog(w logic.Worklog) (logic.Worklog, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.worklogs {
+		if s.worklogs[i].ID == w.ID {
+			setItem(s, &s.worklogs, i, w)
+			return w, true
+		}
+	}
+
+	return logic.Worklog{}, false
+}
+
+func (s *Store) DeleteWorklog(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.worklogs {
+		if s.worklogs[i].ID == id {
+			deleteItem(s, &s.worklogs, i)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Store) ListWorklogsByIssueID(issueID int) []logic.Worklog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.Worklog, 0)
+	for _, w := range s.worklogs {
+		if w.IssueID == issueID {
+			res = append(res, w)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) CreateLink(l logic.IssueLink) logic.IssueLink {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l.ID = s.take(&s.nextLinkID)
+	appendItem(s, &s.links, l)
+
+	return l
+}
+
+func (s *Store) ListLinksByIssueID(issueID int) []logic.IssueLink {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.IssueLink, 0)
+	for _, l := range s.links {
+		if l.FromID == issueID || l.ToID == issueID {
+			res = append(res, l)
+		}
+	}
+
+	return res
+}
+
+func (s *Store) GetWorkflow(projectKey string) (logic.Workflow, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w, ok := s.workflows[projectKey]
+	return w, ok
+}
+
+func (s *Store) SaveWorkflow(w logic.Workflow) logic.Workflow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	w.Statuses = slices.Clone(w.Statuses)
+	w.Transitions = slices.Clone(w.Transitions)
+	old, existed := s.workflows[w.ProjectKey]
+	s.workflows[w.ProjectKey] = w
+	s.record(func() {
+		if existed {
+			s.workflows[w.ProjectKey] = old
+		} else {
+			delete(s.workflows, w.ProjectKey)
+		}
+	})
+
+	return w
+}
+
+func (s *Store) AddStatusChange(c logic.StatusChange) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	appendItem(s, &s.history, c)
+}
+
+func (s *Store) ListStatusChanges(issueID int) []logic.StatusChange {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]logic.StatusChange, 0)
+	for _, c := range s.history {
+		if c.IssueID == issueID {
+			res = append(res, c)
+		}
+	}
+
+	return res
+}
+
+// cloneValues copies custom field values, so callers cannot change the
+// stored issue through them.
+func cloneValues(values map[string][]string) map[string][]string {
+	if values == nil {
+		return nil
+	}
+
+	res := make(map[string][]string, len(values))
+	for k, v := range values {
+		res[k] = slices.Clone(v)
+	}
+
+	return res
+}
@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"MiniJira/internal/logic"
+	"errors"
+	"testing"
+)
+
+var errAbort = errors.New("abort")
+
+func TestStore_TxCommit(t *testing.T) {
+	s := NewStore()
+	s.Create(logic.Project{Key: "PAY", Name: "Payments"})
+
+	err := s.Tx(func(tx logic.Store) error {
+		i := tx.CreateIssue(logic.Issue{ProjectKey: "PAY", Title: "Fix checkout", Status: logic.StatusOpen})
+		tx.UpdateIssueStatus(i.ID, logic.StatusInProgress)
+		tx.AddStatusChange(logic.StatusChange{IssueID: i.ID, From: logic.StatusOpen, To: logic.StatusInProgress})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	i, ok := s.GetIssueByID(1)
+	if !ok || i.Status != logic.StatusInProgress || len(s.ListStatusChanges(1)) != 1 {
+		t.Fatalf("expected the transaction committed, got %+v, %v", i, ok)
+	}
+	if next := s.CreateIssue(logic.Issue{ProjectKey: "PAY"}); next.ID != 2 {
+		t.Fatalf("expected id 2, got %d", next.ID)
+	}
+}
+
+func TestStore_TxRollback(t *testing.T) {
+	s := NewStore()
+	s.Create(logic.Project{Key: "PAY", Name: "Payments"})
+	first := s.CreateIssue(logic.Issue{ProjectKey: "PAY", Title: "Fix checkout", Status: logic.StatusOpen, Labels: []string{"web"}})
+	second := s.CreateIssue(logic.Issue{ProjectKey: "PAY", Title: "Refund flow", Status: logic.StatusOpen})
+	w := s.CreateWorklog(logic.Worklog{IssueID: first.ID})
+	s.SaveWorkflow(logic.DefaultWorkflow("PAY"))
+	before, err := s.Dump()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	writes := func(tx logic.Store) {
+		tx.CreateIssue(logic.Issue{ProjectKey: "PAY", Title: "Rolled back"})
+		tx.UpdateIssue(logic.Issue{ID: first.ID, ProjectKey: "PAY", Title: "Renamed", Status: logic.StatusDone})
+		tx.UpdateIssueRank(second.ID, 7)
+		tx.DeleteWorklog(w.ID)
+		tx.CreateWorklog(logic.Worklog{IssueID: second.ID})
+		tx.SaveWorkflow(logic.DefaultWorkflow("OPS"))
+		tx.SaveWorkflow(logic.Workflow{ProjectKey: "PAY"})
+		tx.AddStatusChange(logic.StatusChange{IssueID: second.ID})
+	}
+
+	tests := []struct {
+		name string
+		fn   func(tx logic.Store) error
+	}{
+		{"error", func(tx logic.Store) error {
+			writes(tx)
+			return errAbort
+		}},
+		{"panic", func(tx logic.Store) error {
+			writes(tx)
+			panic(errAbort)
+		}},
+		{"nested", func(tx logic.Store) error {
+			if err := tx.(*Store).Tx(func(inner logic.Store) error {
+				writes(inner)
+				return nil
+			}); err != nil {
+				return err
+			}
+			return errAbort
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := func() (err error) {
+				defer func() {
+					if p := recover(); p != nil {
+						err = p.(error)
+					}
+				}()
+				return s.Tx(tt.fn)
+			}()
+			if !errors.Is(err, errAbort) {
+				t.Fatalf("expected errAbort, got %v", err)
+			}
+
+			after, err := s.Dump()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if string(after) != string(before) {
+				t.Fatalf("expected the store unchanged:\n%s\n%s", before, after)
+			}
+		})
+	}
+}
+
+func TestStore_TxRollbackTrimmedExecutions(t *testing.T) {
+	s := NewStore()
+	for range logic.MaxRuleExecutions {
+		s.AddRuleExecution(logic.RuleExecution{ProjectKey: "PAY"})
+	}
+	before, _ := s.Dump()
+
+	err := s.Tx(func(tx logic.Store) error {
+		tx.AddRuleExecution(logic.RuleExecution{ProjectKey: "PAY"})
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected errAbort, got %v", err)
+	}
+
+	if after, _ := s.Dump(); string(after) != string(before) {
+		t.Fatal("expected the trimmed executions restored")
+	}
+}
+
+func TestStore_MaxIssueRank(t *testing.T) {
+	s := NewStore()
+	s.CreateIssue(logic.Issue{ProjectKey: "PAY", Rank: 1})
+	second := s.CreateIssue(logic.Issue{ProjectKey: "PAY", Rank: 2})
+	if got := s.MaxIssueRank("PAY"); got != 2 {
+		t.Fatalf("expected 2, got %d", got)
+	}
+
+	err := s.Tx(func(tx logic.Store) error {
+		tx.CreateIssue(logic.Issue{ProjectKey: "PAY", Rank: 3})
+		if got := tx.MaxIssueRank("PAY"); got != 3 {
+			t.Fatalf("expected 3 inside the transaction, got %d", got)
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("expected errAbort, got %v", err)
+	}
+	if got := s.MaxIssueRank("PAY"); got != 2 {
+		t.Fatalf("expected 2 after the rollback, got %d", got)
+	}
+
+	s.UpdateIssueRank(second.ID, 1)
+	if got := s.MaxIssueRank("PAY"); got != 1 {
+		t.Fatalf("expected 1 after reranking, got %d", got)
+	}
+	if got := s.MaxIssueRank("OPS"); got != 0 {
+		t.Fatalf("expected 0 for a project without issues, got %d", got)
+	}
+}
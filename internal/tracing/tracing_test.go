@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		ok      bool
+		sampled bool
+	}{
+		{
+			name:    "sampled",
+			header:  "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			ok:      true,
+			sampled: true,
+		},
+		{
+			name:   "not sampled",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00",
+			ok:     true,
+		},
+		{
+			name:    "future version with extra field",
+			header:  "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+			ok:      true,
+			sampled: true,
+		},
+		{
+			name:   "zero trace id",
+			header: "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "invalid version",
+			header: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		{
+			name:   "short span id",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa-01",
+		},
+		{
+			name:   "empty",
+			header: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, ok := ParseTraceparent(tt.header)
+			if ok != tt.ok {
+				t.Fatalf("expected ok %v, got %v", tt.ok, ok)
+			}
+			if ok && sc.Sampled != tt.sampled {
+				t.Fatalf("expected sampled %v, got %v", tt.sampled, sc.Sampled)
+			}
+		})
+	}
+}
+
+func TestTracer_ChildSpansShareTrace(t *testing.T) {
+	exporter := NewInMemoryExporter()
+	tracer := NewTracer(exporter)
+
+	remote, _ := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := ContextWithRemote(context.Background(), remote)
+
+	ctx, parent := tracer.Start(ctx, "parent", KindServer)
+	_, child := tracer.Start(ctx, "child", KindInternal)
+	child.End()
+	parent.End()
+
+	spans := exporter.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	if spans[1].SpanContext.TraceID != remote.TraceID || spans[0].SpanContext.TraceID != remote.TraceID {
+		t.Fatalf("expected spans to continue trace %s", remote.TraceID)
+	}
+
+	if spans[1].Parent != remote.SpanID {
+		t.Fatalf("expected parent span %s, got %s", remote.SpanID, spans[1].Parent)
+	}
+
+	if spans[0].Parent != spans[1].SpanContext.SpanID {
+		t.Fatalf("expected child of %s, got %s", spans[1].SpanContext.SpanID, spans[0].Parent)
+	}
+}
@@ -1,49 +1,1288 @@
 package usecase
 
 import (
+	"MiniJira/internal/archive"
+	"MiniJira/internal/events"
 	"MiniJira/internal/logic"
+	"MiniJira/internal/tracing"
+	"context"
+	"errors"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// createChunk bounds how many issues CreateIssues creates per transaction.
+const createChunk = 500
+
 type Service struct {
-	projectStore logic.ProjectStore
-	issueStore   logic.IssueStore
-	piStore      logic.ProjectIssueStore
+	store  logic.TxStore
+	events *events.Bus
+	tracer *tracing.Tracer
 }
 
-func NewService(projectStore logic.ProjectStore, issueStore logic.IssueStore, piStore logic.ProjectIssueStore) *Service {
+func NewService(store logic.TxStore, bus *events.Bus, tracer *tracing.Tracer) *Service {
+	if bus == nil {
+		bus = events.NewBus()
+	}
+
 	return &Service{
-		projectStore: projectStore,
-		issueStore:   issueStore,
-		piStore:      piStore,
+		store:  store,
+		events: bus,
+		tracer: tracer,
 	}
 }
 
-func (s *Service) ListProjects() []logic.Project {
-	return s.projectStore.List()
+// begin starts the span of a service call and returns the store to use
+// for it, so every store call is traced as a child of that span.
+func (s *Service) begin(ctx context.Context, op string) (context.Context, *tracing.Span, logic.Store) {
+	ctx, span := s.tracer.Start(ctx, "usecase."+op, tracing.KindInternal)
+
+	return ctx, span, s.traced(ctx, s.store)
+}
+
+func (s *Service) traced(ctx context.Context, store logic.Store) logic.Store {
+	if s.tracer == nil {
+		return store
+	}
+
+	return &tracedStore{Store: store, ctx: ctx, tracer: s.tracer}
+}
+
+func (s *Service) ListProjects(ctx context.Context) []logic.Project {
+	_, span, store := s.begin(ctx, "ListProjects")
+	defer span.End()
+
+	return store.List()
+}
+
+func (s *Service) CreateProject(ctx context.Context, key, name string) (logic.Project, error) {
+	_, span, store := s.begin(ctx, "CreateProject")
+	defer span.End()
+
+	created, err := logic.CreateProject(store, key, name)
+	span.RecordError(err)
+
+	return created, err
 }
 
-func (s *Service) CreateProject(key, name string) (logic.Project, error) {
-	return logic.CreateProject(s.projectStore, key, name)
+func (s *Service) GetProject(ctx context.Context, key string) (logic.Project, error) {
+	_, span, store := s.begin(ctx, "GetProject")
+	defer span.End()
+
+	p, err := logic.GetProject(store, key)
+	span.RecordError(err)
+
+	return p, err
 }
 
-func (s *Service) CreateIssue(projectKey, title string) (logic.Issue, error) {
-	return logic.CreateIssue(s.piStore, projectKey, title)
+func (s *Service) CreateIssue(ctx context.Context, projectKey, title string) (logic.Issue, error) {
+	ctx, span, _ := s.begin(ctx, "CreateIssue")
+	defer span.End()
+
+	var created logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateIssue(s.traced(ctx, tx), projectKey, title, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Issue{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueCreated,
+		ProjectKey: created.ProjectKey,
+		Issue:      created,
+		ToStatus:   created.Status,
+	})
+
+	return created, nil
 }
 
-func (s *Service) ListIssues(projectKey string) ([]logic.Issue, error) {
+// CreateIssueFrom creates an issue with its optional fields in one
+// transaction.
+func (s *Service) CreateIssueFrom(ctx context.Context, projectKey string, in logic.NewIssue) (logic.Issue, error) {
+	ctx, span, _ := s.begin(ctx, "CreateIssueFrom")
+	defer span.End()
+
+	var created logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateIssueFrom(s.traced(ctx, tx), projectKey, in, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Issue{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueCreated,
+		ProjectKey: created.ProjectKey,
+		Issue:      created,
+		ToStatus:   created.Status,
+	})
+
+	return created, nil
+}
+
+// CreateIssues creates issues like CreateIssueFrom, in transactions of up
+// to createChunk issues rather than one per issue; other writers get the
+// store between chunks. Each issue is created or fails on its own: errs[i]
+// is the error of ins[i], and created holds the issues that succeeded.
+func (s *Service) CreateIssues(ctx context.Context, projectKey string, ins []logic.NewIssue) (created []logic.Issue, errs []error) {
+	ctx, span, _ := s.begin(ctx, "CreateIssues")
+	defer span.End()
+	span.SetAttributes(tracing.Attr("issues.count", strconv.Itoa(len(ins))))
+
+	errs = make([]error, len(ins))
+	for i := range ins {
+		errs[i] = logic.ValidateNewIssue(&ins[i])
+	}
+
+	now := time.Now().UTC()
+	for start := 0; start < len(ins); start += createChunk {
+		end := min(start+createChunk, len(ins))
+		var chunk []logic.Issue
+		// Only single issues fail, in transactions nested in this one, so
+		// the chunk itself always commits.
+		_ = s.store.Tx(func(tx logic.Store) error {
+			for i := start; i < end; i++ {
+				if errs[i] != nil {
+					continue
+				}
+				// A failing issue may be stored half way; its nested
+				// transaction takes that back.
+				errs[i] = tx.(logic.TxStore).Tx(func(tx logic.Store) error {
+					issue, err := logic.CreateIssueFrom(s.traced(ctx, tx), projectKey, ins[i], now)
+					if err == nil {
+						chunk = append(chunk, issue)
+					}
+					return err
+				})
+			}
+			return nil
+		})
+
+		for _, issue := range chunk {
+			s.publish(ctx, events.Event{
+				Type:       events.IssueCreated,
+				ProjectKey: issue.ProjectKey,
+				Issue:      issue,
+				ToStatus:   issue.Status,
+			})
+		}
+		created = append(created, chunk...)
+	}
+
+	return created, errs
+}
+
+func (s *Service) ListIssues(ctx context.Context, projectKey string) ([]logic.Issue, error) {
+	_, span, store := s.begin(ctx, "ListIssues")
+	defer span.End()
+
 	projectKey = strings.TrimSpace(projectKey)
 	if projectKey == "" {
-		return nil, logic.ErrInvalidIssue
+		err := logic.NewValidationError(logic.ErrInvalidIssue, logic.FieldError{
+			Field:   "project_key",
+			Code:    logic.FieldRequired,
+			Message: "must not be empty",
+		})
+		span.RecordError(err)
+		return nil, err
 	}
 
-	return s.issueStore.ListIssuesByProjectKey(projectKey), nil
+	issues := store.ListIssuesByProjectKey(projectKey)
+	sort.SliceStable(issues, func(a, b int) bool {
+		return issues[a].Rank < issues[b].Rank
+	})
+
+	return issues, nil
+}
+
+func (s *Service) GetIssue(ctx context.Context, id int) (logic.Issue, error) {
+	_, span, store := s.begin(ctx, "GetIssue")
+	defer span.End()
+
+	issue, err := logic.GetIssue(store, id)
+	span.RecordError(err)
+
+	return issue, err
+}
+
+func (s *Service) UpdateIssue(ctx context.Context, id int, patch logic.IssuePatch) (logic.Issue, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateIssue")
+	defer span.End()
+
+	// The patch is applied to the whole stored issue, so reading and
+	// writing it back must not interleave with other writes.
+	var updated logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateIssue(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Issue{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueUpdated,
+		ProjectKey: updated.ProjectKey,
+		Issue:      updated,
+	})
+
+	return updated, nil
+}
+
+// TransitionIssue moves an issue without a resolution, so it cannot enter
+// a DONE status from another category.
+func (s *Service) TransitionIssue(ctx context.Context, issueID int, toStatus string) (logic.Issue, error) {
+	return s.TransitionIssueWith(ctx, issueID, logic.IssueTransition{ToStatus: toStatus})
+}
+
+// TransitionIssueWith moves an issue with the actor and input the hooks of
+// the workflow transition may need.
+func (s *Service) TransitionIssueWith(ctx context.Context, issueID int, in logic.IssueTransition) (logic.Issue, error) {
+	ctx, span, _ := s.begin(ctx, "TransitionIssue")
+	defer span.End()
+
+	var before, updated logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		tx = s.traced(ctx, tx)
+		before, _ = tx.GetIssueByID(issueID)
+
+		var err error
+		updated, err = logic.TransitionIssueWith(tx, issueID, in, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Issue{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueTransitioned,
+		ProjectKey: updated.ProjectKey,
+		Issue:      updated,
+		FromStatus: before.Status,
+		ToStatus:   updated.Status,
+	})
+
+	return updated, nil
+}
+
+func (s *Service) RankIssue(ctx context.Context, issueID, beforeID int) (logic.Issue, error) {
+	ctx, span, _ := s.begin(ctx, "RankIssue")
+	defer span.End()
+
+	var ranked logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		ranked, err = logic.RankIssue(s.traced(ctx, tx), issueID, beforeID)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Issue{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueRanked,
+		ProjectKey: ranked.ProjectKey,
+		Issue:      ranked,
+	})
+
+	return ranked, nil
+}
+
+func (s *Service) GetWorkflow(ctx context.Context, projectKey string) (logic.Workflow, error) {
+	_, span, store := s.begin(ctx, "GetWorkflow")
+	defer span.End()
+
+	p, err := logic.GetProject(store, projectKey)
+	if err != nil {
+		span.RecordError(err)
+		return logic.Workflow{}, err
+	}
+
+	return logic.GetWorkflow(store, p.Key), nil
+}
+
+func (s *Service) SaveWorkflow(ctx context.Context, projectKey string, w logic.Workflow) (logic.Workflow, error) {
+	ctx, span, _ := s.begin(ctx, "SaveWorkflow")
+	defer span.End()
+
+	var saved logic.Workflow
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		saved, err = logic.SaveWorkflow(s.traced(ctx, tx), projectKey, w)
+		return err
+	})
+	span.RecordError(err)
+
+	return saved, err
+}
+
+func (s *Service) AddComment(ctx context.Context, issueID int, author, body string) (logic.Comment, error) {
+	_, span, store := s.begin(ctx, "AddComment")
+	defer span.End()
+
+	c, err := logic.AddComment(store, issueID, author, body, time.Now().UTC())
+	span.RecordError(err)
+
+	return c, err
+}
+
+func (s *Service) ListComments(ctx context.Context, issueID int) ([]logic.Comment, error) {
+	_, span, store := s.begin(ctx, "ListComments")
+	defer span.End()
+
+	comments, err := logic.ListComments(store, issueID)
+	span.RecordError(err)
+
+	return comments, err
+}
+
+// LogWork records time spent on an issue and publishes the issue with its
+// new remaining estimate.
+func (s *Service) LogWork(ctx context.Context, issueID int, author string, spent time.Duration, startedAt time.Time, comment string) (logic.Worklog, error) {
+	ctx, span, _ := s.begin(ctx, "LogWork")
+	defer span.End()
+
+	var w logic.Worklog
+	var issue logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		w, issue, err = logic.LogWork(s.traced(ctx, tx), issueID, author, spent, startedAt, comment, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Worklog{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueUpdated,
+		ProjectKey: issue.ProjectKey,
+		Issue:      issue,
+	})
+
+	return w, nil
+}
+
+func (s *Service) UpdateWorklog(ctx context.Context, id int, patch logic.WorklogPatch) (logic.Worklog, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateWorklog")
+	defer span.End()
+
+	var w logic.Worklog
+	var issue logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		w, issue, err = logic.UpdateWorklog(s.traced(ctx, tx), id, patch, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Worklog{}, err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueUpdated,
+		ProjectKey: issue.ProjectKey,
+		Issue:      issue,
+	})
+
+	return w, nil
+}
+
+func (s *Service) DeleteWorklog(ctx context.Context, id int) error {
+	ctx, span, _ := s.begin(ctx, "DeleteWorklog")
+	defer span.End()
+
+	var issue logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		issue, err = logic.DeleteWorklog(s.traced(ctx, tx), id)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	s.publish(ctx, events.Event{
+		Type:       events.IssueUpdated,
+		ProjectKey: issue.ProjectKey,
+		Issue:      issue,
+	})
+
+	return nil
+}
+
+func (s *Service) ListWorklogs(ctx context.Context, issueID int) ([]logic.Worklog, error) {
+	_, span, store := s.begin(ctx, "ListWorklogs")
+	defer span.End()
+
+	worklogs, err := logic.ListWorklogs(store, issueID)
+	span.RecordError(err)
+
+	return worklogs, err
+}
+
+// IssueTimeTracking sums an issue with its subtasks, or an epic with its
+// issues.
+func (s *Service) IssueTimeTracking(ctx context.Context, issueID int) (logic.TimeTracking, error) {
+	_, span, store := s.begin(ctx, "IssueTimeTracking")
+	defer span.End()
+
+	t, err := logic.IssueTimeTracking(store, issueID)
+	span.RecordError(err)
+
+	return t, err
+}
+
+func (s *Service) SprintTimeTracking(ctx context.Context, sprintID int) (logic.TimeTracking, error) {
+	_, span, store := s.begin(ctx, "SprintTimeTracking")
+	defer span.End()
+
+	t, err := logic.SprintTimeTracking(store, sprintID)
+	span.RecordError(err)
+
+	return t, err
+}
+
+func (s *Service) LinkIssues(ctx context.Context, linkType string, fromID, toID int) (logic.IssueLink, error) {
+	_, span, store := s.begin(ctx, "LinkIssues")
+	defer span.End()
+
+	l, err := logic.LinkIssues(store, linkType, fromID, toID)
+	span.RecordError(err)
+
+	return l, err
+}
+
+func (s *Service) ListLinks(ctx context.Context, issueID int) ([]logic.IssueLink, error) {
+	_, span, store := s.begin(ctx, "ListLinks")
+	defer span.End()
+
+	links, err := logic.ListLinks(store, issueID)
+	span.RecordError(err)
+
+	return links, err
+}
+
+func (s *Service) ListHistory(ctx context.Context, issueID int) ([]logic.StatusChange, error) {
+	_, span, store := s.begin(ctx, "ListHistory")
+	defer span.End()
+
+	history, err := logic.ListHistory(store, issueID)
+	span.RecordError(err)
+
+	return history, err
+}
+
+// FlowReport computes time in status and cycle and lead time of the
+// issues matching q over [from, to) from a consistent view of the project.
+func (s *Service) FlowReport(ctx context.Context, q logic.IssueQuery, from, to time.Time) (logic.FlowReport, error) {
+	ctx, span, _ := s.begin(ctx, "FlowReport")
+	defer span.End()
+
+	var rep logic.FlowReport
+	now := time.Now().UTC()
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		rep, err = logic.FlowMetrics(s.traced(ctx, tx), q, from, to, now)
+		return err
+	})
+	span.RecordError(err)
+
+	return rep, err
+}
+
+// CumulativeFlow counts the issues matching q per status for each day in
+// [from, to) from a consistent view of the project.
+func (s *Service) CumulativeFlow(ctx context.Context, q logic.IssueQuery, from, to time.Time) (logic.CumulativeFlow, error) {
+	ctx, span, _ := s.begin(ctx, "CumulativeFlow")
+	defer span.End()
+
+	var rep logic.CumulativeFlow
+	now := time.Now()
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		rep, err = logic.CumulativeFlowReport(s.traced(ctx, tx), q, from, to, now)
+		return err
+	})
+	span.RecordError(err)
+
+	return rep, err
+}
+
+func (s *Service) CreateSprint(ctx context.Context, projectKey, name string) (logic.Sprint, error) {
+	_, span, store := s.begin(ctx, "CreateSprint")
+	defer span.End()
+
+	created, err := logic.CreateSprint(store, projectKey, name)
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) ListSprints(ctx context.Context, projectKey string) ([]logic.Sprint, error) {
+	_, span, store := s.begin(ctx, "ListSprints")
+	defer span.End()
+
+	sprints, err := logic.ListSprints(store, projectKey)
+	span.RecordError(err)
+
+	return sprints, err
+}
+
+// StartSprint runs in a transaction so that two sprints of a project
+// cannot become active at once.
+func (s *Service) StartSprint(ctx context.Context, id int, endsAt time.Time) (logic.Sprint, error) {
+	ctx, span, _ := s.begin(ctx, "StartSprint")
+	defer span.End()
+
+	var started logic.Sprint
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		started, err = logic.StartSprint(s.traced(ctx, tx), id, endsAt, time.Now().UTC())
+		return err
+	})
+	span.RecordError(err)
+
+	return started, err
+}
+
+// CloseSprint closes the sprint and moves its unfinished issues in one
+// transaction; board clients get an update per moved issue.
+func (s *Service) CloseSprint(ctx context.Context, id, moveTo int) (logic.Sprint, error) {
+	ctx, span, _ := s.begin(ctx, "CloseSprint")
+	defer span.End()
+
+	var closed logic.Sprint
+	var moved []logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		closed, moved, err = logic.CloseSprint(s.traced(ctx, tx), id, moveTo, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Sprint{}, err
+	}
+
+	for _, issue := range moved {
+		s.publish(ctx, events.Event{
+			Type:       events.IssueUpdated,
+			ProjectKey: issue.ProjectKey,
+			Issue:      issue,
+		})
+	}
+
+	return closed, nil
+}
+
+func (s *Service) SprintBurndown(ctx context.Context, projectKey string, sprintID int, unit string) (logic.Burndown, error) {
+	ctx, span, _ := s.begin(ctx, "SprintBurndown")
+	defer span.End()
+
+	var rep logic.Burndown
+	now := time.Now().UTC()
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		rep, err = logic.SprintBurndown(s.traced(ctx, tx), projectKey, sprintID, unit, now)
+		return err
+	})
+	span.RecordError(err)
+
+	return rep, err
+}
+
+func (s *Service) SprintVelocity(ctx context.Context, projectKey string, sprints int) (logic.Velocity, error) {
+	_, span, store := s.begin(ctx, "SprintVelocity")
+	defer span.End()
+
+	rep, err := logic.SprintVelocity(store, projectKey, sprints)
+	span.RecordError(err)
+
+	return rep, err
+}
+
+func (s *Service) CreateComponent(ctx context.Context, projectKey, name, description, lead string) (logic.Component, error) {
+	ctx, span, _ := s.begin(ctx, "CreateComponent")
+	defer span.End()
+
+	var created logic.Component
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateComponent(s.traced(ctx, tx), projectKey, name, description, lead)
+		return err
+	})
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) GetComponent(ctx context.Context, id int) (logic.Component, error) {
+	_, span, store := s.begin(ctx, "GetComponent")
+	defer span.End()
+
+	c, err := logic.GetComponent(store, id)
+	span.RecordError(err)
+
+	return c, err
+}
+
+func (s *Service) ListComponents(ctx context.Context, projectKey string) ([]logic.Component, error) {
+	_, span, store := s.begin(ctx, "ListComponents")
+	defer span.End()
+
+	components, err := logic.ListComponents(store, projectKey)
+	span.RecordError(err)
+
+	return components, err
+}
+
+func (s *Service) UpdateComponent(ctx context.Context, id int, patch logic.ComponentPatch) (logic.Component, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateComponent")
+	defer span.End()
+
+	var updated logic.Component
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateComponent(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	span.RecordError(err)
+
+	return updated, err
+}
+
+func (s *Service) CreateCustomField(ctx context.Context, projectKey string, f logic.CustomField) (logic.CustomField, error) {
+	ctx, span, _ := s.begin(ctx, "CreateCustomField")
+	defer span.End()
+
+	var created logic.CustomField
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateCustomField(s.traced(ctx, tx), projectKey, f)
+		return err
+	})
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) GetCustomField(ctx context.Context, id int) (logic.CustomField, error) {
+	_, span, store := s.begin(ctx, "GetCustomField")
+	defer span.End()
+
+	f, err := logic.GetCustomField(store, id)
+	span.RecordError(err)
+
+	return f, err
+}
+
+func (s *Service) ListCustomFields(ctx context.Context, projectKey string) ([]logic.CustomField, error) {
+	_, span, store := s.begin(ctx, "ListCustomFields")
+	defer span.End()
+
+	fields, err := logic.ListCustomFields(store, projectKey)
+	span.RecordError(err)
+
+	return fields, err
+}
+
+func (s *Service) UpdateCustomField(ctx context.Context, id int, patch logic.CustomFieldPatch) (logic.CustomField, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateCustomField")
+	defer span.End()
+
+	var updated logic.CustomField
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateCustomField(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	span.RecordError(err)
+
+	return updated, err
+}
+
+// DeleteCustomField removes the field and its values in one transaction.
+func (s *Service) DeleteCustomField(ctx context.Context, id int) error {
+	ctx, span, _ := s.begin(ctx, "DeleteCustomField")
+	defer span.End()
+
+	var cleared []logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		cleared, err = logic.DeleteCustomField(s.traced(ctx, tx), id)
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+
+	for _, issue := range cleared {
+		s.publish(ctx, events.Event{
+			Type:       events.IssueUpdated,
+			ProjectKey: issue.ProjectKey,
+			Issue:      issue,
+		})
+	}
+
+	return nil
+}
+
+func (s *Service) CreateRule(ctx context.Context, projectKey string, r logic.Rule) (logic.Rule, error) {
+	ctx, span, _ := s.begin(ctx, "CreateRule")
+	defer span.End()
+
+	var created logic.Rule
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateRule(s.traced(ctx, tx), projectKey, r)
+		return err
+	})
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) GetRule(ctx context.Context, id int) (logic.Rule, error) {
+	_, span, store := s.begin(ctx, "GetRule")
+	defer span.End()
+
+	r, err := logic.GetRule(store, id)
+	span.RecordError(err)
+
+	return r, err
+}
+
+func (s *Service) ListRules(ctx context.Context, projectKey string) ([]logic.Rule, error) {
+	_, span, store := s.begin(ctx, "ListRules")
+	defer span.End()
+
+	rules, err := logic.ListRules(store, projectKey)
+	span.RecordError(err)
+
+	return rules, err
+}
+
+func (s *Service) UpdateRule(ctx context.Context, id int, patch logic.RulePatch) (logic.Rule, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateRule")
+	defer span.End()
+
+	var updated logic.Rule
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateRule(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	span.RecordError(err)
+
+	return updated, err
+}
+
+func (s *Service) DeleteRule(ctx context.Context, id int) error {
+	ctx, span, _ := s.begin(ctx, "DeleteRule")
+	defer span.End()
+
+	err := s.store.Tx(func(tx logic.Store) error {
+		return logic.DeleteRule(s.traced(ctx, tx), id)
+	})
+	span.RecordError(err)
+
+	return err
+}
+
+func (s *Service) RecordRuleExecution(ctx context.Context, x logic.RuleExecution) logic.RuleExecution {
+	_, span, store := s.begin(ctx, "RecordRuleExecution")
+	defer span.End()
+
+	return logic.RecordRuleExecution(store, x)
+}
+
+func (s *Service) ListRuleExecutions(ctx context.Context, projectKey string, limit int) ([]logic.RuleExecution, error) {
+	_, span, store := s.begin(ctx, "ListRuleExecutions")
+	defer span.End()
+
+	log, err := logic.ListRuleExecutions(store, projectKey, limit)
+	span.RecordError(err)
+
+	return log, err
+}
+
+// DoneParent returns the parent of an issue once all of its subtasks are
+// done and the parent itself is not.
+func (s *Service) DoneParent(ctx context.Context, issueID int) (logic.Issue, bool, error) {
+	_, span, store := s.begin(ctx, "DoneParent")
+	defer span.End()
+
+	parent, ok, err := logic.DoneParent(store, issueID)
+	span.RecordError(err)
+
+	return parent, ok, err
+}
+
+func (s *Service) CreateSLAPolicy(ctx context.Context, projectKey string, p logic.SLAPolicy) (logic.SLAPolicy, error) {
+	ctx, span, _ := s.begin(ctx, "CreateSLAPolicy")
+	defer span.End()
+
+	var created logic.SLAPolicy
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateSLAPolicy(s.traced(ctx, tx), projectKey, p)
+		return err
+	})
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) GetSLAPolicy(ctx context.Context, id int) (logic.SLAPolicy, error) {
+	_, span, store := s.begin(ctx, "GetSLAPolicy")
+	defer span.End()
+
+	p, err := logic.GetSLAPolicy(store, id)
+	span.RecordError(err)
+
+	return p, err
+}
+
+func (s *Service) ListSLAPolicies(ctx context.Context, projectKey string) ([]logic.SLAPolicy, error) {
+	_, span, store := s.begin(ctx, "ListSLAPolicies")
+	defer span.End()
+
+	policies, err := logic.ListSLAPolicies(store, projectKey)
+	span.RecordError(err)
+
+	return policies, err
+}
+
+func (s *Service) UpdateSLAPolicy(ctx context.Context, id int, patch logic.SLAPatch) (logic.SLAPolicy, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateSLAPolicy")
+	defer span.End()
+
+	var updated logic.SLAPolicy
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateSLAPolicy(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	span.RecordError(err)
+
+	return updated, err
+}
+
+func (s *Service) DeleteSLAPolicy(ctx context.Context, id int) error {
+	ctx, span, _ := s.begin(ctx, "DeleteSLAPolicy")
+	defer span.End()
+
+	err := s.store.Tx(func(tx logic.Store) error {
+		return logic.DeleteSLAPolicy(s.traced(ctx, tx), id)
+	})
+	span.RecordError(err)
+
+	return err
+}
+
+// SLATimers returns the SLA timers of the issues as of now, by issue ID.
+func (s *Service) SLATimers(ctx context.Context, issues ...logic.Issue) map[int][]logic.SLATimer {
+	_, span, store := s.begin(ctx, "SLATimers")
+	defer span.End()
+
+	return logic.SLATimers(store, issues, time.Now().UTC())
+}
+
+// CheckSLAs publishes an sla.breached event for every SLA timer that went
+// over its target since the last check, and returns how many it published.
+func (s *Service) CheckSLAs(ctx context.Context) (int, error) {
+	ctx, span, _ := s.begin(ctx, "CheckSLAs")
+	defer span.End()
+
+	var breaches []logic.BreachedSLA
+	now := time.Now().UTC()
+	err := s.store.Tx(func(tx logic.Store) error {
+		breaches = logic.RecordSLABreaches(s.traced(ctx, tx), now)
+		return nil
+	})
+	span.RecordError(err)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, b := range breaches {
+		s.publish(ctx, events.Event{
+			Type:       events.SLABreached,
+			ProjectKey: b.Issue.ProjectKey,
+			Issue:      b.Issue,
+			SLA:        &b.Timer,
+			At:         now,
+		})
+	}
+
+	return len(breaches), nil
+}
+
+func (s *Service) CreateVersion(ctx context.Context, projectKey, name, description string, releaseDate time.Time) (logic.Version, error) {
+	ctx, span, _ := s.begin(ctx, "CreateVersion")
+	defer span.End()
+
+	var created logic.Version
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		created, err = logic.CreateVersion(s.traced(ctx, tx), projectKey, name, description, releaseDate)
+		return err
+	})
+	span.RecordError(err)
+
+	return created, err
+}
+
+func (s *Service) GetVersion(ctx context.Context, id int) (logic.Version, error) {
+	_, span, store := s.begin(ctx, "GetVersion")
+	defer span.End()
+
+	v, err := logic.GetVersion(store, id)
+	span.RecordError(err)
+
+	return v, err
+}
+
+func (s *Service) ListVersions(ctx context.Context, projectKey string) ([]logic.Version, error) {
+	_, span, store := s.begin(ctx, "ListVersions")
+	defer span.End()
+
+	versions, err := logic.ListVersions(store, projectKey)
+	span.RecordError(err)
+
+	return versions, err
+}
+
+func (s *Service) UpdateVersion(ctx context.Context, id int, patch logic.VersionPatch) (logic.Version, error) {
+	ctx, span, _ := s.begin(ctx, "UpdateVersion")
+	defer span.End()
+
+	var updated logic.Version
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		updated, err = logic.UpdateVersion(s.traced(ctx, tx), id, patch)
+		return err
+	})
+	span.RecordError(err)
+
+	return updated, err
+}
+
+// ReleaseVersion releases a version, moving its unfinished issues to
+// moveTo, and publishes the moved issues.
+func (s *Service) ReleaseVersion(ctx context.Context, id, moveTo int, releaseDate time.Time) (logic.Version, error) {
+	ctx, span, _ := s.begin(ctx, "ReleaseVersion")
+	defer span.End()
+
+	var released logic.Version
+	var moved []logic.Issue
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		released, moved, err = logic.ReleaseVersion(s.traced(ctx, tx), id, moveTo, releaseDate, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		return logic.Version{}, err
+	}
+
+	for _, issue := range moved {
+		s.publish(ctx, events.Event{
+			Type:       events.IssueUpdated,
+			ProjectKey: issue.ProjectKey,
+			Issue:      issue,
+		})
+	}
+
+	return released, nil
+}
+
+func (s *Service) ArchiveVersion(ctx context.Context, id int) (logic.Version, error) {
+	ctx, span, _ := s.begin(ctx, "ArchiveVersion")
+	defer span.End()
+
+	var archived logic.Version
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		archived, err = logic.ArchiveVersion(s.traced(ctx, tx), id)
+		return err
+	})
+	span.RecordError(err)
+
+	return archived, err
+}
+
+func (s *Service) ReleaseNotes(ctx context.Context, id int) (logic.ReleaseNotes, error) {
+	ctx, span, _ := s.begin(ctx, "ReleaseNotes")
+	defer span.End()
+
+	var notes logic.ReleaseNotes
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		notes, err = logic.GetReleaseNotes(s.traced(ctx, tx), id)
+		return err
+	})
+	span.RecordError(err)
+
+	return notes, err
+}
+
+func (s *Service) FindIssues(ctx context.Context, q logic.IssueQuery) ([]logic.Issue, error) {
+	_, span, store := s.begin(ctx, "FindIssues")
+	defer span.End()
+
+	issues, err := logic.FindIssues(store, q)
+	span.RecordError(err)
+
+	return issues, err
+}
+
+// BulkTarget selects the issues of a bulk operation: either explicit ids
+// or the result of a query.
+type BulkTarget struct {
+	IssueIDs []int
+	Query    *logic.IssueQuery
+}
+
+type BulkReport struct {
+	Results []logic.BulkResult
+	// Committed is false only when an atomic run was rolled back.
+	Committed bool
+}
+
+// Bulk applies action to every target issue. Without atomic each issue
+// succeeds or fails on its own; with atomic the first failure rolls back
+// the whole batch and every other item reports logic.ErrRolledBack.
+// Events are published only for changes that were committed.
+func (s *Service) Bulk(ctx context.Context, target BulkTarget, action logic.BulkAction, atomic bool) (BulkReport, error) {
+	ctx, span, store := s.begin(ctx, "Bulk")
+	defer span.End()
+	span.SetAttributes(
+		tracing.Attr("bulk.action", action.Action),
+		tracing.Attr("bulk.atomic", strconv.FormatBool(atomic)),
+	)
+
+	ids := target.IssueIDs
+	if target.Query != nil {
+		issues, err := logic.FindIssues(store, *target.Query)
+		if err != nil {
+			span.RecordError(err)
+			return BulkReport{}, err
+		}
+		ids = make([]int, len(issues))
+		for i, issue := range issues {
+			ids[i] = issue.ID
+		}
+	}
+
+	ids = uniqueIDs(ids)
+	if err := logic.ValidateBulk(action, ids); err != nil {
+		span.RecordError(err)
+		return BulkReport{}, err
+	}
+
+	report := BulkReport{Results: make([]logic.BulkResult, len(ids)), Committed: true}
+
+	now := time.Now().UTC()
+	if !atomic {
+		// Each issue still gets its own transaction, so a transition and
+		// its history entry are stored together.
+		for i, id := range ids {
+			_ = s.store.Tx(func(tx logic.Store) error {
+				report.Results[i] = logic.ApplyBulk(s.traced(ctx, tx), id, action, now)
+				return report.Results[i].Err
+			})
+		}
+		s.publishBulk(ctx, report.Results)
+		return report, nil
+	}
+
+	failed := -1
+	err := s.store.Tx(func(tx logic.Store) error {
+		tx = s.traced(ctx, tx)
+		for i, id := range ids {
+			report.Results[i] = logic.ApplyBulk(tx, id, action, now)
+			if report.Results[i].Err != nil {
+				failed = i
+				return report.Results[i].Err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		report.Committed = false
+		for i := range report.Results {
+			if i != failed {
+				report.Results[i] = logic.BulkResult{IssueID: ids[i], Err: logic.ErrRolledBack}
+			}
+		}
+		return report, nil
+	}
+
+	s.publishBulk(ctx, report.Results)
+	return report, nil
+}
+
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+
+	return res
+}
+
+func (s *Service) publishBulk(ctx context.Context, results []logic.BulkResult) {
+	for _, r := range results {
+		if r.Err != nil {
+			continue
+		}
+
+		e := events.Event{
+			Type:       events.IssueUpdated,
+			ProjectKey: r.Issue.ProjectKey,
+			Issue:      r.Issue,
+		}
+		if r.Issue.Status != r.Before.Status {
+			e.Type = events.IssueTransitioned
+			e.FromStatus = r.Before.Status
+			e.ToStatus = r.Issue.Status
+		}
+		s.publish(ctx, e)
+	}
+}
+
+// publish sends e with the automation rules acting in ctx, so that rule
+// chains can be followed from one event to the next.
+func (s *Service) publish(ctx context.Context, e events.Event) {
+	e.Rules = events.RulesFrom(ctx)
+	s.events.Publish(e)
+}
+
+// ExportProject returns a consistent snapshot of a project, its sprints,
+// components, versions and custom fields, and its issues in board order.
+func (s *Service) ExportProject(ctx context.Context, key string) (archive.Snapshot, error) {
+	ctx, span, _ := s.begin(ctx, "ExportProject")
+	defer span.End()
+
+	var snap archive.Snapshot
+	err := s.store.Tx(func(tx logic.Store) error {
+		tx = s.traced(ctx, tx)
+
+		p, err := logic.GetProject(tx, key)
+		if err != nil {
+			return err
+		}
+
+		snap.Project = p
+		if wf, ok := tx.GetWorkflow(p.Key); ok {
+			snap.Workflow = &wf
+		}
+		snap.Components = tx.ListComponentsByProjectKey(p.Key)
+		snap.Versions = tx.ListVersionsByProjectKey(p.Key)
+		snap.CustomFields = tx.ListCustomFieldsByProjectKey(p.Key)
+		snap.Sprints = tx.ListSprintsByProjectKey(p.Key)
+		snap.Issues, err = logic.FindIssues(tx, logic.IssueQuery{ProjectKey: p.Key})
+		if err != nil {
+			return err
+		}
+
+		inProject := make(map[int]bool, len(snap.Issues))
+		for _, i := range snap.Issues {
+			inProject[i.ID] = true
+		}
+
+		snap.Comments = make(map[int][]logic.Comment)
+		snap.History = make(map[int][]logic.StatusChange)
+		snap.Worklogs = make(map[int][]logic.Worklog)
+		for _, i := range snap.Issues {
+			snap.Comments[i.ID] = tx.ListCommentsByIssueID(i.ID)
+			snap.History[i.ID] = tx.ListStatusChanges(i.ID)
+			snap.Worklogs[i.ID] = tx.ListWorklogsByIssueID(i.ID)
+			for _, l := range tx.ListLinksByIssueID(i.ID) {
+				// Each link is seen from both ends; keep it once, from its source.
+				if l.FromID == i.ID && inProject[l.ToID] {
+					snap.Links = append(snap.Links, l)
+				}
+			}
+		}
+
+		return nil
+	})
+	span.RecordError(err)
+
+	return snap, err
+}
+
+var errDryRun = errors.New("dry run")
+
+// ImportProject recreates an archived project in one transaction. A dry
+// run performs the same checks and writes, then rolls them back, so its
+// report shows the IDs a real import would assign at that moment.
+func (s *Service) ImportProject(ctx context.Context, a archive.Archive, opts archive.Options, dryRun bool) (archive.Report, error) {
+	ctx, span, _ := s.begin(ctx, "ImportProject")
+	defer span.End()
+	span.SetAttributes(tracing.Attr("import.dry_run", strconv.FormatBool(dryRun)))
+
+	var rep archive.Report
+	err := s.store.Tx(func(tx logic.Store) error {
+		var err error
+		rep, err = archive.Import(s.traced(ctx, tx), a, opts)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		return rep, nil
+	}
+	if err != nil {
+		span.RecordError(err)
+		return archive.Report{}, err
+	}
+
+	for _, issue := range rep.Issues {
+		s.publish(ctx, events.Event{
+			Type:       events.IssueCreated,
+			ProjectKey: issue.ProjectKey,
+			Issue:      issue,
+			ToStatus:   issue.Status,
+		})
+	}
+
+	return rep, nil
+}
+
+func (s *Service) Subscribe(buffer int, filter func(events.Event) bool) *events.Subscription {
+	return s.events.Subscribe(buffer, filter)
 }
 
-func (s *Service) GetIssue(id int) (logic.Issue, error) {
-	return logic.GetIssue(s.issueStore, id)
+func (s *Service) SubscribeQueued(filter func(events.Event) bool) *events.Subscription {
+	return s.events.SubscribeQueued(filter)
 }
 
-func (s *Service) TransitionIssue(issueID int, toStatus string) (logic.Issue, error) {
-	return logic.TransitionIssue(s.issueStore, issueID, toStatus)
+func (s *Service) Listen(fn func(events.Event)) {
+	s.events.Listen(fn)
 }
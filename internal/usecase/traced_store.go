@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"MiniJira/internal/logic"
+	"MiniJira/internal/tracing"
+	"context"
+	"strconv"
+)
+
+// tracedStore wraps the store for the duration of one service call and
+// records a span per store method. Methods not overridden here pass
+// through untraced via the embedded interface.
+type tracedStore struct {
+	logic.Store
+	ctx    context.Context
+	tracer *tracing.Tracer
+}
+
+func (t *tracedStore) span(op string, attrs ...tracing.Attribute) *tracing.Span {
+	attrs = append(attrs, tracing.Attr("db.system", "memory"), tracing.Attr("db.operation", op))
+	_, span := t.tracer.Start(t.ctx, "store."+op, tracing.KindClient, attrs...)
+	return span
+}
+
+func (t *tracedStore) GetByKey(key string) (logic.Project, bool) {
+	span := t.span("GetByKey", tracing.Attr("project.key", key))
+	defer span.End()
+
+	return t.Store.GetByKey(key)
+}
+
+func (t *tracedStore) CreateProject(p logic.Project) logic.Project {
+	span := t.span("CreateProject", tracing.Attr("project.key", p.Key))
+	defer span.End()
+
+	return t.Store.CreateProject(p)
+}
+
+func (t *tracedStore) List() []logic.Project {
+	span := t.span("List")
+	defer span.End()
+
+	return t.Store.List()
+}
+
+func (t *tracedStore) CreateIssue(i logic.Issue) logic.Issue {
+	span := t.span("CreateIssue", tracing.Attr("project.key", i.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateIssue(i)
+}
+
+func (t *tracedStore) GetIssueByID(id int) (logic.Issue, bool) {
+	span := t.span("GetIssueByID", tracing.Attr("issue.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetIssueByID(id)
+}
+
+func (t *tracedStore) UpdateIssueStatus(id int, newStatus string) (logic.Issue, bool) {
+	span := t.span("UpdateIssueStatus", tracing.Attr("issue.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.UpdateIssueStatus(id, newStatus)
+}
+
+func (t *tracedStore) UpdateIssueRank(id int, rank int) (logic.Issue, bool) {
+	span := t.span("UpdateIssueRank", tracing.Attr("issue.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.UpdateIssueRank(id, rank)
+}
+
+func (t *tracedStore) UpdateIssue(i logic.Issue) (logic.Issue, bool) {
+	span := t.span("UpdateIssue", tracing.Attr("issue.id", strconv.Itoa(i.ID)))
+	defer span.End()
+
+	return t.Store.UpdateIssue(i)
+}
+
+func (t *tracedStore) ListIssuesByProjectKey(projectKey string) []logic.Issue {
+	span := t.span("ListIssuesByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListIssuesByProjectKey(projectKey)
+}
+
+func (t *tracedStore) MaxIssueRank(projectKey string) int {
+	span := t.span("MaxIssueRank", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.MaxIssueRank(projectKey)
+}
+
+func (t *tracedStore) CreateSprint(sp logic.Sprint) logic.Sprint {
+	span := t.span("CreateSprint", tracing.Attr("project.key", sp.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateSprint(sp)
+}
+
+func (t *tracedStore) GetSprintByID(id int) (logic.Sprint, bool) {
+	span := t.span("GetSprintByID", tracing.Attr("sprint.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetSprintByID(id)
+}
+
+func (t *tracedStore) UpdateSprint(sp logic.Sprint) (logic.Sprint, bool) {
+	span := t.span("UpdateSprint", tracing.Attr("sprint.id", strconv.Itoa(sp.ID)))
+	defer span.End()
+
+	return t.Store.UpdateSprint(sp)
+}
+
+func (t *tracedStore) ListSprintsByProjectKey(projectKey string) []logic.Sprint {
+	span := t.span("ListSprintsByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListSprintsByProjectKey(projectKey)
+}
+
+func (t *tracedStore) CreateComponent(c logic.Component) logic.Component {
+	span := t.span("CreateComponent", tracing.Attr("project.key", c.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateComponent(c)
+}
+
+func (t *tracedStore) GetComponentByID(id int) (logic.Component, bool) {
+	span := t.span("GetComponentByID", tracing.Attr("component.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetComponentByID(id)
+}
+
+func (t *tracedStore) UpdateComponent(c logic.Component) (logic.Component, bool) {
+	span := t.span("UpdateComponent", tracing.Attr("component.id", strconv.Itoa(c.ID)))
+	defer span.End()
+
+	return t.Store.UpdateComponent(c)
+}
+
+func (t *tracedStore) ListComponentsByProjectKey(projectKey string) []logic.Component {
+	span := t.span("ListComponentsByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListComponentsByProjectKey(projectKey)
+}
+
+func (t *tracedStore) CreateCustomField(f logic.CustomField) logic.CustomField {
+	span := t.span("CreateCustomField", tracing.Attr("project.key", f.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateCustomField(f)
+}
+
+func (t *tracedStore) GetCustomFieldByID(id int) (logic.CustomField, bool) {
+	span := t.span("GetCustomFieldByID", tracing.Attr("custom_field.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetCustomFieldByID(id)
+}
+
+func (t *tracedStore) UpdateCustomField(f logic.CustomField) (logic.CustomField, bool) {
+	span := t.span("UpdateCustomField", tracing.Attr("custom_field.id", strconv.Itoa(f.ID)))
+	defer span.End()
+
+	return t.Store.UpdateCustomField(f)
+}
+
+func (t *tracedStore) DeleteCustomField(id int) bool {
+	span := t.span("DeleteCustomField", tracing.Attr("custom_field.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.DeleteCustomField(id)
+}
+
+func (t *tracedStore) ListCustomFieldsByProjectKey(projectKey string) []logic.CustomField {
+	span := t.span("ListCustomFieldsByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListCustomFieldsByProjectKey(projectKey)
+}
+
+func (t *tracedStore) CreateRule(r logic.Rule) logic.Rule {
+	span := t.span("CreateRule", tracing.Attr("project.key", r.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateRule(r)
+}
+
+func (t *tracedStore) GetRuleByID(id int) (logic.Rule, bool) {
+	span := t.span("GetRuleByID", tracing.Attr("rule.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetRuleByID(id)
+}
+
+func (t *tracedStore) UpdateRule(r logic.Rule) (logic.Rule, bool) {
+	span := t.span("UpdateRule", tracing.Attr("rule.id", strconv.Itoa(r.ID)))
+	defer span.End()
+
+	return t.Store.UpdateRule(r)
+}
+
+func (t *tracedStore) DeleteRule(id int) bool {
+	span := t.span("DeleteRule", tracing.Attr("rule.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.DeleteRule(id)
+}
+
+func (t *tracedStore) ListRulesByProjectKey(projectKey string) []logic.Rule {
+	span := t.span("ListRulesByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListRulesByProjectKey(projectKey)
+}
+
+func (t *tracedStore) AddRuleExecution(x logic.RuleExecution) logic.RuleExecution {
+	span := t.span("AddRuleExecution", tracing.Attr("rule.id", strconv.Itoa(x.RuleID)))
+	defer span.End()
+
+	return t.Store.AddRuleExecution(x)
+}
+
+func (t *tracedStore) ListRuleExecutions(projectKey string) []logic.RuleExecution {
+	span := t.span("ListRuleExecutions", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListRuleExecutions(projectKey)
+}
+
+func (t *tracedStore) CreateSLAPolicy(p logic.SLAPolicy) logic.SLAPolicy {
+	span := t.span("CreateSLAPolicy", tracing.Attr("project.key", p.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateSLAPolicy(p)
+}
+
+func (t *tracedStore) GetSLAPolicyByID(id int) (logic.SLAPolicy, bool) {
+	span := t.span("GetSLAPolicyByID", tracing.Attr("sla.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetSLAPolicyByID(id)
+}
+
+func (t *tracedStore) UpdateSLAPolicy(p logic.SLAPolicy) (logic.SLAPolicy, bool) {
+	span := t.span("UpdateSLAPolicy", tracing.Attr("sla.id", strconv.Itoa(p.ID)))
+	defer span.End()
+
+	return t.Store.UpdateSLAPolicy(p)
+}
+
+func (t *tracedStore) DeleteSLAPolicy(id int) bool {
+	span := t.span("DeleteSLAPolicy", tracing.Attr("sla.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.DeleteSLAPolicy(id)
+}
+
+func (t *tracedStore) ListSLAPoliciesByProjectKey(projectKey string) []logic.SLAPolicy {
+	span := t.span("ListSLAPoliciesByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListSLAPoliciesByProjectKey(projectKey)
+}
+
+func (t *tracedStore) AddSLABreach(b logic.SLABreach) bool {
+	span := t.span("AddSLABreach", tracing.Attr("sla.id", strconv.Itoa(b.PolicyID)), tracing.Attr("issue.id", strconv.Itoa(b.IssueID)))
+	defer span.End()
+
+	return t.Store.AddSLABreach(b)
+}
+
+func (t *tracedStore) CreateVersion(v logic.Version) logic.Version {
+	span := t.span("CreateVersion", tracing.Attr("project.key", v.ProjectKey))
+	defer span.End()
+
+	return t.Store.CreateVersion(v)
+}
+
+func (t *tracedStore) GetVersionByID(id int) (logic.Version, bool) {
+	span := t.span("GetVersionByID", tracing.Attr("version.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetVersionByID(id)
+}
+
+func (t *tracedStore) UpdateVersion(v logic.Version) (logic.Version, bool) {
+	span := t.span("UpdateVersion", tracing.Attr("version.id", strconv.Itoa(v.ID)))
+	defer span.End()
+
+	return t.Store.UpdateVersion(v)
+}
+
+func (t *tracedStore) ListVersionsByProjectKey(projectKey string) []logic.Version {
+	span := t.span("ListVersionsByProjectKey", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.ListVersionsByProjectKey(projectKey)
+}
+
+func (t *tracedStore) CreateComment(c logic.Comment) logic.Comment {
+	span := t.span("CreateComment", tracing.Attr("issue.id", strconv.Itoa(c.IssueID)))
+	defer span.End()
+
+	return t.Store.CreateComment(c)
+}
+
+func (t *tracedStore) ListCommentsByIssueID(issueID int) []logic.Comment {
+	span := t.span("ListCommentsByIssueID", tracing.Attr("issue.id", strconv.Itoa(issueID)))
+	defer span.End()
+
+	return t.Store.ListCommentsByIssueID(issueID)
+}
+
+func (t *tracedStore) CreateWorklog(w logic.Worklog) logic.Worklog {
+	span := t.span("CreateWorklog", tracing.Attr("issue.id", strconv.Itoa(w.IssueID)))
+	defer span.End()
+
+	return t.Store.CreateWorklog(w)
+}
+
+func (t *tracedStore) GetWorklogByID(id int) (logic.Worklog, bool) {
+	span := t.span("GetWorklogByID", tracing.Attr("worklog.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.GetWorklogByID(id)
+}
+
+func (t *tracedStore) UpdateWorklog(w logic.Worklog) (logic.Worklog, bool) {
+	span := t.span("UpdateWorklog", tracing.Attr("worklog.id", strconv.Itoa(w.ID)))
+	defer span.End()
+
+	return t.Store.UpdateWorklog(w)
+}
+
+func (t *tracedStore) DeleteWorklog(id int) bool {
+	span := t.span("DeleteWorklog", tracing.Attr("worklog.id", strconv.Itoa(id)))
+	defer span.End()
+
+	return t.Store.DeleteWorklog(id)
+}
+
+func (t *tracedStore) ListWorklogsByIssueID(issueID int) []logic.Worklog {
+	span := t.span("ListWorklogsByIssueID", tracing.Attr("issue.id", strconv.Itoa(issueID)))
+	defer span.End()
+
+	return t.Store.ListWorklogsByIssueID(issueID)
+}
+
+func (t *tracedStore) AddStatusChange(c logic.StatusChange) {
+	span := t.span("AddStatusChange", tracing.Attr("issue.id", strconv.Itoa(c.IssueID)))
+	defer span.End()
+
+	t.Store.AddStatusChange(c)
+}
+
+func (t *tracedStore) ListStatusChanges(issueID int) []logic.StatusChange {
+	span := t.span("ListStatusChanges", tracing.Attr("issue.id", strconv.Itoa(issueID)))
+	defer span.End()
+
+	return t.Store.ListStatusChanges(issueID)
+}
+
+func (t *tracedStore) CreateLink(l logic.IssueLink) logic.IssueLink {
+	span := t.span("CreateLink", tracing.Attr("issue.id", strconv.Itoa(l.FromID)))
+	defer span.End()
+
+	return t.Store.CreateLink(l)
+}
+
+func (t *tracedStore) ListLinksByIssueID(issueID int) []logic.IssueLink {
+	span := t.span("ListLinksByIssueID", tracing.Attr("issue.id", strconv.Itoa(issueID)))
+	defer span.End()
+
+	return t.Store.ListLinksByIssueID(issueID)
+}
+
+func (t *tracedStore) GetWorkflow(projectKey string) (logic.Workflow, bool) {
+	span := t.span("GetWorkflow", tracing.Attr("project.key", projectKey))
+	defer span.End()
+
+	return t.Store.GetWorkflow(projectKey)
+}
+
+func (t *tracedStore) SaveWorkflow(w logic.Workflow) logic.Workflow {
+	span := t.span("SaveWorkflow", tracing.Attr("project.key", w.ProjectKey))
+	defer span.End()
+
+	return t.Store.SaveWorkflow(w)
+}